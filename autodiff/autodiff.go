@@ -0,0 +1,75 @@
+package autodiff
+
+import (
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+)
+
+type Function func(xs []HyperDual) HyperDual
+
+type DualFunction func(xs []Dual) Dual
+
+func variables(xs []float64) []HyperDual {
+	vars := make([]HyperDual, len(xs))
+	for i, x := range xs {
+		vars[i] = HyperDual{Real: x}
+	}
+	return vars
+}
+
+func Func(f Function) func(xs []float64) float64 {
+	return func(xs []float64) float64 {
+		return f(variables(xs)).Real
+	}
+}
+
+func Gradient(f Function, dimension int) []func(xs []float64) float64 {
+	gradient := make([]func(xs []float64) float64, dimension)
+	for i := 0; i < dimension; i++ {
+		i := i
+		gradient[i] = func(xs []float64) float64 {
+			vars := variables(xs)
+			vars[i].E1 = 1
+			return f(vars).E1
+		}
+	}
+	return gradient
+}
+
+func Hessian(f Function, dimension int) func(xs []float64) la_methods.Matrix {
+	return func(xs []float64) la_methods.Matrix {
+		var hessian la_methods.Matrix
+		hessian.Init(dimension, dimension)
+		for i := 0; i < dimension; i++ {
+			for j := i; j < dimension; j++ {
+				vars := variables(xs)
+				vars[i].E1 = 1
+				vars[j].E2 = 1
+				hessian.Points[i][j] = f(vars).E1E2
+				hessian.Points[j][i] = hessian.Points[i][j]
+			}
+		}
+		return hessian
+	}
+}
+
+func DualGradient(f DualFunction, dimension int) []func(xs []float64) float64 {
+	gradient := make([]func(xs []float64) float64, dimension)
+	for i := 0; i < dimension; i++ {
+		i := i
+		gradient[i] = func(xs []float64) float64 {
+			vars := make([]Dual, len(xs))
+			for j, x := range xs {
+				vars[j] = Dual{Real: x}
+			}
+			vars[i].Eps = 1
+			return f(vars).Eps
+		}
+	}
+	return gradient
+}
+
+func Derivative(f func(x Dual) Dual) func(x float64) float64 {
+	return func(x float64) float64 {
+		return f(Dual{Real: x, Eps: 1}).Eps
+	}
+}
@@ -0,0 +1,91 @@
+package autodiff
+
+import (
+	"math"
+	"testing"
+)
+
+func hyperDualRosenbrock(xs []HyperDual) HyperDual {
+	a := xs[1].Sub(xs[0].Mul(xs[0]))
+	b := xs[0].Neg().AddK(1)
+	return a.Mul(a).MulK(100).Add(b.Mul(b))
+}
+
+func TestGradientAndHessian(t *testing.T) {
+	point := []float64{-1.2, 1}
+	x, y := point[0], point[1]
+	expectedGradient := []float64{-400*x*(y-x*x) - 2*(1-x), 200 * (y - x*x)}
+	expectedHessian := [][]float64{{1200*x*x - 400*y + 2, -400 * x}, {-400 * x, 200}}
+	if f := Func(hyperDualRosenbrock)(point); math.Abs(f-24.2) > 1e-12 {
+		t.Errorf("expected value 24.2, got %f", f)
+	}
+	for i, g := range Gradient(hyperDualRosenbrock, 2) {
+		if math.Abs(g(point)-expectedGradient[i]) > 1e-9 {
+			t.Errorf("gradient component %d: expected %f, got %f", i, expectedGradient[i], g(point))
+		}
+	}
+	hessian := Hessian(hyperDualRosenbrock, 2)(point)
+	for i := range expectedHessian {
+		for j := range expectedHessian[i] {
+			if math.Abs(hessian.Points[i][j]-expectedHessian[i][j]) > 1e-9 {
+				t.Errorf("hessian element %d, %d: expected %f, got %f", i, j, expectedHessian[i][j], hessian.Points[i][j])
+			}
+		}
+	}
+}
+
+func TestDualGradient(t *testing.T) {
+	f := func(xs []Dual) Dual {
+		return xs[0].Sin().Mul(xs[1].Exp()).Add(xs[0].Div(xs[1]).Log())
+	}
+	point := []float64{0.7, 1.3}
+	x, y := point[0], point[1]
+	expected := []float64{math.Cos(x)*math.Exp(y) + 1/x, math.Sin(x)*math.Exp(y) - 1/y}
+	for i, g := range DualGradient(f, 2) {
+		if math.Abs(g(point)-expected[i]) > 1e-12 {
+			t.Errorf("component %d: expected %f, got %f", i, expected[i], g(point))
+		}
+	}
+}
+
+func TestDerivativeElementaryFunctions(t *testing.T) {
+	cases := map[string]struct {
+		f        func(x Dual) Dual
+		expected func(x float64) float64
+	}{
+		"sqrt": {func(x Dual) Dual { return x.Sqrt() }, func(x float64) float64 { return 0.5 / math.Sqrt(x) }},
+		"pow":  {func(x Dual) Dual { return x.PowK(3.5) }, func(x float64) float64 { return 3.5 * math.Pow(x, 2.5) }},
+		"cos":  {func(x Dual) Dual { return x.Cos() }, func(x float64) float64 { return -math.Sin(x) }},
+		"inv":  {func(x Dual) Dual { return x.Inv() }, func(x float64) float64 { return -1 / (x * x) }},
+		"abs":  {func(x Dual) Dual { return x.Neg().Abs() }, func(x float64) float64 { return 1 }},
+	}
+	for name, c := range cases {
+		derivative := Derivative(c.f)
+		for _, x := range []float64{0.3, 1, 2.5} {
+			if math.Abs(derivative(x)-c.expected(x)) > 1e-12 {
+				t.Errorf("%s at %f: expected %f, got %f", name, x, c.expected(x), derivative(x))
+			}
+		}
+	}
+}
+
+func TestHyperDualSecondDerivatives(t *testing.T) {
+	cases := map[string]struct {
+		f      func(x HyperDual) HyperDual
+		second func(x float64) float64
+	}{
+		"exp":  {func(x HyperDual) HyperDual { return x.Exp() }, math.Exp},
+		"log":  {func(x HyperDual) HyperDual { return x.Log() }, func(x float64) float64 { return -1 / (x * x) }},
+		"sin":  {func(x HyperDual) HyperDual { return x.Sin() }, func(x float64) float64 { return -math.Sin(x) }},
+		"sqrt": {func(x HyperDual) HyperDual { return x.Sqrt() }, func(x float64) float64 { return -0.25 * math.Pow(x, -1.5) }},
+		"div":  {func(x HyperDual) HyperDual { return x.Div(x.Mul(x).AddK(1)) }, func(x float64) float64 { return 2 * x * (x*x - 3) / math.Pow(x*x+1, 3) }},
+	}
+	for name, c := range cases {
+		for _, x := range []float64{0.3, 1, 2.5} {
+			result := c.f(HyperDual{Real: x, E1: 1, E2: 1})
+			if math.Abs(result.E1E2-c.second(x)) > 1e-12 {
+				t.Errorf("%s at %f: expected %f, got %f", name, x, c.second(x), result.E1E2)
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package autodiff
+
+import (
+	"math"
+)
+
+type Dual struct {
+	Real float64
+	Eps  float64 // first derivative part
+}
+
+func (a Dual) apply(f float64, df float64) Dual {
+	return Dual{Real: f, Eps: df * a.Eps}
+}
+
+func (a Dual) Add(b Dual) Dual {
+	return Dual{Real: a.Real + b.Real, Eps: a.Eps + b.Eps}
+}
+
+func (a Dual) AddK(k float64) Dual {
+	return Dual{Real: a.Real + k, Eps: a.Eps}
+}
+
+func (a Dual) Sub(b Dual) Dual {
+	return Dual{Real: a.Real - b.Real, Eps: a.Eps - b.Eps}
+}
+
+func (a Dual) SubK(k float64) Dual {
+	return Dual{Real: a.Real - k, Eps: a.Eps}
+}
+
+func (a Dual) Neg() Dual {
+	return Dual{Real: -a.Real, Eps: -a.Eps}
+}
+
+func (a Dual) Mul(b Dual) Dual {
+	return Dual{Real: a.Real * b.Real, Eps: a.Real*b.Eps + a.Eps*b.Real}
+}
+
+func (a Dual) MulK(k float64) Dual {
+	return Dual{Real: a.Real * k, Eps: a.Eps * k}
+}
+
+func (a Dual) Div(b Dual) Dual {
+	return a.Mul(b.Inv())
+}
+
+func (a Dual) Inv() Dual {
+	return a.apply(1/a.Real, -1/(a.Real*a.Real))
+}
+
+func (a Dual) PowK(p float64) Dual {
+	return a.apply(math.Pow(a.Real, p), p*math.Pow(a.Real, p-1))
+}
+
+func (a Dual) Sqrt() Dual {
+	s := math.Sqrt(a.Real)
+	return a.apply(s, 0.5/s)
+}
+
+func (a Dual) Exp() Dual {
+	e := math.Exp(a.Real)
+	return a.apply(e, e)
+}
+
+func (a Dual) Log() Dual {
+	return a.apply(math.Log(a.Real), 1/a.Real)
+}
+
+func (a Dual) Sin() Dual {
+	return a.apply(math.Sin(a.Real), math.Cos(a.Real))
+}
+
+func (a Dual) Cos() Dual {
+	return a.apply(math.Cos(a.Real), -math.Sin(a.Real))
+}
+
+func (a Dual) Abs() Dual {
+	if a.Real < 0 {
+		return a.Neg()
+	}
+	return a
+}
@@ -0,0 +1,95 @@
+package autodiff
+
+import (
+	"math"
+)
+
+type HyperDual struct {
+	Real float64
+	E1   float64 // derivative along first direction
+	E2   float64 // derivative along second direction
+	E1E2 float64 // mixed second derivative
+}
+
+func (a HyperDual) apply(f float64, df float64, d2f float64) HyperDual {
+	return HyperDual{
+		Real: f,
+		E1:   df * a.E1,
+		E2:   df * a.E2,
+		E1E2: df*a.E1E2 + d2f*a.E1*a.E2,
+	}
+}
+
+func (a HyperDual) Add(b HyperDual) HyperDual {
+	return HyperDual{Real: a.Real + b.Real, E1: a.E1 + b.E1, E2: a.E2 + b.E2, E1E2: a.E1E2 + b.E1E2}
+}
+
+func (a HyperDual) AddK(k float64) HyperDual {
+	return HyperDual{Real: a.Real + k, E1: a.E1, E2: a.E2, E1E2: a.E1E2}
+}
+
+func (a HyperDual) Sub(b HyperDual) HyperDual {
+	return HyperDual{Real: a.Real - b.Real, E1: a.E1 - b.E1, E2: a.E2 - b.E2, E1E2: a.E1E2 - b.E1E2}
+}
+
+func (a HyperDual) SubK(k float64) HyperDual {
+	return HyperDual{Real: a.Real - k, E1: a.E1, E2: a.E2, E1E2: a.E1E2}
+}
+
+func (a HyperDual) Neg() HyperDual {
+	return HyperDual{Real: -a.Real, E1: -a.E1, E2: -a.E2, E1E2: -a.E1E2}
+}
+
+func (a HyperDual) Mul(b HyperDual) HyperDual {
+	return HyperDual{
+		Real: a.Real * b.Real,
+		E1:   a.Real*b.E1 + a.E1*b.Real,
+		E2:   a.Real*b.E2 + a.E2*b.Real,
+		E1E2: a.Real*b.E1E2 + a.E1*b.E2 + a.E2*b.E1 + a.E1E2*b.Real,
+	}
+}
+
+func (a HyperDual) MulK(k float64) HyperDual {
+	return HyperDual{Real: a.Real * k, E1: a.E1 * k, E2: a.E2 * k, E1E2: a.E1E2 * k}
+}
+
+func (a HyperDual) Div(b HyperDual) HyperDual {
+	return a.Mul(b.Inv())
+}
+
+func (a HyperDual) Inv() HyperDual {
+	return a.apply(1/a.Real, -1/(a.Real*a.Real), 2/(a.Real*a.Real*a.Real))
+}
+
+func (a HyperDual) PowK(p float64) HyperDual {
+	return a.apply(math.Pow(a.Real, p), p*math.Pow(a.Real, p-1), p*(p-1)*math.Pow(a.Real, p-2))
+}
+
+func (a HyperDual) Sqrt() HyperDual {
+	s := math.Sqrt(a.Real)
+	return a.apply(s, 0.5/s, -0.25/(s*a.Real))
+}
+
+func (a HyperDual) Exp() HyperDual {
+	e := math.Exp(a.Real)
+	return a.apply(e, e, e)
+}
+
+func (a HyperDual) Log() HyperDual {
+	return a.apply(math.Log(a.Real), 1/a.Real, -1/(a.Real*a.Real))
+}
+
+func (a HyperDual) Sin() HyperDual {
+	return a.apply(math.Sin(a.Real), math.Cos(a.Real), -math.Sin(a.Real))
+}
+
+func (a HyperDual) Cos() HyperDual {
+	return a.apply(math.Cos(a.Real), -math.Sin(a.Real), -math.Cos(a.Real))
+}
+
+func (a HyperDual) Abs() HyperDual {
+	if a.Real < 0 {
+		return a.Neg()
+	}
+	return a
+}
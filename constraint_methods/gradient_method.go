@@ -1,44 +1,56 @@
 package constraint_methods
 
 import (
+	"context"
 	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/finite_differences"
 	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
-	"github.com/saskamegaprogrammist/optimization_methods/one_dimension_search"
+	"github.com/saskamegaprogrammist/optimization_methods/line_search"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
 	"gonum.org/v1/gonum/mat"
 	"math"
 )
 
 type GradientMethod struct {
-	startPoint     []float64
-	dimension      int
-	targetFunc     func(xs []float64) float64
-	penalties      []func(xs []float64) float64
-	gradient       []func(xs []float64) float64
-	A              func(xs []float64) la_methods.Matrix
-	eps2           float64
-	eps1           float64
-	maxIter        int
-	method         string
-	svennAlgorithm one_dimension_search.Svenn
+	startPoint []float64
+	dimension  int
+	targetFunc func(xs []float64) float64
+	penalties  []func(xs []float64) float64
+	gradient   []func(xs []float64) float64
+	A          func(xs []float64) la_methods.Matrix
+	eps2       float64
+	eps1       float64
+	maxIter    int
+	lineSearch line_search.LineSearch
+	methodStats
 }
 
 func (gm *GradientMethod) Init(startPoint []float64, dimension int,
 	targetFunc func(xs []float64) float64, penalties []func(xs []float64) float64,
 	gradient []func(xs []float64) float64, A func(xs []float64) la_methods.Matrix,
-	eps1 float64, eps2 float64, M int, method string) {
+	eps1 float64, eps2 float64, M int, lineSearch line_search.LineSearch) {
 	gm.startPoint = startPoint
-	gm.targetFunc = targetFunc
+	gm.targetFunc = gm.countFunc(targetFunc)
 	gm.dimension = dimension
 	gm.penalties = penalties
 	gm.gradient = gradient
+	if gradient == nil {
+		var fd finite_differences.Differentiator
+		fd.Init(finite_differences.Central, 0)
+		gm.gradient = fd.Gradient(targetFunc, dimension)
+	}
 	gm.A = A
 	gm.maxIter = M
 	gm.eps2 = eps2
 	gm.eps1 = eps1
-	gm.method = method
+	gm.lineSearch = lineSearch
 }
 
 func (gm *GradientMethod) Solve() ([]float64, float64, error) {
+	return gm.SolveContext(context.Background())
+}
+
+func (gm *GradientMethod) SolveContext(ctx context.Context) ([]float64, float64, error) {
 	var err error
 	var x, deltaX, gradV, lambda la_methods.Vector
 	var A, AClean la_methods.Matrix
@@ -50,31 +62,30 @@ func (gm *GradientMethod) Solve() ([]float64, float64, error) {
 	var excluded []int
 	var grVal float64
 	var lastAlpha float64
-	var hasExcl, fib bool
-	var search one_dimension_search.OneDimensionSearchI
-	if gm.method == "break in two" {
-		search = &one_dimension_search.BreakInTwoSearch{}
-	} else if gm.method == "golden ratio" {
-		search = &one_dimension_search.GoldenRatioSearch{}
-	} else if gm.method == "fibonacci" {
-		fib = true
+	var hasExcl bool
+	if gm.lineSearch == nil {
+		return nil, 0, fmt.Errorf("one dimensional method is not set")
 	}
 	err = x.InitWithPoints(gm.dimension, gm.startPoint)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error during vector initializing: %v", err)
+		return nil, 0, fmt.Errorf("error during vector initializing: %w", err)
 	}
+	ctx, cancel := gm.start(ctx)
+	defer cancel()
 	lastAlpha = 0.1
 	for {
+		if gm.interrupted(ctx, k) {
+			return x.Points, gm.targetFunc(x.Points), nil
+		}
 		AClean, err = gm.getA(x, []int{})
 		if err != nil {
-			return nil, 0, fmt.Errorf("error getting A clean: %v", err)
+			return nil, 0, fmt.Errorf("error getting A clean: %w", err)
 		}
 		A, err = gm.getA(x, excluded)
 		if err != nil {
-			return nil, 0, fmt.Errorf("error getting A: %v", err)
+			return nil, 0, fmt.Errorf("error getting A: %w", err)
 		}
 		if k >= gm.maxIter {
-			fmt.Printf("k value: %d\n", k)
 			return x.Points, gm.targetFunc(x.Points), nil
 			//goto NINE
 		}
@@ -90,7 +101,7 @@ func (gm *GradientMethod) Solve() ([]float64, float64, error) {
 		if !has {
 			x, err = gm.getNewX(x, AClean)
 			if err != nil {
-				return nil, 0, fmt.Errorf("error getting new x: %v", err)
+				return nil, 0, fmt.Errorf("error getting new x: %w", err)
 			}
 		}
 		zero = true
@@ -102,18 +113,18 @@ func (gm *GradientMethod) Solve() ([]float64, float64, error) {
 		}
 		err = gradV.InitWithPoints(gm.dimension, grad)
 		if err != nil {
-			return nil, 0, fmt.Errorf("error initializing vector: %v", err)
+			return nil, 0, fmt.Errorf("error initializing vector: %w", err)
 		}
 		if zero {
 			if k == 0 && !(gm.penalties[0](x.Points) <= 0 &&
 				gm.penalties[1](x.Points) <= 0 && gm.penalties[2](x.Points) <= 0) {
-				return nil, 0, fmt.Errorf("select another starting point")
+				return nil, 0, fmt.Errorf("%w: start point violates constraints, select another starting point", optimization_errors.ErrInfeasible)
 			}
 			goto NINE
 		}
 		deltaX, err = gm.getDeltaX(A, gradV)
 		if err != nil {
-			return nil, 0, fmt.Errorf("error getting delta x: %v", err)
+			return nil, 0, fmt.Errorf("error getting delta x: %w", err)
 		}
 		if !(deltaX.Len() <= gm.eps2) {
 			goto TEN
@@ -121,7 +132,7 @@ func (gm *GradientMethod) Solve() ([]float64, float64, error) {
 	NINE:
 		lambda, err = gm.getLambda(A, gradV)
 		if err != nil {
-			return nil, 0, fmt.Errorf("error getting lambda: %v", err)
+			return nil, 0, fmt.Errorf("error getting lambda: %w", err)
 		}
 		stop = true
 		minIndex = 0
@@ -134,22 +145,18 @@ func (gm *GradientMethod) Solve() ([]float64, float64, error) {
 			}
 		}
 		if stop {
-			fmt.Printf("k value: %d\n", k)
 			return x.Points, gm.targetFunc(x.Points), nil
 		} else {
 			hasExcl = gm.excludeConstraints(minIndex, &excluded)
 		}
 	TEN:
 		tF := gm.getOneDimensionFunc(x, deltaX, gm.targetFunc)
-		if fib {
-			alphMin, err = gm.oneDimensionFibonacciSearch(lastAlpha, tF)
-		} else if search != nil {
-			alphMin, err = gm.oneDimensionSearch(lastAlpha, search, tF)
-		} else {
-			return nil, 0, fmt.Errorf("wrong search method")
-		}
+		alphMin, err = gm.lineSearch.Search(ctx, tF, nil, lastAlpha)
 		if err != nil {
-			return nil, 0, fmt.Errorf("error finding minimum: %v", err)
+			if gm.interrupted(ctx, k) {
+				return x.Points, gm.targetFunc(x.Points), nil
+			}
+			return nil, 0, fmt.Errorf("error finding minimum: %w", err)
 		}
 		//fmt.Println(alphMin)
 		if !hasExcl {
@@ -167,12 +174,14 @@ func (gm *GradientMethod) Solve() ([]float64, float64, error) {
 		aplhaM := deltaX.MulOnValue(minAlpha)
 		sumXAlphM, err := x.Add(aplhaM)
 		if err != nil {
-			return nil, 0, fmt.Errorf("error adding vectors: %v", err)
+			return nil, 0, fmt.Errorf("error adding vectors: %w", err)
 		}
 		err = x.InitWithPoints(gm.dimension, sumXAlphM.Points)
-		//fmt.Println(gm.targetFunc(sumXAlphM.Points))
 		if err != nil {
-			return nil, 0, fmt.Errorf("error during vector initializing: %v", err)
+			return nil, 0, fmt.Errorf("error during vector initializing: %w", err)
+		}
+		if gm.observer != nil {
+			gm.notify(k, x.Points, gm.targetFunc(x.Points), aplhaM.Len()) // function value isn't held by the method
 		}
 	}
 }
@@ -245,36 +254,6 @@ func thirdConstraintLambda(x la_methods.Vector, deltaX la_methods.Vector) float6
 	return -x.Points[1] / deltaX.Points[1]
 }
 
-func (gm *GradientMethod) findBounds(alpha float64, targetFunc func(x float64) float64) (float64, float64, error) {
-	gm.svennAlgorithm.Init(0.0001, alpha, targetFunc)
-	return gm.svennAlgorithm.Solve()
-}
-
-func (gm *GradientMethod) oneDimensionFibonacciSearch(alpha float64, tF func(alpha float64) float64) (float64, error) {
-	var fib one_dimension_search.FibonacciSearch
-	a, b, err := gm.findBounds(alpha, tF)
-	if err != nil {
-		return 0, fmt.Errorf("error during svenn algorithm: %v", err)
-	}
-	fib.Init(a, b, 0.00001, 0.00001, tF)
-	min, _, err := fib.Solve()
-	if err != nil {
-		return 0, fmt.Errorf("error during fibonacci search: %v", err)
-	}
-	return min, nil
-}
-
-func (gm *GradientMethod) oneDimensionSearch(
-	alpha float64, search one_dimension_search.OneDimensionSearchI, tF func(alpha float64) float64) (float64, error) {
-	a, b, err := gm.findBounds(alpha, tF)
-	if err != nil {
-		return 0, fmt.Errorf("error during svenn algorithm: %v", err)
-	}
-	search.Init(a, b, 0.00001, tF)
-	min, _ := search.Solve()
-	return min, nil
-}
-
 func (gm *GradientMethod) excludeConstraints(minIndex int, excluded *[]int) bool {
 	for _, v := range *excluded {
 		if minIndex == v {
@@ -300,7 +279,7 @@ func (gm *GradientMethod) getA(x la_methods.Vector, excluded []int) (la_methods.
 	A = gm.A(x.Points)
 	AExcl, err = A.RemoveRows(excluded)
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during rows removing: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during rows removing: %w", err)
 	}
 	return AExcl, nil
 }
@@ -312,11 +291,11 @@ func (gm *GradientMethod) getNewX(x la_methods.Vector, A la_methods.Matrix) (la_
 	var err error
 	Atransp, err = A.Transponate()
 	if err != nil {
-		return la_methods.Vector{}, fmt.Errorf("error transponating matrix: %v", err)
+		return la_methods.Vector{}, fmt.Errorf("error transponating matrix: %w", err)
 	}
 	Amul, err = A.MulM(Atransp)
 	if err != nil {
-		return la_methods.Vector{}, fmt.Errorf("error multiplying matrix: %v", err)
+		return la_methods.Vector{}, fmt.Errorf("error multiplying matrix: %w", err)
 	}
 
 	var a []float64
@@ -326,10 +305,10 @@ func (gm *GradientMethod) getNewX(x la_methods.Vector, A la_methods.Matrix) (la_
 
 	aMulN := mat.NewDense(Amul.DimensionRows, Amul.DimensionColumns, a)
 	aMulNInverted := mat.NewDense(Amul.DimensionRows, Amul.DimensionColumns, nil)
-	_ = aMulNInverted.Inverse(aMulN)
-	//if err != nil {
-	//	return la_methods.Vector{}, fmt.Errorf("error inverting matrix: %v", err)
-	//}
+	err = la_methods.InverseError(aMulNInverted.Inverse(aMulN))
+	if err != nil {
+		return la_methods.Vector{}, fmt.Errorf("error inverting matrix: %w", err)
+	}
 	var aI [][]float64
 	rows, cols := aMulNInverted.Dims()
 	for i := 0; i < rows; i++ {
@@ -337,27 +316,27 @@ func (gm *GradientMethod) getNewX(x la_methods.Vector, A la_methods.Matrix) (la_
 	}
 	err = AInv.InitWithPoints(rows, cols, aI)
 	if err != nil {
-		return la_methods.Vector{}, fmt.Errorf("error initing matrix: %v", err)
+		return la_methods.Vector{}, fmt.Errorf("error initing matrix: %w", err)
 	}
 
 	AMul2, err = Atransp.MulM(AInv)
 	if err != nil {
-		return la_methods.Vector{}, fmt.Errorf("error multiplying matrix: %v", err)
+		return la_methods.Vector{}, fmt.Errorf("error multiplying matrix: %w", err)
 	}
 	for _, g := range gm.penalties {
 		t = append(t, g(x.Points))
 	}
 	err = tVec.InitWithPoints(len(gm.penalties), t)
 	if err != nil {
-		return la_methods.Vector{}, fmt.Errorf("error initializing vector: %v", err)
+		return la_methods.Vector{}, fmt.Errorf("error initializing vector: %w", err)
 	}
 	tMulVec, err = AMul2.MulV(tVec)
 	if err != nil {
-		return la_methods.Vector{}, fmt.Errorf("error multiplying matrix and vector: %v", err)
+		return la_methods.Vector{}, fmt.Errorf("error multiplying matrix and vector: %w", err)
 	}
 	xNewVec, err = x.Add(tMulVec)
 	if err != nil {
-		return la_methods.Vector{}, fmt.Errorf("error adding vectors: %v", err)
+		return la_methods.Vector{}, fmt.Errorf("error adding vectors: %w", err)
 	}
 	return xNewVec, nil
 }
@@ -368,11 +347,11 @@ func (gm *GradientMethod) getDeltaX(A la_methods.Matrix, gradient la_methods.Vec
 	var err error
 	Atransp, err = A.Transponate()
 	if err != nil {
-		return la_methods.Vector{}, fmt.Errorf("error transponating matrix: %v", err)
+		return la_methods.Vector{}, fmt.Errorf("error transponating matrix: %w", err)
 	}
 	Amul, err = A.MulM(Atransp)
 	if err != nil {
-		return la_methods.Vector{}, fmt.Errorf("error multiplying matrix: %v", err)
+		return la_methods.Vector{}, fmt.Errorf("error multiplying matrix: %w", err)
 	}
 	var a []float64
 	for _, p := range Amul.Points {
@@ -380,10 +359,10 @@ func (gm *GradientMethod) getDeltaX(A la_methods.Matrix, gradient la_methods.Vec
 	}
 	aMulN := mat.NewDense(Amul.DimensionRows, Amul.DimensionColumns, a)
 	aMulNInverted := mat.NewDense(Amul.DimensionRows, Amul.DimensionColumns, nil)
-	_ = aMulNInverted.Inverse(aMulN)
-	//if err != nil {
-	//	return la_methods.Vector{}, fmt.Errorf("error inverting matrix: %v", err)
-	//}
+	err = la_methods.InverseError(aMulNInverted.Inverse(aMulN))
+	if err != nil {
+		return la_methods.Vector{}, fmt.Errorf("error inverting matrix: %w", err)
+	}
 	var aI [][]float64
 	rows, cols := aMulNInverted.Dims()
 	for i := 0; i < rows; i++ {
@@ -391,27 +370,27 @@ func (gm *GradientMethod) getDeltaX(A la_methods.Matrix, gradient la_methods.Vec
 	}
 	err = AInv.InitWithPoints(rows, cols, aI)
 	if err != nil {
-		return la_methods.Vector{}, fmt.Errorf("error initing matrix: %v", err)
+		return la_methods.Vector{}, fmt.Errorf("error initing matrix: %w", err)
 	}
 	AMul2, err = Atransp.MulM(AInv)
 	if err != nil {
-		return la_methods.Vector{}, fmt.Errorf("error multiplying matrix: %v", err)
+		return la_methods.Vector{}, fmt.Errorf("error multiplying matrix: %w", err)
 	}
 	AMul3, err = AMul2.MulM(A)
 	if err != nil {
-		return la_methods.Vector{}, fmt.Errorf("error multiplying matrix: %v", err)
+		return la_methods.Vector{}, fmt.Errorf("error multiplying matrix: %w", err)
 	}
 	AE.Init(AMul3.DimensionRows, AMul3.DimensionColumns)
 	AE.E()
 	AMul3 = AMul3.MulVal(-1)
 	AESub, err = AE.AddM(AMul3)
 	if err != nil {
-		return la_methods.Vector{}, fmt.Errorf("error substracting matrices: %v", err)
+		return la_methods.Vector{}, fmt.Errorf("error substracting matrices: %w", err)
 	}
 	AESub = AESub.MulVal(-1)
 	xDelta, err = AESub.MulV(gradient)
 	if err != nil {
-		return la_methods.Vector{}, fmt.Errorf("error multiplying matrix and vector: %v", err)
+		return la_methods.Vector{}, fmt.Errorf("error multiplying matrix and vector: %w", err)
 	}
 	return xDelta, nil
 }
@@ -422,11 +401,11 @@ func (gm *GradientMethod) getLambda(A la_methods.Matrix, gradient la_methods.Vec
 	var err error
 	Atransp, err = A.Transponate()
 	if err != nil {
-		return la_methods.Vector{}, fmt.Errorf("error transponating matrix: %v", err)
+		return la_methods.Vector{}, fmt.Errorf("error transponating matrix: %w", err)
 	}
 	Amul, err = A.MulM(Atransp)
 	if err != nil {
-		return la_methods.Vector{}, fmt.Errorf("error multiplying matrix: %v", err)
+		return la_methods.Vector{}, fmt.Errorf("error multiplying matrix: %w", err)
 	}
 	var a []float64
 	for _, p := range Amul.Points {
@@ -434,10 +413,10 @@ func (gm *GradientMethod) getLambda(A la_methods.Matrix, gradient la_methods.Vec
 	}
 	aMulN := mat.NewDense(Amul.DimensionRows, Amul.DimensionColumns, a)
 	aMulNInverted := mat.NewDense(Amul.DimensionRows, Amul.DimensionColumns, nil)
-	_ = aMulNInverted.Inverse(aMulN)
-	//if err != nil {
-	//	return la_methods.Vector{}, fmt.Errorf("error inverting matrix: %v", err)
-	//}
+	err = la_methods.InverseError(aMulNInverted.Inverse(aMulN))
+	if err != nil {
+		return la_methods.Vector{}, fmt.Errorf("error inverting matrix: %w", err)
+	}
 	var aI [][]float64
 	rows, cols := aMulNInverted.Dims()
 	for i := 0; i < rows; i++ {
@@ -445,16 +424,16 @@ func (gm *GradientMethod) getLambda(A la_methods.Matrix, gradient la_methods.Vec
 	}
 	err = AInv.InitWithPoints(rows, cols, aI)
 	if err != nil {
-		return la_methods.Vector{}, fmt.Errorf("error initing matrix: %v", err)
+		return la_methods.Vector{}, fmt.Errorf("error initing matrix: %w", err)
 	}
 	AInvMinus = AInv.MulVal(-1)
 	AMul1, err = AInvMinus.MulM(A)
 	if err != nil {
-		return la_methods.Vector{}, fmt.Errorf("error multiplying matrix: %v", err)
+		return la_methods.Vector{}, fmt.Errorf("error multiplying matrix: %w", err)
 	}
 	lambda, err = AMul1.MulV(gradient)
 	if err != nil {
-		return la_methods.Vector{}, fmt.Errorf("error multiplying matrix and vector: %v", err)
+		return la_methods.Vector{}, fmt.Errorf("error multiplying matrix and vector: %w", err)
 	}
 	return lambda, nil
 }
@@ -0,0 +1,82 @@
+package constraint_methods
+
+import (
+	"context"
+	"github.com/saskamegaprogrammist/optimization_methods/many_dimension_search"
+	"math"
+	"time"
+)
+
+type methodStats struct {
+	limits          many_dimension_search.Limits
+	reason          many_dimension_search.TerminationReason // not terminated if converged
+	funcEvaluations int
+	timeStart       time.Time
+	observer        many_dimension_search.Observer
+}
+
+func (ms *methodStats) SetLimits(limits many_dimension_search.Limits) {
+	ms.limits = limits
+}
+
+func (ms *methodStats) Reason() many_dimension_search.TerminationReason {
+	return ms.reason
+}
+
+func (ms *methodStats) SetObserver(observer many_dimension_search.Observer) {
+	ms.observer = observer
+}
+
+func (ms *methodStats) notify(k int, x []float64, f float64, step float64) {
+	if ms.observer == nil {
+		return
+	}
+	ms.observer(many_dimension_search.IterationInfo{
+		Iteration:       k,
+		X:               append([]float64{}, x...),
+		F:               f,
+		Step:            step,
+		FuncEvaluations: ms.funcEvaluations,
+	})
+}
+
+func (ms *methodStats) start(ctx context.Context) (context.Context, context.CancelFunc) {
+	ms.reason = many_dimension_search.NotTerminated
+	ms.funcEvaluations = 0
+	ms.timeStart = time.Now()
+	if ms.limits.MaxTime > 0 {
+		return context.WithTimeout(ctx, ms.limits.MaxTime)
+	}
+	return context.WithCancel(ctx)
+}
+
+func (ms *methodStats) interrupted(ctx context.Context, k int) bool {
+	ms.reason = ms.limits.Exceeded(ctx, k, ms.funcEvaluations, ms.timeStart)
+	return ms.reason != many_dimension_search.NotTerminated
+}
+
+func (ms *methodStats) countFunc(targetFunc func(xs []float64) float64) func(xs []float64) float64 {
+	return func(xs []float64) float64 {
+		ms.funcEvaluations++
+		return targetFunc(xs)
+	}
+}
+
+func (ms *methodStats) innerLimits() many_dimension_search.Limits {
+	if ms.limits.MaxFuncEvaluations == 0 {
+		return many_dimension_search.Limits{}
+	}
+	remaining := ms.limits.MaxFuncEvaluations - ms.funcEvaluations
+	if remaining < 1 {
+		remaining = 1
+	}
+	return many_dimension_search.Limits{MaxFuncEvaluations: remaining}
+}
+
+func distance(x []float64, y []float64) float64 {
+	var sum float64
+	for i := range x {
+		sum += (x[i] - y[i]) * (x[i] - y[i])
+	}
+	return math.Sqrt(sum)
+}
@@ -1,13 +1,18 @@
 package constraint_methods
 
 import (
+	"context"
 	"fmt"
 	"github.com/saskamegaprogrammist/optimization_methods/genetic_methods"
 	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"github.com/saskamegaprogrammist/optimization_methods/line_search"
 	"github.com/saskamegaprogrammist/optimization_methods/many_dimension_search"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
 	"math"
 )
 
+const populationRadius = 2.0 // default search box half width for population methods
+
 type Penalty struct {
 	startPoint           []float64
 	dimension            int
@@ -22,7 +27,12 @@ type Penalty struct {
 	c                    float64
 	eps                  float64
 	method               string
-	methodMap            map[string]func(x []float64, r float64) ([]float64, float64, error)
+	methodMap            map[string]func(ctx context.Context, x []float64, r float64) ([]float64, float64, error)
+	seed                 int64
+	seeded               bool // stochastic inner solvers use seed
+	lower                []float64
+	upper                []float64 // population methods search box, set around current point when nil
+	methodStats
 }
 
 func (ep *Penalty) Init(startPoint []float64, dimension int,
@@ -33,7 +43,7 @@ func (ep *Penalty) Init(startPoint []float64, dimension int,
 	constraint func(xs []float64, r float64) float64,
 	eps float64, c float64, method string) {
 	ep.startPoint = startPoint
-	ep.targetFunc = targetFunc
+	ep.targetFunc = ep.countFunc(targetFunc)
 	ep.dimension = dimension
 	ep.penalties = penalties
 	ep.gradient = gradient
@@ -44,7 +54,7 @@ func (ep *Penalty) Init(startPoint []float64, dimension int,
 	ep.c = c
 	ep.eps = eps
 	ep.method = method
-	ep.methodMap = map[string]func(x []float64, r float64) ([]float64, float64, error){
+	ep.methodMap = map[string]func(ctx context.Context, x []float64, r float64) ([]float64, float64, error){
 		"hooke jeeves":            ep.hookeJeevesSearch,
 		"fast gradient":           ep.fastGradientDescendSearch,
 		"nelder mead":             ep.nelderMeadSearch,
@@ -53,6 +63,8 @@ func (ep *Penalty) Init(startPoint []float64, dimension int,
 		"davidon fletcher powell": ep.davidonFletcherPowell,
 		"levenberg":               ep.levenbergMarkkvadratSearch,
 		"genetic":                 ep.geneticAlgorithm,
+		"differential evolution":  ep.differentialEvolution,
+		"particle swarm":          ep.particleSwarm,
 	}
 }
 
@@ -63,7 +75,7 @@ func (ep *Penalty) InitSimple(startPoint []float64, dimension int,
 	constraint func(xs []float64, r float64) float64,
 	eps float64, c float64, method string) {
 	ep.startPoint = startPoint
-	ep.targetFunc = targetFunc
+	ep.targetFunc = ep.countFunc(targetFunc)
 	ep.dimension = dimension
 	ep.penalties = penalties
 	ep.gradient = gradient
@@ -72,7 +84,7 @@ func (ep *Penalty) InitSimple(startPoint []float64, dimension int,
 	ep.c = c
 	ep.eps = eps
 	ep.method = method
-	ep.methodMap = map[string]func(x []float64, r float64) ([]float64, float64, error){
+	ep.methodMap = map[string]func(ctx context.Context, x []float64, r float64) ([]float64, float64, error){
 		"hooke jeeves":            ep.hookeJeevesSearch,
 		"fast gradient":           ep.fastGradientDescendSearch,
 		"nelder mead":             ep.nelderMeadSearch,
@@ -80,24 +92,54 @@ func (ep *Penalty) InitSimple(startPoint []float64, dimension int,
 		"pollac":                  ep.pollacSearch,
 		"davidon fletcher powell": ep.davidonFletcherPowell,
 		"genetic":                 ep.geneticAlgorithm,
+		"differential evolution":  ep.differentialEvolution,
+		"particle swarm":          ep.particleSwarm,
 	}
 }
 
+func (ep *Penalty) SetSeed(seed int64) {
+	ep.seed = seed
+	ep.seeded = true
+}
+
+func (ep *Penalty) SetSearchBox(lower []float64, upper []float64) {
+	ep.lower = lower
+	ep.upper = upper
+}
+
 func (ep *Penalty) Solve() ([]float64, float64, error) {
+	return ep.SolveContext(context.Background())
+}
+
+func (ep *Penalty) SolveContext(ctx context.Context) ([]float64, float64, error) {
 	var err error
 	var x la_methods.Vector
 	var k int
 	var r float64
 	var xMin []float64
 	var yMin float64
+	method, ok := ep.methodMap[ep.method]
+	if !ok {
+		return nil, 0, &optimization_errors.MethodError{Method: ep.method}
+	}
+	ctx, cancel := ep.start(ctx)
+	defer cancel()
 	r = 1
 	err = x.InitWithPoints(ep.dimension, ep.startPoint)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error during vector initializing: %v", err)
+		return nil, 0, fmt.Errorf("error during vector initializing: %w", err)
 	}
+	xMin = ep.startPoint
+	yMin = ep.targetFunc(xMin)
 	for {
-		xMin, yMin, err = ep.methodMap[ep.method](x.Points, r)
-		//fmt.Println(xMin, yMin, ep.constraint(xMin, r))
+		if ep.interrupted(ctx, k) {
+			return xMin, yMin, nil
+		}
+		xMin, yMin, err = method(ctx, x.Points, r)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error solving penalty subproblem: %w", err)
+		}
+		ep.notify(k, xMin, yMin, distance(x.Points, xMin))
 		if math.Abs(ep.constraint(xMin, r)) < ep.eps {
 			//fmt.Printf("k value: %d\n", k)
 			return xMin, yMin, nil
@@ -106,40 +148,44 @@ func (ep *Penalty) Solve() ([]float64, float64, error) {
 			r *= ep.c
 			err = x.InitWithPoints(ep.dimension, xMin)
 			if err != nil {
-				return nil, 0, fmt.Errorf("error during vector initializing: %v", err)
+				return nil, 0, fmt.Errorf("error during vector initializing: %w", err)
 			}
 		}
 	}
 }
 
-func (ep *Penalty) hookeJeevesSearch(x []float64, r float64) ([]float64, float64, error) {
+func (ep *Penalty) hookeJeevesSearch(ctx context.Context, x []float64, r float64) ([]float64, float64, error) {
 	var xMin []float64
 	var yMin float64
 	var err error
 	var hjs many_dimension_search.HookeJeevesSearch
+	var fs line_search.FibonacciSearch
+	fs.Init(0.0001, 0.1)
 	hjs.Init(x, 0.1, ep.dimension, 2, 0.0001, 0.1,
-		0.1, ep.addFunctions(ep.targetFunc, ep.constraint, r), "fibonacci")
-	xMin, yMin, err = hjs.Solve()
+		ep.addFunctions(ep.targetFunc, ep.constraint, r), &fs)
+	hjs.SetLimits(ep.innerLimits())
+	xMin, yMin, err = hjs.SolveContext(ctx)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error solving hooke jeeves : %v\n", err)
+		return nil, 0, fmt.Errorf("error solving hooke jeeves : %w\n", err)
 	}
 	return xMin, yMin, nil
 }
 
-func (ep *Penalty) nelderMeadSearch(x []float64, r float64) ([]float64, float64, error) {
+func (ep *Penalty) nelderMeadSearch(ctx context.Context, x []float64, r float64) ([]float64, float64, error) {
 	var xMin []float64
 	var yMin float64
 	var err error
 	var nms many_dimension_search.NelderMeadSearch
 	nms.Init(x, 0.1, ep.dimension, ep.eps, ep.addFunctions(ep.targetFunc, ep.constraint, r))
-	xMin, yMin, err = nms.Solve()
+	nms.SetLimits(ep.innerLimits())
+	xMin, yMin, err = nms.SolveContext(ctx)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error solving nelder mead : %v\n", err)
+		return nil, 0, fmt.Errorf("error solving nelder mead : %w\n", err)
 	}
 	return xMin, yMin, nil
 }
 
-func (ep *Penalty) geneticAlgorithm(x []float64, r float64) ([]float64, float64, error) {
+func (ep *Penalty) geneticAlgorithm(ctx context.Context, x []float64, r float64) ([]float64, float64, error) {
 	var xMin []float64
 	var yMin float64
 	var err error
@@ -147,66 +193,127 @@ func (ep *Penalty) geneticAlgorithm(x []float64, r float64) ([]float64, float64,
 	ga.Init(0, 4, 1, 2000, x, ep.dimension, ep.addFunctions(ep.targetFunc, ep.constraint, r), func(xs []float64) float64 {
 		return float64(1) / ep.addFunctions(ep.targetFunc, ep.constraint, r)(xs)
 	})
-	xMin, yMin, err = ga.Solve()
+	if ep.seeded {
+		ga.SetSeed(ep.seed)
+	}
+	xMin, yMin, err = ga.SolveContext(ctx)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error solving genetic algorithm : %v\n", err)
+		return nil, 0, fmt.Errorf("error solving genetic algorithm : %w\n", err)
 	}
 	return xMin, yMin, nil
 }
 
-func (ep *Penalty) fastGradientDescendSearch(x []float64, r float64) ([]float64, float64, error) {
+func (ep *Penalty) differentialEvolution(ctx context.Context, x []float64, r float64) ([]float64, float64, error) {
+	var xMin []float64
+	var yMin float64
+	var err error
+	var de genetic_methods.DifferentialEvolution
+	lower, upper := ep.searchBox(x)
+	de.Init(lower, upper, ep.dimension, 10*ep.dimension, 0.5, 0.9, genetic_methods.CurrentToPBestOneBin,
+		1000, ep.eps, ep.addFunctions(ep.targetFunc, ep.constraint, r))
+	de.SetMaxEvaluations(ep.innerLimits().MaxFuncEvaluations)
+	if ep.seeded {
+		de.SetSeed(ep.seed)
+	}
+	xMin, yMin, err = de.SolveContext(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error solving differential evolution : %w\n", err)
+	}
+	return xMin, yMin, nil
+}
+
+func (ep *Penalty) particleSwarm(ctx context.Context, x []float64, r float64) ([]float64, float64, error) {
+	var xMin []float64
+	var yMin float64
+	var err error
+	var pso genetic_methods.ParticleSwarm
+	lower, upper := ep.searchBox(x)
+	pso.Init(lower, upper, ep.dimension, 10*ep.dimension, genetic_methods.Constriction,
+		1000, ep.eps, ep.addFunctions(ep.targetFunc, ep.constraint, r))
+	pso.SetMaxEvaluations(ep.innerLimits().MaxFuncEvaluations)
+	if ep.seeded {
+		pso.SetSeed(ep.seed)
+	}
+	xMin, yMin, err = pso.SolveContext(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error solving particle swarm : %w\n", err)
+	}
+	return xMin, yMin, nil
+}
+
+func (ep *Penalty) searchBox(x []float64) ([]float64, []float64) {
+	if ep.lower != nil && ep.upper != nil {
+		return ep.lower, ep.upper
+	}
+	lower := make([]float64, len(x))
+	upper := make([]float64, len(x))
+	for i := range x {
+		lower[i] = x[i] - populationRadius
+		upper[i] = x[i] + populationRadius
+	}
+	return lower, upper
+}
+
+func (ep *Penalty) fastGradientDescendSearch(ctx context.Context, x []float64, r float64) ([]float64, float64, error) {
 	var xMin []float64
 	var yMin float64
 	var err error
 	var fgd many_dimension_search.FastGradientDescendSearch
-	fgd.Init(x, ep.eps, ep.eps, ep.addFunctions(ep.targetFunc, ep.constraint, r), ep.addGradients(ep.gradient, ep.gradientConstraint, r), ep.dimension, ep.eps, ep.eps, "fibonacci")
-	xMin, yMin, err = fgd.Solve()
+	var fs line_search.FibonacciSearch
+	fs.Init(ep.eps, ep.eps)
+	fgd.Init(x, ep.eps, ep.eps, ep.addFunctions(ep.targetFunc, ep.constraint, r), ep.addGradients(ep.gradient, ep.gradientConstraint, r), ep.dimension, ep.eps, &fs)
+	fgd.SetLimits(ep.innerLimits())
+	xMin, yMin, err = fgd.SolveContext(ctx)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error solving fast gradient descent method : %v\n", err)
+		return nil, 0, fmt.Errorf("error solving fast gradient descent method : %w\n", err)
 	}
 	return xMin, yMin, nil
 }
 
-func (ep *Penalty) pollacSearch(x []float64, r float64) ([]float64, float64, error) {
-	return ep.fletcherReevesSearchWithParam(x, r, true)
+func (ep *Penalty) pollacSearch(ctx context.Context, x []float64, r float64) ([]float64, float64, error) {
+	return ep.fletcherReevesSearchWithParam(ctx, x, r, true)
 }
 
-func (ep *Penalty) fletcherReevesSearch(x []float64, r float64) ([]float64, float64, error) {
-	return ep.fletcherReevesSearchWithParam(x, r, false)
+func (ep *Penalty) fletcherReevesSearch(ctx context.Context, x []float64, r float64) ([]float64, float64, error) {
+	return ep.fletcherReevesSearchWithParam(ctx, x, r, false)
 }
 
-func (ep *Penalty) fletcherReevesSearchWithParam(x []float64, r float64, pollac bool) ([]float64, float64, error) {
+func (ep *Penalty) fletcherReevesSearchWithParam(ctx context.Context, x []float64, r float64, pollac bool) ([]float64, float64, error) {
 	var xMin []float64
 	var yMin float64
 	var err error
 	var frs many_dimension_search.FletcherReevesSearch
-	frs.Init(x, 0.001, 3, ep.eps, ep.eps, ep.eps, 0.00011, 100,
+	var gr line_search.GoldenRatioSearch
+	gr.Init(ep.eps, 0.00011)
+	frs.Init(x, 0.001, ep.dimension, ep.eps, ep.eps, ep.eps, 100,
 		ep.addFunctions(ep.targetFunc, ep.constraint, r),
-		ep.addGradients(ep.gradient, ep.gradientConstraint, r), "golden ratio", pollac)
-	xMin, yMin, err = frs.Solve()
+		ep.addGradients(ep.gradient, ep.gradientConstraint, r), &gr, pollac)
+	frs.SetLimits(ep.innerLimits())
+	xMin, yMin, err = frs.SolveContext(ctx)
 	if err != nil {
-		fmt.Printf("error solving pollak : %v\n", err)
-		return nil, 0, fmt.Errorf("error solving pollak : %v\n", err)
+		return nil, 0, fmt.Errorf("error solving pollak : %w\n", err)
 	}
 	return xMin, yMin, nil
 }
 
-func (ep *Penalty) davidonFletcherPowell(x []float64, r float64) ([]float64, float64, error) {
+func (ep *Penalty) davidonFletcherPowell(ctx context.Context, x []float64, r float64) ([]float64, float64, error) {
 	var xMin []float64
 	var yMin float64
 	var err error
 	var dfps many_dimension_search.DavidonFletcherPowellSearch
-	dfps.Init(x, ep.eps, ep.dimension, ep.eps, ep.eps, ep.eps, 0.00011, 100, ep.addFunctions(ep.targetFunc, ep.constraint, r),
-		ep.addGradients(ep.gradient, ep.gradientConstraint, r), "fibonacci")
-	xMin, yMin, err = dfps.Solve()
+	var fs line_search.FibonacciSearch
+	fs.Init(ep.eps, 0.00011)
+	dfps.Init(x, ep.eps, ep.dimension, ep.eps, ep.eps, ep.eps, 100, ep.addFunctions(ep.targetFunc, ep.constraint, r),
+		ep.addGradients(ep.gradient, ep.gradientConstraint, r), &fs)
+	dfps.SetLimits(ep.innerLimits())
+	xMin, yMin, err = dfps.SolveContext(ctx)
 	if err != nil {
-		fmt.Printf("error solving davidon fletcher powell : %v\n", err)
-		return nil, 0, fmt.Errorf("error solving davidon fletcher powell : %v\n", err)
+		return nil, 0, fmt.Errorf("error solving davidon fletcher powell : %w\n", err)
 	}
 	return xMin, yMin, nil
 }
 
-func (ep *Penalty) levenbergMarkkvadratSearch(x []float64, r float64) ([]float64, float64, error) {
+func (ep *Penalty) levenbergMarkkvadratSearch(ctx context.Context, x []float64, r float64) ([]float64, float64, error) {
 	var xMin []float64
 	var yMin float64
 	var err error
@@ -214,9 +321,10 @@ func (ep *Penalty) levenbergMarkkvadratSearch(x []float64, r float64) ([]float64
 	lms.Init(x, ep.dimension, ep.addFunctions(ep.targetFunc, ep.constraint, r),
 		ep.addGradients(ep.gradient, ep.gradientConstraint, r),
 		ep.addHessians(ep.hessian, ep.hessianConstraint, r), 1000, 10, 0.00001)
-	xMin, yMin, err = lms.Solve()
+	lms.SetLimits(ep.innerLimits())
+	xMin, yMin, err = lms.SolveContext(ctx)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error solving levenberg markkvadrat method : %v\n", err)
+		return nil, 0, fmt.Errorf("error solving levenberg markkvadrat method : %w\n", err)
 	}
 	return xMin, yMin, nil
 }
@@ -231,6 +339,9 @@ func (ep *Penalty) addFunctions(function func(xs []float64) float64,
 
 func (ep *Penalty) addHessians(funcHessian func(xs []float64) la_methods.Matrix,
 	constraintHessian func(xs []float64, r float64) la_methods.Matrix, r float64) func(xs []float64) la_methods.Matrix {
+	if funcHessian == nil || constraintHessian == nil {
+		return nil
+	}
 	return func(xs []float64) la_methods.Matrix {
 		matrixFunc := funcHessian(xs)
 		newM, _ := matrixFunc.AddM(constraintHessian(xs, r))
@@ -240,13 +351,15 @@ func (ep *Penalty) addHessians(funcHessian func(xs []float64) la_methods.Matrix,
 
 func (ep *Penalty) addGradients(gradient []func(xs []float64) float64,
 	gradientConstraint []func(xs []float64, r float64) float64, r float64) []func(xs []float64) float64 {
-	var newGrad []func(xs []float64) float64
-	newGrad = append(newGrad, func(xs []float64) float64 {
-		return gradient[0](xs) + gradientConstraint[0](xs, r)
-	}, func(xs []float64) float64 {
-		return gradient[1](xs) + gradientConstraint[1](xs, r)
-	}, func(xs []float64) float64 {
-		return gradient[2](xs) + gradientConstraint[2](xs, r)
-	})
+	if gradient == nil || gradientConstraint == nil {
+		return nil
+	}
+	newGrad := make([]func(xs []float64) float64, ep.dimension)
+	for i := 0; i < ep.dimension; i++ {
+		i := i
+		newGrad[i] = func(xs []float64) float64 {
+			return gradient[i](xs) + gradientConstraint[i](xs, r)
+		}
+	}
 	return newGrad
 }
@@ -1,9 +1,12 @@
 package constraint_methods
 
 import (
+	"context"
 	"fmt"
 	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"github.com/saskamegaprogrammist/optimization_methods/line_search"
 	"github.com/saskamegaprogrammist/optimization_methods/many_dimension_search"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
 	"math"
 )
 
@@ -21,8 +24,9 @@ type PenaltyCombined struct {
 	c2                    float64
 	eps                   float64
 	method                string
-	methodMap             map[string]func(x []float64, r float64, targetFunc func(xs []float64) float64,
+	methodMap             map[string]func(ctx context.Context, x []float64, r float64, targetFunc func(xs []float64) float64,
 		gradient []func(xs []float64) float64) ([]float64, float64, error)
+	methodStats
 }
 
 func (pc *PenaltyCombined) Init(startPoint []float64, dimension int,
@@ -34,7 +38,7 @@ func (pc *PenaltyCombined) Init(startPoint []float64, dimension int,
 	constraintInt func(xs []float64, r float64) float64,
 	eps float64, c1 float64, c2 float64, method string) {
 	pc.startPoint = startPoint
-	pc.targetFunc = targetFunc
+	pc.targetFunc = pc.countFunc(targetFunc)
 	pc.dimension = dimension
 	pc.gradient = gradient
 	pc.gradientConstraintExt = gradientConstraintExt
@@ -45,7 +49,7 @@ func (pc *PenaltyCombined) Init(startPoint []float64, dimension int,
 	pc.c2 = c2
 	pc.eps = eps
 	pc.method = method
-	pc.methodMap = map[string]func(x []float64, r float64, targetFunc func(xs []float64) float64,
+	pc.methodMap = map[string]func(ctx context.Context, x []float64, r float64, targetFunc func(xs []float64) float64,
 		gradient []func(xs []float64) float64) ([]float64, float64, error){
 		"hooke jeeves":            pc.hookeJeevesSearch,
 		"fast gradient":           pc.fastGradientDescendSearch,
@@ -57,6 +61,10 @@ func (pc *PenaltyCombined) Init(startPoint []float64, dimension int,
 }
 
 func (pc *PenaltyCombined) Solve() ([]float64, float64, error) {
+	return pc.SolveContext(context.Background())
+}
+
+func (pc *PenaltyCombined) SolveContext(ctx context.Context) ([]float64, float64, error) {
 	var err error
 	var x la_methods.Vector
 	var k int
@@ -68,11 +76,17 @@ func (pc *PenaltyCombined) Solve() ([]float64, float64, error) {
 	var targFunc func(xs []float64) float64
 	var constrFuncGrad []func(xs []float64) float64
 	var targFuncGrad []func(xs []float64) float64
+	method, ok := pc.methodMap[pc.method]
+	if !ok {
+		return nil, 0, &optimization_errors.MethodError{Method: pc.method}
+	}
+	ctx, cancel := pc.start(ctx)
+	defer cancel()
 	r1 = 1
 	r2 = 1
 	err = x.InitWithPoints(pc.dimension, pc.startPoint)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error during vector initializing: %v", err)
+		return nil, 0, fmt.Errorf("error during vector initializing: %w", err)
 	}
 	var constraints []func(xs []float64, r float64) float64
 	constraints = append(constraints, pc.constraintExt, pc.constraintInt)
@@ -81,11 +95,17 @@ func (pc *PenaltyCombined) Solve() ([]float64, float64, error) {
 	constrFunc = pc.addConstraints(constraints, r1)
 	valConstrOld = constrFunc(x.Points)
 	for {
+		if pc.interrupted(ctx, k) {
+			return x.Points, pc.targetFunc(x.Points), nil
+		}
 		constrFunc = pc.addConstraints(constraints, r1)
 		constrFuncGrad = pc.addGradientsConstraints(constraintsGrads, r1)
 
-		xMin, valConstraintMin, err = pc.methodMap[pc.method](x.Points, r1, constrFunc, constrFuncGrad)
-		//fmt.Println(xMin, valConstraintMin)
+		xMin, valConstraintMin, err = method(ctx, x.Points, r1, constrFunc, constrFuncGrad)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error solving feasibility subproblem: %w", err)
+		}
+		pc.notify(k, xMin, valConstraintMin, distance(x.Points, xMin)) // feasibility phase reports constraint value
 		if math.Abs(valConstraintMin-valConstrOld) < pc.eps {
 			break
 		} else {
@@ -94,7 +114,7 @@ func (pc *PenaltyCombined) Solve() ([]float64, float64, error) {
 			r1 *= pc.c1
 			err = x.InitWithPoints(pc.dimension, xMin)
 			if err != nil {
-				return nil, 0, fmt.Errorf("error during vector initializing: %v", err)
+				return nil, 0, fmt.Errorf("error during vector initializing: %w", err)
 			}
 		}
 	}
@@ -102,13 +122,18 @@ func (pc *PenaltyCombined) Solve() ([]float64, float64, error) {
 	targFunc = pc.addFunctions(pc.targetFunc, constraints, r2)
 	yMinOld = targFunc(x.Points)
 	for {
+		if pc.interrupted(ctx, k) {
+			return x.Points, pc.targetFunc(x.Points), nil
+		}
 		targFunc = pc.addFunctions(pc.targetFunc, constraints, r2)
 		targFuncGrad = pc.addGradients(pc.gradient, constraintsGrads, r2)
 
-		xMin, yMin, err = pc.methodMap[pc.method](x.Points, r2, targFunc, targFuncGrad)
-		//fmt.Println(xMin, yMin)
+		xMin, yMin, err = method(ctx, x.Points, r2, targFunc, targFuncGrad)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error solving penalty subproblem: %w", err)
+		}
+		pc.notify(k, xMin, yMin, distance(x.Points, xMin))
 		if math.Abs(yMin-yMinOld) < pc.eps {
-			fmt.Printf("k value: %d\n", k)
 			return xMin, yMin, nil
 		} else {
 			yMinOld = yMin
@@ -116,93 +141,104 @@ func (pc *PenaltyCombined) Solve() ([]float64, float64, error) {
 			r2 *= pc.c2
 			err = x.InitWithPoints(pc.dimension, xMin)
 			if err != nil {
-				return nil, 0, fmt.Errorf("error during vector initializing: %v", err)
+				return nil, 0, fmt.Errorf("error during vector initializing: %w", err)
 			}
 		}
 	}
 }
 
-func (pc *PenaltyCombined) hookeJeevesSearch(x []float64, r float64, targetFunc func(xs []float64) float64,
+func (pc *PenaltyCombined) hookeJeevesSearch(ctx context.Context, x []float64, r float64, targetFunc func(xs []float64) float64,
 	gradient []func(xs []float64) float64) ([]float64, float64, error) {
 	var xMin []float64
 	var yMin float64
 	var err error
 	var hjs many_dimension_search.HookeJeevesSearch
+	var bit line_search.BreakInTwoSearch
+	bit.Init(0.0001, 0.1)
 	hjs.Init(x, 0.1, pc.dimension, 2, 0.0001, 0.1,
-		0.1, targetFunc, "break in two")
-	xMin, yMin, err = hjs.Solve()
+		targetFunc, &bit)
+	hjs.SetLimits(pc.innerLimits())
+	xMin, yMin, err = hjs.SolveContext(ctx)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error solving hooke jeeves : %v\n", err)
+		return nil, 0, fmt.Errorf("error solving hooke jeeves : %w\n", err)
 	}
 	return xMin, yMin, nil
 }
 
-func (pc *PenaltyCombined) nelderMeadSearch(x []float64, r float64, targetFunc func(xs []float64) float64,
+func (pc *PenaltyCombined) nelderMeadSearch(ctx context.Context, x []float64, r float64, targetFunc func(xs []float64) float64,
 	gradient []func(xs []float64) float64) ([]float64, float64, error) {
 	var xMin []float64
 	var yMin float64
 	var err error
 	var nms many_dimension_search.NelderMeadSearch
 	nms.Init(x, 0.1, pc.dimension, pc.eps, targetFunc)
-	xMin, yMin, err = nms.Solve()
+	nms.SetLimits(pc.innerLimits())
+	xMin, yMin, err = nms.SolveContext(ctx)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error solving nelder mead : %v\n", err)
+		return nil, 0, fmt.Errorf("error solving nelder mead : %w\n", err)
 	}
 	return xMin, yMin, nil
 }
 
-func (pc *PenaltyCombined) fastGradientDescendSearch(x []float64, r float64, targetFunc func(xs []float64) float64,
+func (pc *PenaltyCombined) fastGradientDescendSearch(ctx context.Context, x []float64, r float64, targetFunc func(xs []float64) float64,
 	gradient []func(xs []float64) float64) ([]float64, float64, error) {
 	var xMin []float64
 	var yMin float64
 	var err error
 	var fgd many_dimension_search.FastGradientDescendSearch
-	fgd.Init(x, pc.eps, pc.eps, targetFunc, gradient, pc.dimension, pc.eps, pc.eps, "golden ratio")
-	xMin, yMin, err = fgd.Solve()
+	var gr line_search.GoldenRatioSearch
+	gr.Init(pc.eps, pc.eps)
+	fgd.Init(x, pc.eps, pc.eps, targetFunc, gradient, pc.dimension, pc.eps, &gr)
+	fgd.SetLimits(pc.innerLimits())
+	xMin, yMin, err = fgd.SolveContext(ctx)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error solving fast gradient descent method : %v\n", err)
+		return nil, 0, fmt.Errorf("error solving fast gradient descent method : %w\n", err)
 	}
 	return xMin, yMin, nil
 }
 
-func (pc *PenaltyCombined) pollacSearch(x []float64, r float64, targetFunc func(xs []float64) float64,
+func (pc *PenaltyCombined) pollacSearch(ctx context.Context, x []float64, r float64, targetFunc func(xs []float64) float64,
 	gradient []func(xs []float64) float64) ([]float64, float64, error) {
-	return pc.fletcherReevesSearchWithParam(x, r, true, targetFunc, gradient)
+	return pc.fletcherReevesSearchWithParam(ctx, x, r, true, targetFunc, gradient)
 }
 
-func (pc *PenaltyCombined) fletcherReevesSearch(x []float64, r float64, targetFunc func(xs []float64) float64,
+func (pc *PenaltyCombined) fletcherReevesSearch(ctx context.Context, x []float64, r float64, targetFunc func(xs []float64) float64,
 	gradient []func(xs []float64) float64) ([]float64, float64, error) {
-	return pc.fletcherReevesSearchWithParam(x, r, false, targetFunc, gradient)
+	return pc.fletcherReevesSearchWithParam(ctx, x, r, false, targetFunc, gradient)
 }
 
-func (pc *PenaltyCombined) fletcherReevesSearchWithParam(x []float64, r float64, pollac bool, targetFunc func(xs []float64) float64,
+func (pc *PenaltyCombined) fletcherReevesSearchWithParam(ctx context.Context, x []float64, r float64, pollac bool, targetFunc func(xs []float64) float64,
 	gradient []func(xs []float64) float64) ([]float64, float64, error) {
 	var xMin []float64
 	var yMin float64
 	var err error
 	var frs many_dimension_search.FletcherReevesSearch
-	frs.Init(x, 0.001, 2, pc.eps, pc.eps, pc.eps, 0.00011, 100,
-		targetFunc, gradient, "break in two", pollac)
-	xMin, yMin, err = frs.Solve()
+	var bit line_search.BreakInTwoSearch
+	bit.Init(pc.eps, 0.00011)
+	frs.Init(x, 0.001, pc.dimension, pc.eps, pc.eps, pc.eps, 100,
+		targetFunc, gradient, &bit, pollac)
+	frs.SetLimits(pc.innerLimits())
+	xMin, yMin, err = frs.SolveContext(ctx)
 	if err != nil {
-		fmt.Printf("error solving pollak : %v\n", err)
-		return nil, 0, fmt.Errorf("error solving pollak : %v\n", err)
+		return nil, 0, fmt.Errorf("error solving pollak : %w\n", err)
 	}
 	return xMin, yMin, nil
 }
 
-func (pc *PenaltyCombined) davidonFletcherPowell(x []float64, r float64, targetFunc func(xs []float64) float64,
+func (pc *PenaltyCombined) davidonFletcherPowell(ctx context.Context, x []float64, r float64, targetFunc func(xs []float64) float64,
 	gradient []func(xs []float64) float64) ([]float64, float64, error) {
 	var xMin []float64
 	var yMin float64
 	var err error
 	var dfps many_dimension_search.DavidonFletcherPowellSearch
-	dfps.Init(x, pc.eps, pc.dimension, pc.eps, pc.eps, pc.eps, 0.00011, 100, targetFunc,
-		gradient, "golden ratio")
-	xMin, yMin, err = dfps.Solve()
+	var gr line_search.GoldenRatioSearch
+	gr.Init(pc.eps, 0.00011)
+	dfps.Init(x, pc.eps, pc.dimension, pc.eps, pc.eps, pc.eps, 100, targetFunc,
+		gradient, &gr)
+	dfps.SetLimits(pc.innerLimits())
+	xMin, yMin, err = dfps.SolveContext(ctx)
 	if err != nil {
-		fmt.Printf("error solving davidon fletcher powell : %v\n", err)
-		return nil, 0, fmt.Errorf("error solving davidon fletcher powell : %v\n", err)
+		return nil, 0, fmt.Errorf("error solving davidon fletcher powell : %w\n", err)
 	}
 	return xMin, yMin, nil
 }
@@ -220,20 +256,17 @@ func (pc *PenaltyCombined) addFunctions(function func(xs []float64) float64,
 
 func (pc *PenaltyCombined) addGradients(gradient []func(xs []float64) float64,
 	gradientConstraints [][]func(xs []float64, r float64) float64, r float64) []func(xs []float64) float64 {
-	var newGrad []func(xs []float64) float64
-	newGrad = append(newGrad, func(xs []float64) float64 {
-		var sum float64
-		for _, f := range gradientConstraints {
-			sum += f[0](xs, r)
-		}
-		return gradient[0](xs) + sum
-	}, func(xs []float64) float64 {
-		var sum float64
-		for _, f := range gradientConstraints {
-			sum += f[1](xs, r)
+	constraintsGrad := pc.addGradientsConstraints(gradientConstraints, r)
+	if gradient == nil || constraintsGrad == nil {
+		return nil
+	}
+	newGrad := make([]func(xs []float64) float64, pc.dimension)
+	for i := 0; i < pc.dimension; i++ {
+		i := i
+		newGrad[i] = func(xs []float64) float64 {
+			return gradient[i](xs) + constraintsGrad[i](xs)
 		}
-		return gradient[1](xs) + sum
-	})
+	}
 	return newGrad
 }
 
@@ -248,19 +281,21 @@ func (pc *PenaltyCombined) addConstraints(constraintFunctions []func(xs []float6
 }
 
 func (pc *PenaltyCombined) addGradientsConstraints(gradientConstraints [][]func(xs []float64, r float64) float64, r float64) []func(xs []float64) float64 {
-	var newGrad []func(xs []float64) float64
-	newGrad = append(newGrad, func(xs []float64) float64 {
-		var sum float64
-		for _, f := range gradientConstraints {
-			sum += f[0](xs, r)
+	for _, g := range gradientConstraints {
+		if g == nil {
+			return nil
 		}
-		return sum
-	}, func(xs []float64) float64 {
-		var sum float64
-		for _, f := range gradientConstraints {
-			sum += f[1](xs, r)
+	}
+	newGrad := make([]func(xs []float64) float64, pc.dimension)
+	for i := 0; i < pc.dimension; i++ {
+		i := i
+		newGrad[i] = func(xs []float64) float64 {
+			var sum float64
+			for _, f := range gradientConstraints {
+				sum += f[i](xs, r)
+			}
+			return sum
 		}
-		return sum
-	})
+	}
 	return newGrad
 }
@@ -1,9 +1,12 @@
 package constraint_methods
 
 import (
+	"context"
 	"fmt"
 	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"github.com/saskamegaprogrammist/optimization_methods/line_search"
 	"github.com/saskamegaprogrammist/optimization_methods/many_dimension_search"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
 	"math"
 )
 
@@ -20,7 +23,8 @@ type PenaltyLagrange struct {
 	m                    []float64
 	eps                  float64
 	method               string
-	methodMap            map[string]func(x []float64, r float64, m []float64) ([]float64, float64, error)
+	methodMap            map[string]func(ctx context.Context, x []float64, r float64, m []float64) ([]float64, float64, error)
+	methodStats
 }
 
 func (pl *PenaltyLagrange) Init(startPoint []float64, dimension int,
@@ -32,7 +36,7 @@ func (pl *PenaltyLagrange) Init(startPoint []float64, dimension int,
 	m []float64,
 	eps float64, c float64, method string) {
 	pl.startPoint = startPoint
-	pl.targetFunc = targetFunc
+	pl.targetFunc = pl.countFunc(targetFunc)
 	pl.dimension = dimension
 	pl.gradient = gradient
 	pl.gradientConstraint = gradientConstraint
@@ -42,7 +46,7 @@ func (pl *PenaltyLagrange) Init(startPoint []float64, dimension int,
 	pl.m = m
 	pl.eps = eps
 	pl.method = method
-	pl.methodMap = map[string]func(x []float64, r float64, m []float64) ([]float64, float64, error){
+	pl.methodMap = map[string]func(ctx context.Context, x []float64, r float64, m []float64) ([]float64, float64, error){
 		"hooke jeeves":            pl.hookeJeevesSearch,
 		"fast gradient":           pl.fastGradientDescendSearch,
 		"nelder mead":             pl.nelderMeadSearch,
@@ -53,6 +57,10 @@ func (pl *PenaltyLagrange) Init(startPoint []float64, dimension int,
 }
 
 func (pl *PenaltyLagrange) Solve() ([]float64, float64, error) {
+	return pl.SolveContext(context.Background())
+}
+
+func (pl *PenaltyLagrange) SolveContext(ctx context.Context) ([]float64, float64, error) {
 	var err error
 	var x la_methods.Vector
 	var k int
@@ -60,25 +68,38 @@ func (pl *PenaltyLagrange) Solve() ([]float64, float64, error) {
 	var xMin []float64
 	var yMin float64
 	var m []float64
+	method, ok := pl.methodMap[pl.method]
+	if !ok {
+		return nil, 0, &optimization_errors.MethodError{Method: pl.method}
+	}
+	ctx, cancel := pl.start(ctx)
+	defer cancel()
 	r = 4
 	err = x.InitWithPoints(pl.dimension, pl.startPoint)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error during vector initializing: %v", err)
+		return nil, 0, fmt.Errorf("error during vector initializing: %w", err)
 	}
+	xMin = pl.startPoint
+	yMin = pl.targetFunc(xMin)
 	m = pl.m
 	for {
+		if pl.interrupted(ctx, k) {
+			return xMin, yMin, nil
+		}
 		//fmt.Println(m)
-		xMin, yMin, err = pl.methodMap[pl.method](x.Points, r, m)
-		//fmt.Println(xMin, yMin, pl.constraint(xMin, r, m))
+		xMin, yMin, err = method(ctx, x.Points, r, m)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error solving penalty subproblem: %w", err)
+		}
+		pl.notify(k, xMin, yMin, distance(x.Points, xMin))
 		if math.Abs(pl.constraint(xMin, r, m)) < pl.eps {
-			fmt.Printf("k value: %d\n", k)
 			return xMin, yMin, nil
 		} else {
 			k++
 			//r *= pl.c
 			err = x.InitWithPoints(pl.dimension, xMin)
 			if err != nil {
-				return nil, 0, fmt.Errorf("error during vector initializing: %v", err)
+				return nil, 0, fmt.Errorf("error during vector initializing: %w", err)
 			}
 			m = pl.calculateM(m, x.Points, r)
 		}
@@ -93,82 +114,93 @@ func (pl *PenaltyLagrange) calculateM(m []float64, x []float64, r float64) []flo
 	return newM
 }
 
-func (pl *PenaltyLagrange) hookeJeevesSearch(x []float64, r float64, m []float64) ([]float64, float64, error) {
+func (pl *PenaltyLagrange) hookeJeevesSearch(ctx context.Context, x []float64, r float64, m []float64) ([]float64, float64, error) {
 	var xMin []float64
 	var yMin float64
 	var err error
 	var hjs many_dimension_search.HookeJeevesSearch
+	var gr line_search.GoldenRatioSearch
+	gr.Init(0.0001, 0.1)
 	tf := pl.addFunctions(pl.targetFunc, pl.constraint, r, m)
 	hjs.Init(x, 0.1, pl.dimension, 2, 0.0001, 0.1,
-		0.1, tf, "golden ratio")
-	xMin, yMin, err = hjs.Solve()
+		tf, &gr)
+	hjs.SetLimits(pl.innerLimits())
+	xMin, yMin, err = hjs.SolveContext(ctx)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error solving hooke jeeves : %v\n", err)
+		return nil, 0, fmt.Errorf("error solving hooke jeeves : %w\n", err)
 	}
 	return xMin, yMin, nil
 }
 
-func (pl *PenaltyLagrange) nelderMeadSearch(x []float64, r float64, m []float64) ([]float64, float64, error) {
+func (pl *PenaltyLagrange) nelderMeadSearch(ctx context.Context, x []float64, r float64, m []float64) ([]float64, float64, error) {
 	var xMin []float64
 	var yMin float64
 	var err error
 	var nms many_dimension_search.NelderMeadSearch
 	nms.Init(x, 0.1, pl.dimension, pl.eps, pl.addFunctions(pl.targetFunc, pl.constraint, r, m))
-	xMin, yMin, err = nms.Solve()
+	nms.SetLimits(pl.innerLimits())
+	xMin, yMin, err = nms.SolveContext(ctx)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error solving nelder mead : %v\n", err)
+		return nil, 0, fmt.Errorf("error solving nelder mead : %w\n", err)
 	}
 	return xMin, yMin, nil
 }
 
-func (pl *PenaltyLagrange) fastGradientDescendSearch(x []float64, r float64, m []float64) ([]float64, float64, error) {
+func (pl *PenaltyLagrange) fastGradientDescendSearch(ctx context.Context, x []float64, r float64, m []float64) ([]float64, float64, error) {
 	var xMin []float64
 	var yMin float64
 	var err error
 	var fgd many_dimension_search.FastGradientDescendSearch
-	fgd.Init(x, pl.eps, pl.eps, pl.addFunctions(pl.targetFunc, pl.constraint, r, m), pl.addGradients(pl.gradient, pl.gradientConstraint, r, m), pl.dimension, pl.eps, pl.eps, "golden ratio")
-	xMin, yMin, err = fgd.Solve()
+	var gr line_search.GoldenRatioSearch
+	gr.Init(pl.eps, pl.eps)
+	fgd.Init(x, pl.eps, pl.eps, pl.addFunctions(pl.targetFunc, pl.constraint, r, m), pl.addGradients(pl.gradient, pl.gradientConstraint, r, m), pl.dimension, pl.eps, &gr)
+	fgd.SetLimits(pl.innerLimits())
+	xMin, yMin, err = fgd.SolveContext(ctx)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error solving fast gradient descent method : %v\n", err)
+		return nil, 0, fmt.Errorf("error solving fast gradient descent method : %w\n", err)
 	}
 	return xMin, yMin, nil
 }
 
-func (pl *PenaltyLagrange) pollacSearch(x []float64, r float64, m []float64) ([]float64, float64, error) {
-	return pl.fletcherReevesSearchWithParam(x, r, m, true)
+func (pl *PenaltyLagrange) pollacSearch(ctx context.Context, x []float64, r float64, m []float64) ([]float64, float64, error) {
+	return pl.fletcherReevesSearchWithParam(ctx, x, r, m, true)
 }
 
-func (pl *PenaltyLagrange) fletcherReevesSearch(x []float64, r float64, m []float64) ([]float64, float64, error) {
-	return pl.fletcherReevesSearchWithParam(x, r, m, false)
+func (pl *PenaltyLagrange) fletcherReevesSearch(ctx context.Context, x []float64, r float64, m []float64) ([]float64, float64, error) {
+	return pl.fletcherReevesSearchWithParam(ctx, x, r, m, false)
 }
 
-func (pl *PenaltyLagrange) fletcherReevesSearchWithParam(x []float64, r float64, m []float64, pollac bool) ([]float64, float64, error) {
+func (pl *PenaltyLagrange) fletcherReevesSearchWithParam(ctx context.Context, x []float64, r float64, m []float64, pollac bool) ([]float64, float64, error) {
 	var xMin []float64
 	var yMin float64
 	var err error
 	var frs many_dimension_search.FletcherReevesSearch
-	frs.Init(x, 0.0001, 2, pl.eps, pl.eps, 0.00001, 0.0001, 10,
+	var gr line_search.GoldenRatioSearch
+	gr.Init(pl.eps, 0.0001)
+	frs.Init(x, 0.0001, pl.dimension, pl.eps, pl.eps, 0.00001, 10,
 		pl.addFunctions(pl.targetFunc, pl.constraint, r, m),
-		pl.addGradients(pl.gradient, pl.gradientConstraint, r, m), "golden ratio", pollac)
-	xMin, yMin, err = frs.Solve()
+		pl.addGradients(pl.gradient, pl.gradientConstraint, r, m), &gr, pollac)
+	frs.SetLimits(pl.innerLimits())
+	xMin, yMin, err = frs.SolveContext(ctx)
 	if err != nil {
-		fmt.Printf("error solving pollak : %v\n", err)
-		return nil, 0, fmt.Errorf("error solving pollak : %v\n", err)
+		return nil, 0, fmt.Errorf("error solving pollak : %w\n", err)
 	}
 	return xMin, yMin, nil
 }
 
-func (pl *PenaltyLagrange) davidonFletcherPowell(x []float64, r float64, m []float64) ([]float64, float64, error) {
+func (pl *PenaltyLagrange) davidonFletcherPowell(ctx context.Context, x []float64, r float64, m []float64) ([]float64, float64, error) {
 	var xMin []float64
 	var yMin float64
 	var err error
 	var dfps many_dimension_search.DavidonFletcherPowellSearch
-	dfps.Init(x, 0.0001, pl.dimension, pl.eps, pl.eps, 0.00001, 0.0001, 10, pl.addFunctions(pl.targetFunc, pl.constraint, r, m),
-		pl.addGradients(pl.gradient, pl.gradientConstraint, r, m), "break in two")
-	xMin, yMin, err = dfps.Solve()
+	var bit line_search.BreakInTwoSearch
+	bit.Init(pl.eps, 0.0001)
+	dfps.Init(x, 0.0001, pl.dimension, pl.eps, pl.eps, 0.00001, 10, pl.addFunctions(pl.targetFunc, pl.constraint, r, m),
+		pl.addGradients(pl.gradient, pl.gradientConstraint, r, m), &bit)
+	dfps.SetLimits(pl.innerLimits())
+	xMin, yMin, err = dfps.SolveContext(ctx)
 	if err != nil {
-		fmt.Printf("error solving davidon fletcher powell : %v\n", err)
-		return nil, 0, fmt.Errorf("error solving davidon fletcher powell : %v\n", err)
+		return nil, 0, fmt.Errorf("error solving davidon fletcher powell : %w\n", err)
 	}
 	return xMin, yMin, nil
 }
@@ -183,11 +215,15 @@ func (pl *PenaltyLagrange) addFunctions(function func(xs []float64) float64,
 
 func (pl *PenaltyLagrange) addGradients(gradient []func(xs []float64) float64,
 	gradientConstraint []func(xs []float64, r float64, m []float64) float64, r float64, m []float64) []func(xs []float64) float64 {
-	var newGrad []func(xs []float64) float64
-	newGrad = append(newGrad, func(xs []float64) float64 {
-		return gradient[0](xs) + gradientConstraint[0](xs, r, m)
-	}, func(xs []float64) float64 {
-		return gradient[1](xs) + gradientConstraint[1](xs, r, m)
-	})
+	if gradient == nil || gradientConstraint == nil {
+		return nil
+	}
+	newGrad := make([]func(xs []float64) float64, pl.dimension)
+	for i := 0; i < pl.dimension; i++ {
+		i := i
+		newGrad[i] = func(xs []float64) float64 {
+			return gradient[i](xs) + gradientConstraint[i](xs, r, m)
+		}
+	}
 	return newGrad
 }
@@ -0,0 +1,96 @@
+package finite_differences
+
+import (
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
+	"math"
+)
+
+type GradientCheck struct {
+	Point          []float64
+	Supplied       []float64
+	Numerical      []float64
+	RelativeErrors []float64
+	MaxError       float64
+	MaxComponent   int
+}
+
+type HessianCheck struct {
+	Point          []float64
+	Supplied       la_methods.Matrix
+	Numerical      la_methods.Matrix
+	RelativeErrors [][]float64
+	MaxError       float64
+	MaxRow         int
+	MaxColumn      int
+}
+
+func CheckGradient(targetFunc func(xs []float64) float64, gradient []func(xs []float64) float64,
+	points [][]float64) ([]GradientCheck, error) {
+	var d Differentiator
+	d.Init(Richardson, 0)
+	checks := make([]GradientCheck, len(points))
+	for k, point := range points {
+		if len(point) != len(gradient) {
+			return nil, fmt.Errorf("gradient length: %w", &optimization_errors.DimensionError{Expected: len(point), Actual: len(gradient)})
+		}
+		check := GradientCheck{
+			Point:          point,
+			Supplied:       make([]float64, len(point)),
+			Numerical:      make([]float64, len(point)),
+			RelativeErrors: make([]float64, len(point)),
+		}
+		for i, g := range gradient {
+			check.Supplied[i] = g(point)
+			check.Numerical[i] = d.PartialDerivative(targetFunc, point, i)
+			check.RelativeErrors[i] = relativeError(check.Supplied[i], check.Numerical[i])
+			if check.RelativeErrors[i] > check.MaxError || math.IsNaN(check.RelativeErrors[i]) {
+				check.MaxError = check.RelativeErrors[i]
+				check.MaxComponent = i
+			}
+		}
+		checks[k] = check
+	}
+	return checks, nil
+}
+
+func CheckHessian(targetFunc func(xs []float64) float64, hessian func(xs []float64) la_methods.Matrix,
+	points [][]float64) ([]HessianCheck, error) {
+	var d Differentiator
+	d.Init(Richardson, 0)
+	checks := make([]HessianCheck, len(points))
+	for k, point := range points {
+		dimension := len(point)
+		check := HessianCheck{
+			Point:          point,
+			Supplied:       hessian(point),
+			Numerical:      d.Hessian(targetFunc, dimension)(point),
+			RelativeErrors: make([][]float64, dimension),
+		}
+		if check.Supplied.DimensionRows != dimension {
+			return nil, fmt.Errorf("hessian rows: %w", &optimization_errors.DimensionError{Expected: dimension, Actual: check.Supplied.DimensionRows})
+		}
+		if check.Supplied.DimensionColumns != dimension {
+			return nil, fmt.Errorf("hessian columns: %w", &optimization_errors.DimensionError{Expected: dimension, Actual: check.Supplied.DimensionColumns})
+		}
+		for i := 0; i < dimension; i++ {
+			check.RelativeErrors[i] = make([]float64, dimension)
+			for j := 0; j < dimension; j++ {
+				check.RelativeErrors[i][j] = relativeError(check.Supplied.Points[i][j], check.Numerical.Points[i][j])
+				if check.RelativeErrors[i][j] > check.MaxError || math.IsNaN(check.RelativeErrors[i][j]) {
+					check.MaxError = check.RelativeErrors[i][j]
+					check.MaxRow = i
+					check.MaxColumn = j
+				}
+			}
+		}
+		checks[k] = check
+	}
+	return checks, nil
+}
+
+func relativeError(supplied float64, numerical float64) float64 {
+	scale := math.Max(math.Max(math.Abs(supplied), math.Abs(numerical)), 1) // absolute error near zero
+	return math.Abs(supplied-numerical) / scale
+}
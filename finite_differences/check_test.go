@@ -0,0 +1,65 @@
+package finite_differences
+
+import (
+	"errors"
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
+	"testing"
+)
+
+func suppliedGradient(wrong bool) []func(xs []float64) float64 {
+	return []func(xs []float64) float64{
+		func(xs []float64) float64 { return testGradient(xs)[0] },
+		func(xs []float64) float64 {
+			if wrong {
+				return testGradient(xs)[1] * 1.01
+			}
+			return testGradient(xs)[1]
+		},
+	}
+}
+
+func TestCheckGradient(t *testing.T) {
+	points := [][]float64{{0.7, -0.3}, {-1, 1}}
+	checks, err := CheckGradient(testFunc, suppliedGradient(false), points)
+	if err != nil {
+		t.Fatalf("error checking gradient: %v", err)
+	}
+	for _, check := range checks {
+		if check.MaxError > 1e-8 {
+			t.Errorf("correct gradient at %v has error %g", check.Point, check.MaxError)
+		}
+	}
+	checks, err = CheckGradient(testFunc, suppliedGradient(true), points)
+	if err != nil {
+		t.Fatalf("error checking gradient: %v", err)
+	}
+	for _, check := range checks {
+		if check.MaxError < 1e-3 || check.MaxComponent != 1 {
+			t.Errorf("wrong gradient component isn't detected at %v: %g, %d", check.Point, check.MaxError, check.MaxComponent)
+		}
+	}
+}
+
+func TestCheckHessian(t *testing.T) {
+	hessian := func(xs []float64) la_methods.Matrix {
+		var matrix la_methods.Matrix
+		_ = matrix.InitWithPoints(2, 2, testHessian(xs))
+		matrix.Points[0][1] += 0.5
+		return matrix
+	}
+	checks, err := CheckHessian(testFunc, hessian, [][]float64{{0.7, -0.3}})
+	if err != nil {
+		t.Fatalf("error checking hessian: %v", err)
+	}
+	if check := checks[0]; check.MaxError < 1e-2 || check.MaxRow != 0 || check.MaxColumn != 1 {
+		t.Errorf("wrong hessian element isn't detected: %g, %d, %d", check.MaxError, check.MaxRow, check.MaxColumn)
+	}
+}
+
+func TestCheckGradientDimension(t *testing.T) {
+	_, err := CheckGradient(testFunc, suppliedGradient(false), [][]float64{{1, 2, 3}})
+	if !errors.Is(err, optimization_errors.ErrDimensionMismatch) {
+		t.Errorf("expected dimension mismatch, got %v", err)
+	}
+}
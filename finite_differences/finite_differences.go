@@ -0,0 +1,219 @@
+package finite_differences
+
+import (
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"math"
+)
+
+type Scheme int
+
+const (
+	Forward Scheme = iota
+	Central
+	Richardson
+)
+
+const (
+	richardsonTableSize = 10
+	richardsonShrink    = 1.4 // step reduction on each extrapolation level
+)
+
+type Differentiator struct {
+	scheme Scheme
+	step   float64 // relative step, scheme default is used when zero
+}
+
+func (d *Differentiator) Init(scheme Scheme, step float64) {
+	d.scheme = scheme
+	d.step = step
+}
+
+func (d *Differentiator) Gradient(targetFunc func(xs []float64) float64, dimension int) []func(xs []float64) float64 {
+	gradient := make([]func(xs []float64) float64, dimension)
+	for i := 0; i < dimension; i++ {
+		i := i
+		gradient[i] = func(xs []float64) float64 {
+			return d.PartialDerivative(targetFunc, xs, i)
+		}
+	}
+	return gradient
+}
+
+func (d *Differentiator) Hessian(targetFunc func(xs []float64) float64, dimension int) func(xs []float64) la_methods.Matrix {
+	return func(xs []float64) la_methods.Matrix {
+		var hessian la_methods.Matrix
+		hessian.Init(dimension, dimension)
+		for i := 0; i < dimension; i++ {
+			for j := i; j < dimension; j++ {
+				hessian.Points[i][j] = d.SecondPartialDerivative(targetFunc, xs, i, j)
+				hessian.Points[j][i] = hessian.Points[i][j]
+			}
+		}
+		return hessian
+	}
+}
+
+func (d *Differentiator) Jacobian(residuals func(xs []float64) []float64, dimension int) func(xs []float64) la_methods.Matrix {
+	return func(xs []float64) la_methods.Matrix {
+		var jacobian la_methods.Matrix
+		var r []float64
+		if d.scheme == Forward {
+			r = residuals(xs)
+		}
+		columns := make([][]float64, dimension)
+		for i := 0; i < dimension; i++ {
+			columns[i] = d.jacobianColumn(residuals, xs, r, i)
+		}
+		rows := 0
+		if dimension > 0 {
+			rows = len(columns[0])
+		}
+		jacobian.Init(rows, dimension)
+		for i := 0; i < dimension; i++ {
+			for j := 0; j < rows; j++ {
+				jacobian.Points[j][i] = columns[i][j]
+			}
+		}
+		return jacobian
+	}
+}
+
+func (d *Differentiator) jacobianColumn(residuals func(xs []float64) []float64, xs []float64, r []float64, i int) []float64 {
+	h := d.stepFor(xs[i], 1)
+	switch d.scheme {
+	case Forward:
+		rh := residuals(shifted(xs, i, h))
+		column := make([]float64, len(rh))
+		for j := range rh {
+			column[j] = (rh[j] - r[j]) / h
+		}
+		return column
+	case Richardson:
+		estimates := map[float64][]float64{} // residuals are evaluated once for every step
+		estimate := func(t float64) []float64 {
+			if column, ok := estimates[t]; ok {
+				return column
+			}
+			estimates[t] = centralColumn(residuals, xs, i, h*t)
+			return estimates[t]
+		}
+		column := make([]float64, len(estimate(1)))
+		for j := range column {
+			j := j
+			column[j] = extrapolate(func(t float64) float64 {
+				return estimate(t)[j]
+			})
+		}
+		return column
+	}
+	return centralColumn(residuals, xs, i, h)
+}
+
+func (d *Differentiator) PartialDerivative(targetFunc func(xs []float64) float64, xs []float64, i int) float64 {
+	h := d.stepFor(xs[i], 1)
+	switch d.scheme {
+	case Forward:
+		return (targetFunc(shifted(xs, i, h)) - targetFunc(xs)) / h
+	case Richardson:
+		return extrapolate(func(t float64) float64 {
+			return centralDerivative(targetFunc, xs, i, h*t)
+		})
+	}
+	return centralDerivative(targetFunc, xs, i, h)
+}
+
+func (d *Differentiator) SecondPartialDerivative(targetFunc func(xs []float64) float64, xs []float64, i int, j int) float64 {
+	hi := d.stepFor(xs[i], 2)
+	hj := d.stepFor(xs[j], 2)
+	switch d.scheme {
+	case Forward:
+		f := targetFunc(xs)
+		fi := targetFunc(shifted(xs, i, hi))
+		fj := targetFunc(shifted(xs, j, hj))
+		fij := targetFunc(shifted(shifted(xs, i, hi), j, hj))
+		return (fij - fi - fj + f) / (hi * hj)
+	case Richardson:
+		return extrapolate(func(t float64) float64 {
+			return centralSecondDerivative(targetFunc, xs, i, j, hi*t, hj*t)
+		})
+	}
+	return centralSecondDerivative(targetFunc, xs, i, j, hi, hj)
+}
+
+func (d *Differentiator) stepFor(x float64, order int) float64 {
+	step := d.step
+	if step == 0 {
+		step = defaultStep(d.scheme, order)
+	}
+	h := step * math.Max(math.Abs(x), 1)
+	return (x + h) - x // exactly representable step
+}
+
+func defaultStep(scheme Scheme, order int) float64 {
+	eps := math.Nextafter(1, 2) - 1
+	switch scheme {
+	case Forward:
+		return math.Pow(eps, 1/float64(order+1))
+	case Richardson:
+		return 0.01
+	}
+	return math.Pow(eps, 1/float64(order+2))
+}
+
+func shifted(xs []float64, i int, h float64) []float64 {
+	ys := make([]float64, len(xs))
+	copy(ys, xs)
+	ys[i] += h
+	return ys
+}
+
+func centralDerivative(targetFunc func(xs []float64) float64, xs []float64, i int, h float64) float64 {
+	return (targetFunc(shifted(xs, i, h)) - targetFunc(shifted(xs, i, -h))) / (2 * h)
+}
+
+func centralColumn(residuals func(xs []float64) []float64, xs []float64, i int, h float64) []float64 {
+	rp := residuals(shifted(xs, i, h))
+	rm := residuals(shifted(xs, i, -h))
+	column := make([]float64, len(rp))
+	for j := range rp {
+		column[j] = (rp[j] - rm[j]) / (2 * h)
+	}
+	return column
+}
+
+func centralSecondDerivative(targetFunc func(xs []float64) float64, xs []float64, i int, j int, hi float64, hj float64) float64 {
+	if i == j {
+		return (targetFunc(shifted(xs, i, hi)) - 2*targetFunc(xs) + targetFunc(shifted(xs, i, -hi))) / (hi * hi)
+	}
+	fpp := targetFunc(shifted(shifted(xs, i, hi), j, hj))
+	fpm := targetFunc(shifted(shifted(xs, i, hi), j, -hj))
+	fmp := targetFunc(shifted(shifted(xs, i, -hi), j, hj))
+	fmm := targetFunc(shifted(shifted(xs, i, -hi), j, -hj))
+	return (fpp - fpm - fmp + fmm) / (4 * hi * hj)
+}
+
+func extrapolate(estimate func(t float64) float64) float64 {
+	var table [richardsonTableSize][richardsonTableSize]float64
+	t := 1.0
+	table[0][0] = estimate(t)
+	result := table[0][0]
+	err := math.Inf(1)
+	for i := 1; i < richardsonTableSize; i++ {
+		t /= richardsonShrink
+		table[0][i] = estimate(t)
+		factor := richardsonShrink * richardsonShrink
+		for j := 1; j <= i; j++ {
+			table[j][i] = (table[j-1][i]*factor - table[j-1][i-1]) / (factor - 1)
+			factor *= richardsonShrink * richardsonShrink
+			errT := math.Max(math.Abs(table[j][i]-table[j-1][i]), math.Abs(table[j][i]-table[j-1][i-1]))
+			if errT <= err {
+				err = errT
+				result = table[j][i]
+			}
+		}
+		if math.Abs(table[i][i]-table[i-1][i-1]) >= 2*err {
+			break
+		}
+	}
+	return result
+}
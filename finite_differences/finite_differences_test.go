@@ -0,0 +1,88 @@
+package finite_differences
+
+import (
+	"math"
+	"testing"
+)
+
+func testFunc(xs []float64) float64 {
+	return math.Sin(xs[0])*math.Exp(xs[1]) + xs[0]*xs[0]*xs[1]
+}
+
+func testGradient(xs []float64) []float64 {
+	return []float64{
+		math.Cos(xs[0])*math.Exp(xs[1]) + 2*xs[0]*xs[1],
+		math.Sin(xs[0])*math.Exp(xs[1]) + xs[0]*xs[0],
+	}
+}
+
+func testHessian(xs []float64) [][]float64 {
+	return [][]float64{
+		{-math.Sin(xs[0])*math.Exp(xs[1]) + 2*xs[1], math.Cos(xs[0])*math.Exp(xs[1]) + 2*xs[0]},
+		{math.Cos(xs[0])*math.Exp(xs[1]) + 2*xs[0], math.Sin(xs[0]) * math.Exp(xs[1])},
+	}
+}
+
+func TestGradientSchemes(t *testing.T) {
+	tolerances := map[Scheme]float64{Forward: 1e-6, Central: 1e-9, Richardson: 1e-11}
+	point := []float64{0.7, -0.3}
+	expected := testGradient(point)
+	for scheme, tolerance := range tolerances {
+		var d Differentiator
+		d.Init(scheme, 0)
+		for i, g := range d.Gradient(testFunc, 2) {
+			if err := math.Abs(g(point) - expected[i]); err > tolerance*math.Max(1, math.Abs(expected[i])) {
+				t.Errorf("scheme %d, component %d: error %g exceeds %g", scheme, i, err, tolerance)
+			}
+		}
+	}
+}
+
+func TestHessian(t *testing.T) {
+	point := []float64{0.7, -0.3}
+	expected := testHessian(point)
+	for _, scheme := range []Scheme{Central, Richardson} {
+		var d Differentiator
+		d.Init(scheme, 0)
+		hessian := d.Hessian(testFunc, 2)(point)
+		for i := range expected {
+			for j := range expected[i] {
+				if math.Abs(hessian.Points[i][j]-expected[i][j]) > 1e-5 {
+					t.Errorf("scheme %d, element %d, %d: expected %f, got %f", scheme, i, j, expected[i][j], hessian.Points[i][j])
+				}
+			}
+		}
+	}
+}
+
+func TestJacobian(t *testing.T) {
+	residuals := func(xs []float64) []float64 {
+		return []float64{xs[0] * xs[1], math.Exp(xs[0]), xs[1] * xs[1]}
+	}
+	point := []float64{0.5, 2}
+	expected := [][]float64{{2, 0.5}, {math.Exp(0.5), 0}, {0, 4}}
+	for _, scheme := range []Scheme{Forward, Central, Richardson} {
+		var d Differentiator
+		d.Init(scheme, 0)
+		jacobian := d.Jacobian(residuals, 2)(point)
+		if len(jacobian.Points) != 3 || len(jacobian.Points[0]) != 2 {
+			t.Fatalf("scheme %d: wrong jacobian size", scheme)
+		}
+		for i := range expected {
+			for j := range expected[i] {
+				if math.Abs(jacobian.Points[i][j]-expected[i][j]) > 1e-6 {
+					t.Errorf("scheme %d, element %d, %d: expected %f, got %f", scheme, i, j, expected[i][j], jacobian.Points[i][j])
+				}
+			}
+		}
+	}
+}
+
+func TestRelativeStep(t *testing.T) {
+	var d Differentiator
+	d.Init(Central, 0)
+	large := func(xs []float64) float64 { return xs[0] * xs[0] }
+	if derivative := d.PartialDerivative(large, []float64{1e8}, 0); math.Abs(derivative-2e8)/2e8 > 1e-8 {
+		t.Errorf("derivative at large point: expected %g, got %g", 2e8, derivative)
+	}
+}
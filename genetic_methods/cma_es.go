@@ -0,0 +1,413 @@
+package genetic_methods
+
+import (
+	"context"
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"sort"
+)
+
+type RestartStrategy int
+
+const (
+	NoRestarts RestartStrategy = iota
+	IPOP                       // population doubles on every restart
+	BIPOP                      // large and small population regimes alternate
+)
+
+const (
+	tolX            = 1e-12 // relative to initial step size
+	maxCondition    = 1e14
+	historyMinimum  = 10
+	eigenRecalcRate = 10
+)
+
+type CMAES struct {
+	startPoint  []float64
+	dimension   int
+	sigma       float64 // initial step size
+	lambda      int     // population size, default is used when zero
+	eps         float64 // function values range precision
+	targetFunc  func(xs []float64) float64
+	lower       []float64
+	upper       []float64
+	restart     RestartStrategy
+	maxRestarts int
+	restarts    int
+	generations int
+	populationStats
+}
+
+type cmaRun struct {
+	mean       la_methods.Vector
+	sigma      float64
+	lambda     int
+	best       []float64
+	bestF      float64
+	iterations int
+}
+
+func (cma *CMAES) Init(startPoint []float64, dimension int, sigma float64, lambda int, eps float64,
+	maxEvaluations int, targetFunc func(xs []float64) float64) {
+	cma.startPoint = startPoint
+	cma.dimension = dimension
+	cma.sigma = sigma
+	cma.lambda = lambda
+	cma.eps = eps
+	cma.targetFunc = targetFunc
+	cma.lower = nil
+	cma.upper = nil
+	cma.restart = NoRestarts
+	cma.maxRestarts = 0
+	cma.initStats()
+	cma.SetMaxEvaluations(maxEvaluations)
+}
+
+func (cma *CMAES) SetBounds(lower []float64, upper []float64) {
+	cma.lower = lower
+	cma.upper = upper
+}
+
+func (cma *CMAES) SetRestarts(restart RestartStrategy, maxRestarts int) {
+	cma.restart = restart
+	cma.maxRestarts = maxRestarts
+}
+
+func (cma *CMAES) Restarts() int {
+	return cma.restarts
+}
+
+func (cma *CMAES) Generations() int {
+	return cma.generations
+}
+
+func (cma *CMAES) Solve() ([]float64, float64, error) {
+	return cma.SolveContext(context.Background())
+}
+
+func (cma *CMAES) SolveContext(ctx context.Context) ([]float64, float64, error) {
+	var err error
+	var mean la_methods.Vector
+	var best []float64
+	var bestF float64
+	var evaluationsLarge, evaluationsSmall int
+	err = cma.check()
+	if err != nil {
+		return nil, 0, fmt.Errorf("error checking parameters: %w", err)
+	}
+	cma.resetStats()
+	cma.restarts = 0
+	cma.generations = 0
+	lambdaDefault := cma.lambda
+	if lambdaDefault == 0 {
+		lambdaDefault = 4 + int(3*math.Log(float64(cma.dimension)))
+	}
+	lambdaLarge := lambdaDefault
+	err = mean.InitWithPoints(cma.dimension, cma.startPoint)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error during vector initializing: %w", err)
+	}
+	mean = cma.project(mean)
+	bestF = math.Inf(1)
+	for {
+		run := cmaRun{mean: mean, sigma: cma.sigma, lambda: lambdaLarge}
+		large := true
+		if cma.restart == BIPOP && cma.restarts > 0 && evaluationsSmall < evaluationsLarge {
+			u := cma.random.Float64()
+			run.lambda = int(float64(lambdaDefault) * math.Pow(float64(lambdaLarge)/float64(2*lambdaDefault), u*u))
+			run.sigma = cma.sigma * math.Pow(10, -2*cma.random.Float64())
+			large = false
+		}
+		evaluations := cma.evaluations
+		err = cma.run(ctx, &run)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during cma-es run: %w", err)
+		}
+		if large {
+			evaluationsLarge += cma.evaluations - evaluations
+		} else {
+			evaluationsSmall += cma.evaluations - evaluations
+		}
+		if run.bestF < bestF {
+			best, bestF = run.best, run.bestF
+		}
+		if cma.stopped(ctx) || cma.restart == NoRestarts || cma.restarts >= cma.maxRestarts {
+			return best, bestF, nil
+		}
+		cma.restarts++
+		if large {
+			lambdaLarge *= 2
+		}
+		mean = cma.randomMean()
+	}
+}
+
+func (cma *CMAES) check() error {
+	if cma.sigma <= 0 {
+		return fmt.Errorf("wrong step size: %f", cma.sigma)
+	}
+	if cma.lambda != 0 && cma.lambda < 2 {
+		return fmt.Errorf("wrong population size: %d", cma.lambda)
+	}
+	if len(cma.startPoint) != cma.dimension {
+		return fmt.Errorf("wrong start point length: %d", len(cma.startPoint))
+	}
+	if cma.lower != nil && len(cma.lower) != cma.dimension || cma.upper != nil && len(cma.upper) != cma.dimension {
+		return fmt.Errorf("wrong bounds length: %d, %d", len(cma.lower), len(cma.upper))
+	}
+	return nil
+}
+
+func (cma *CMAES) run(ctx context.Context, run *cmaRun) error {
+	var err error
+	n := float64(cma.dimension)
+	mu := run.lambda / 2
+	weights := make([]float64, mu)
+	var weightsSum, weightsSquaresSum float64
+	for i := range weights {
+		weights[i] = math.Log(float64(mu)+0.5) - math.Log(float64(i+1))
+		weightsSum += weights[i]
+	}
+	for i := range weights {
+		weights[i] /= weightsSum
+		weightsSquaresSum += weights[i] * weights[i]
+	}
+	mueff := 1 / weightsSquaresSum
+	cc := (4 + mueff/n) / (n + 4 + 2*mueff/n)
+	cs := (mueff + 2) / (n + mueff + 5)
+	c1 := 2 / ((n+1.3)*(n+1.3) + mueff)
+	cmu := math.Min(1-c1, 2*(mueff-2+1/mueff)/((n+2)*(n+2)+mueff))
+	damps := 1 + 2*math.Max(0, math.Sqrt((mueff-1)/(n+1))-1) + cs
+	chiN := math.Sqrt(n) * (1 - 1/(4*n) + 1/(21*n*n))
+
+	var C, B la_methods.Matrix
+	var ps, pc la_methods.Vector
+	C.Init(cma.dimension, cma.dimension)
+	C.E()
+	B.Init(cma.dimension, cma.dimension)
+	B.E()
+	D := make([]float64, cma.dimension) // square roots of covariance eigenvalues
+	for i := range D {
+		D[i] = 1
+	}
+	ps.Init(cma.dimension)
+	pc.Init(cma.dimension)
+	eigenEvaluations := 0
+	historyLength := historyMinimum + int(math.Ceil(30*n/float64(run.lambda)))
+	var history []float64
+	run.bestF = math.Inf(1)
+	points := make([]la_methods.Vector, run.lambda)
+	order := make([]int, run.lambda)
+	for generation := 0; ; generation++ {
+		if cma.stopped(ctx) {
+			return nil
+		}
+		if float64(cma.evaluations-eigenEvaluations) > float64(run.lambda)/(c1+cmu)/n/eigenRecalcRate {
+			eigenEvaluations = cma.evaluations
+			B, D, err = decompose(C)
+			if err != nil {
+				return fmt.Errorf("error decomposing covariance matrix: %w", err)
+			}
+		}
+		for k := 0; k < run.lambda; k++ {
+			var z la_methods.Vector
+			z.Init(cma.dimension)
+			for i := range z.Points {
+				z.Points[i] = D[i] * cma.random.NormFloat64()
+			}
+			y, err := B.MulV(z)
+			if err != nil {
+				return fmt.Errorf("error during matrix and vector multiplying: %w", err)
+			}
+			points[k], err = run.mean.Add(y.MulOnValue(run.sigma))
+			if err != nil {
+				return fmt.Errorf("error during vector adding: %w", err)
+			}
+			points[k] = cma.project(points[k]) // repaired point takes part in the update
+			order[k] = k
+		}
+		values := cma.evaluate(ctx, cma.targetFunc, vectorsPoints(points))
+		sort.Slice(order, func(i, j int) bool { return values[order[i]] < values[order[j]] })
+		if values[order[0]] < run.bestF {
+			run.best = points[order[0]].Points
+			run.bestF = values[order[0]]
+		}
+		meanOld := run.mean
+		run.mean.Init(cma.dimension)
+		for i := 0; i < mu; i++ {
+			run.mean, err = run.mean.Add(points[order[i]].MulOnValue(weights[i]))
+			if err != nil {
+				return fmt.Errorf("error during vector adding: %w", err)
+			}
+		}
+		yw, err := run.mean.Sub(meanOld)
+		if err != nil {
+			return fmt.Errorf("error during vector substracting: %w", err)
+		}
+		yw = yw.MulOnValue(1 / run.sigma)
+		invSqrtY, err := inverseSqrtMul(B, D, yw)
+		if err != nil {
+			return fmt.Errorf("error calculating whitened step: %w", err)
+		}
+		ps = ps.MulOnValue(1 - cs)
+		ps, err = ps.Add(invSqrtY.MulOnValue(math.Sqrt(cs * (2 - cs) * mueff)))
+		if err != nil {
+			return fmt.Errorf("error during vector adding: %w", err)
+		}
+		hsig := 0.0
+		if ps.Len()/math.Sqrt(1-math.Pow(1-cs, float64(2*(generation+1))))/chiN < 1.4+2/(n+1) {
+			hsig = 1
+		}
+		pc = pc.MulOnValue(1 - cc)
+		pc, err = pc.Add(yw.MulOnValue(hsig * math.Sqrt(cc*(2-cc)*mueff)))
+		if err != nil {
+			return fmt.Errorf("error during vector adding: %w", err)
+		}
+		C = C.MulVal(1 - c1 - cmu + (1-hsig)*c1*cc*(2-cc))
+		C, err = C.AddM(outer(pc, c1))
+		if err != nil {
+			return fmt.Errorf("error during matrix adding: %w", err)
+		}
+		for i := 0; i < mu; i++ {
+			yi, err := points[order[i]].Sub(meanOld)
+			if err != nil {
+				return fmt.Errorf("error during vector substracting: %w", err)
+			}
+			C, err = C.AddM(outer(yi, cmu*weights[i]/(run.sigma*run.sigma)))
+			if err != nil {
+				return fmt.Errorf("error during matrix adding: %w", err)
+			}
+		}
+		run.sigma *= math.Exp(cs / damps * (ps.Len()/chiN - 1))
+		run.iterations++
+		cma.notify(cma.generations, run.best, run.bestF)
+		cma.generations++
+
+		history = append(history, values[order[0]])
+		if len(history) > historyLength {
+			history = history[1:]
+		}
+		if cma.converged(run, len(history) == historyLength, history, values, order, D, C) {
+			return nil
+		}
+	}
+}
+
+func (cma *CMAES) converged(run *cmaRun, historyFull bool, history []float64, values []float64, order []int,
+	D []float64, C la_methods.Matrix) bool {
+	if historyFull {
+		minValue, maxValue := values[order[0]], values[order[len(order)-1]]
+		for _, h := range history {
+			minValue = math.Min(minValue, h)
+			maxValue = math.Max(maxValue, h)
+		}
+		if maxValue-minValue < cma.eps {
+			return true
+		}
+	}
+	maxD, minD := D[0], D[0]
+	for _, d := range D {
+		maxD = math.Max(maxD, d)
+		minD = math.Min(minD, d)
+	}
+	if minD <= 0 || (maxD/minD)*(maxD/minD) > maxCondition {
+		return true
+	}
+	for i := 0; i < cma.dimension; i++ {
+		if run.sigma*math.Sqrt(C.Points[i][i]) > tolX*cma.sigma {
+			return false
+		}
+	}
+	return true
+}
+
+func (cma *CMAES) project(x la_methods.Vector) la_methods.Vector {
+	y := x.Copy()
+	for i := range y.Points {
+		if cma.lower != nil {
+			y.Points[i] = math.Max(y.Points[i], cma.lower[i])
+		}
+		if cma.upper != nil {
+			y.Points[i] = math.Min(y.Points[i], cma.upper[i])
+		}
+	}
+	return y
+}
+
+func (cma *CMAES) randomMean() la_methods.Vector {
+	var mean la_methods.Vector
+	mean.Init(cma.dimension)
+	for i := range mean.Points {
+		if cma.lower != nil && cma.upper != nil && !math.IsInf(cma.lower[i], 0) && !math.IsInf(cma.upper[i], 0) {
+			mean.Points[i] = cma.lower[i] + cma.random.Float64()*(cma.upper[i]-cma.lower[i])
+		} else {
+			mean.Points[i] = cma.startPoint[i] + cma.sigma*cma.random.NormFloat64()
+		}
+	}
+	return cma.project(mean)
+}
+
+func decompose(C la_methods.Matrix) (la_methods.Matrix, []float64, error) {
+	var eigen mat.EigenSym
+	var vectors mat.Dense
+	var B la_methods.Matrix
+	n := C.DimensionRows
+	sym := mat.NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			sym.SetSym(i, j, (C.Points[i][j]+C.Points[j][i])/2)
+		}
+	}
+	if !eigen.Factorize(sym, true) {
+		return la_methods.Matrix{}, nil, fmt.Errorf("eigen decomposition failed")
+	}
+	values := eigen.Values(nil)
+	eigen.VectorsTo(&vectors)
+	B.Init(n, n)
+	D := make([]float64, n)
+	for i := 0; i < n; i++ {
+		D[i] = math.Sqrt(math.Max(values[i], 0))
+		for j := 0; j < n; j++ {
+			B.Points[i][j] = vectors.At(i, j)
+		}
+	}
+	return B, D, nil
+}
+
+func inverseSqrtMul(B la_methods.Matrix, D []float64, v la_methods.Vector) (la_methods.Vector, error) {
+	Bt, err := B.Transponate()
+	if err != nil {
+		return la_methods.Vector{}, fmt.Errorf("error transponating matrix: %w", err)
+	}
+	w, err := Bt.MulV(v)
+	if err != nil {
+		return la_methods.Vector{}, fmt.Errorf("error during matrix and vector multiplying: %w", err)
+	}
+	for i := range w.Points {
+		if D[i] > 0 {
+			w.Points[i] /= D[i]
+		}
+	}
+	return B.MulV(w)
+}
+
+func vectorsPoints(vectors []la_methods.Vector) [][]float64 {
+	points := make([][]float64, len(vectors))
+	for i, v := range vectors {
+		points[i] = v.Points
+	}
+	return points
+}
+
+func outer(v la_methods.Vector, weight float64) la_methods.Matrix {
+	var product la_methods.Matrix
+	product.Init(len(v.Points), len(v.Points))
+	for i := range v.Points {
+		for j := range v.Points {
+			product.Points[i][j] = weight * v.Points[i] * v.Points[j]
+		}
+	}
+	return product
+}
@@ -0,0 +1,98 @@
+package genetic_methods
+
+import (
+	"github.com/saskamegaprogrammist/optimization_methods/test_functions"
+	"math"
+	"testing"
+)
+
+func ellipsoid(xs []float64) float64 {
+	var sum float64
+	for i, x := range xs {
+		sum += math.Pow(1e6, float64(i)/float64(len(xs)-1)) * x * x
+	}
+	return sum
+}
+
+func TestCMAESConvergence(t *testing.T) {
+	functions := map[string]func(xs []float64) float64{"rosenbrock": test_functions.Rosenbrock, "ellipsoid": ellipsoid}
+	for name, f := range functions {
+		var cma CMAES
+		cma.Init([]float64{-1, 2, -1, 2, 0}, 5, 0.5, 0, 1e-14, 20000, f)
+		cma.SetSeed(1)
+		x, value, err := cma.Solve()
+		if err != nil {
+			t.Fatalf("%s: error during cma-es: %v", name, err)
+		}
+		if value > 1e-8 || value != f(x) {
+			t.Errorf("%s: minimum isn't found: %v, %g", name, x, value)
+		}
+		if cma.Evaluations() > 20000+cma.Generations() {
+			t.Errorf("%s: evaluations limit isn't respected: %d", name, cma.Evaluations())
+		}
+	}
+}
+
+func TestCMAESBounds(t *testing.T) {
+	lower, upper := []float64{2, 2}, []float64{4, 4}
+	var cma CMAES
+	cma.Init([]float64{3, 3}, 2, 0.5, 0, 1e-14, 5000, test_functions.Sphere)
+	cma.SetBounds(lower, upper)
+	cma.SetSeed(1)
+	x, _, err := cma.Solve()
+	if err != nil {
+		t.Fatalf("error during cma-es: %v", err)
+	}
+	for i := range x {
+		if x[i] < lower[i] || x[i] > upper[i] {
+			t.Fatalf("point %v is out of bounds", x)
+		}
+	}
+	if math.Abs(x[0]-2) > 1e-6 || math.Abs(x[1]-2) > 1e-6 {
+		t.Errorf("expected minimum at corner [2 2], got %v", x)
+	}
+}
+
+func TestCMAESRestarts(t *testing.T) {
+	lower, upper := []float64{-5, -5, -5, -5}, []float64{5, 5, 5, 5}
+	var single CMAES
+	single.Init([]float64{3, 3, 3, 3}, 4, 2, 0, 1e-10, 100000, test_functions.Rastrigin)
+	single.SetBounds(lower, upper)
+	single.SetSeed(2)
+	_, fSingle, err := single.Solve()
+	if err != nil {
+		t.Fatalf("error during cma-es: %v", err)
+	}
+	for _, restart := range []RestartStrategy{IPOP, BIPOP} {
+		var cma CMAES
+		cma.Init([]float64{3, 3, 3, 3}, 4, 2, 0, 1e-10, 100000, test_functions.Rastrigin)
+		cma.SetBounds(lower, upper)
+		cma.SetRestarts(restart, 9)
+		cma.SetSeed(2)
+		x, f, err := cma.Solve()
+		if err != nil {
+			t.Fatalf("strategy %d: error during cma-es: %v", restart, err)
+		}
+		if cma.Restarts() == 0 {
+			t.Errorf("strategy %d: restarts aren't made", restart)
+		}
+		if f >= fSingle {
+			t.Errorf("strategy %d: restarts didn't improve minimum %g: %v, %g", restart, fSingle, x, f)
+		}
+	}
+}
+
+func TestCMAESWrongParameters(t *testing.T) {
+	cases := map[string]func(cma *CMAES){
+		"step size":   func(cma *CMAES) { cma.Init([]float64{0, 0}, 2, 0, 0, 1e-10, 100, test_functions.Sphere) },
+		"population":  func(cma *CMAES) { cma.Init([]float64{0, 0}, 2, 1, 1, 1e-10, 100, test_functions.Sphere) },
+		"start point": func(cma *CMAES) { cma.Init([]float64{0}, 2, 1, 0, 1e-10, 100, test_functions.Sphere) },
+	}
+	for name, init := range cases {
+		var cma CMAES
+		init(&cma)
+		if _, _, err := cma.Solve(); err == nil {
+			t.Errorf("wrong %s is accepted", name)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package genetic_methods
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+)
+
+type DEStrategy int
+
+const (
+	RandOneBin DEStrategy = iota
+	BestOneBin
+	CurrentToPBestOneBin // jade with adaptive f and cr
+)
+
+const (
+	jadeAdaptationRate = 0.1
+	jadeGreediness     = 0.05 // part of population used as pbest
+	jadeDeviation      = 0.1
+)
+
+type DifferentialEvolution struct {
+	lower          []float64
+	upper          []float64
+	dimension      int
+	np             int     // population size
+	f              float64 // differential weight
+	cr             float64 // crossover probability
+	strategy       DEStrategy
+	maxGenerations int
+	eps            float64 // population values range precision
+	targetFunc     func(xs []float64) float64
+	generations    int
+	populationStats
+}
+
+func (de *DifferentialEvolution) Init(lower []float64, upper []float64, dimension int, np int, f float64, cr float64,
+	strategy DEStrategy, maxGenerations int, eps float64, targetFunc func(xs []float64) float64) {
+	de.lower = lower
+	de.upper = upper
+	de.dimension = dimension
+	de.np = np
+	de.f = f
+	de.cr = cr
+	de.strategy = strategy
+	de.maxGenerations = maxGenerations
+	de.eps = eps
+	de.targetFunc = targetFunc
+	de.initStats()
+}
+
+func (de *DifferentialEvolution) Generations() int {
+	return de.generations
+}
+
+func (de *DifferentialEvolution) Solve() ([]float64, float64, error) {
+	return de.SolveContext(context.Background())
+}
+
+func (de *DifferentialEvolution) SolveContext(ctx context.Context) ([]float64, float64, error) {
+	err := checkBounds(de.lower, de.upper, de.dimension)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error checking bounds: %w", err)
+	}
+	if de.np < 4 {
+		return nil, 0, fmt.Errorf("wrong population size: %d", de.np)
+	}
+	if de.f <= 0 || de.f > 2 || de.cr < 0 || de.cr > 1 {
+		return nil, 0, fmt.Errorf("wrong differential evolution parameters: %f, %f", de.f, de.cr)
+	}
+	de.resetStats()
+	de.generations = 0
+	population := make([][]float64, de.np)
+	for i := range population {
+		population[i] = de.randomPoint(de.lower, de.upper)
+	}
+	values := de.evaluate(ctx, de.targetFunc, population)
+	var archive [][]float64
+	muF, muCR := de.f, de.cr
+	fs := make([]float64, de.np)
+	crs := make([]float64, de.np)
+	for ; de.generations < de.maxGenerations; de.generations++ {
+		if de.stopped(ctx) || valuesRange(values) < de.eps {
+			break
+		}
+		best := bestIndex(values)
+		order := make([]int, de.np)
+		for i := range order {
+			order[i] = i
+		}
+		sort.Slice(order, func(i, j int) bool { return values[order[i]] < values[order[j]] })
+		trials := make([][]float64, de.np)
+		for i := range population {
+			fs[i], crs[i] = de.f, de.cr
+			if de.strategy == CurrentToPBestOneBin {
+				fs[i], crs[i] = de.adaptiveParameters(muF, muCR)
+			}
+			trials[i] = de.crossover(population[i], de.mutate(population, archive, order, best, i, fs[i]), crs[i])
+		}
+		trialValues := de.evaluate(ctx, de.targetFunc, trials)
+		var successF, successCR []float64
+		for i := range population {
+			if trialValues[i] > values[i] {
+				continue
+			}
+			if trialValues[i] < values[i] {
+				archive = append(archive, population[i])
+				successF = append(successF, fs[i])
+				successCR = append(successCR, crs[i])
+			}
+			population[i], values[i] = trials[i], trialValues[i]
+		}
+		if de.strategy == CurrentToPBestOneBin {
+			for len(archive) > de.np {
+				j := de.random.Intn(len(archive))
+				archive = append(archive[:j], archive[j+1:]...)
+			}
+			if len(successF) > 0 {
+				muCR = (1-jadeAdaptationRate)*muCR + jadeAdaptationRate*mean(successCR)
+				muF = (1-jadeAdaptationRate)*muF + jadeAdaptationRate*lehmerMean(successF)
+			}
+		}
+		best = bestIndex(values)
+		de.notify(de.generations, population[best], values[best])
+	}
+	best := bestIndex(values)
+	return population[best], values[best], nil
+}
+
+func (de *DifferentialEvolution) adaptiveParameters(muF float64, muCR float64) (float64, float64) {
+	cr := math.Min(math.Max(muCR+jadeDeviation*de.random.NormFloat64(), 0), 1)
+	f := 0.0
+	for f <= 0 {
+		f = muF + jadeDeviation*math.Tan(math.Pi*(de.random.Float64()-0.5)) // cauchy distribution
+	}
+	return math.Min(f, 1), cr
+}
+
+func (de *DifferentialEvolution) mutate(population [][]float64, archive [][]float64, order []int, best int, i int, f float64) []float64 {
+	mutant := make([]float64, de.dimension)
+	switch de.strategy {
+	case BestOneBin:
+		r := de.distinct(i, best, 2)
+		for j := range mutant {
+			mutant[j] = population[best][j] + f*(population[r[0]][j]-population[r[1]][j])
+		}
+	case CurrentToPBestOneBin:
+		top := int(math.Max(1, math.Round(jadeGreediness*float64(de.np))))
+		pBest := order[de.random.Intn(top)]
+		r := de.distinct(i, i, 1)
+		x2 := population[r[0]]
+		for x2Index := r[0]; x2Index == r[0] || x2Index == i; {
+			x2Index = de.random.Intn(de.np + len(archive))
+			if x2Index >= de.np {
+				x2 = archive[x2Index-de.np]
+				break
+			}
+			x2 = population[x2Index]
+		}
+		for j := range mutant {
+			mutant[j] = population[i][j] + f*(population[pBest][j]-population[i][j]) + f*(population[r[0]][j]-x2[j])
+		}
+	default:
+		r := de.distinct(i, i, 3)
+		for j := range mutant {
+			mutant[j] = population[r[0]][j] + f*(population[r[1]][j]-population[r[2]][j])
+		}
+	}
+	return mutant
+}
+
+func (de *DifferentialEvolution) crossover(x []float64, mutant []float64, cr float64) []float64 {
+	trial := make([]float64, de.dimension)
+	jRand := de.random.Intn(de.dimension)
+	for j := range trial {
+		trial[j] = x[j]
+		if j == jRand || de.random.Float64() < cr {
+			trial[j] = mutant[j]
+		}
+		if trial[j] < de.lower[j] {
+			trial[j] = (de.lower[j] + x[j]) / 2
+		} else if trial[j] > de.upper[j] {
+			trial[j] = (de.upper[j] + x[j]) / 2
+		}
+	}
+	return trial
+}
+
+func (de *DifferentialEvolution) distinct(first int, second int, n int) []int {
+	chosen := make([]int, 0, n)
+	for len(chosen) < n {
+		r := de.random.Intn(de.np)
+		if r == first || r == second || contains(chosen, r) {
+			continue
+		}
+		chosen = append(chosen, r)
+	}
+	return chosen
+}
+
+func contains(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func mean(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func lehmerMean(values []float64) float64 {
+	var sum, squaresSum float64
+	for _, v := range values {
+		sum += v
+		squaresSum += v * v
+	}
+	return squaresSum / sum
+}
@@ -0,0 +1,72 @@
+package genetic_methods
+
+import (
+	"github.com/saskamegaprogrammist/optimization_methods/test_functions"
+	"testing"
+)
+
+func TestDifferentialEvolutionStrategies(t *testing.T) {
+	shifted := func(xs []float64) float64 {
+		return test_functions.Sphere([]float64{xs[0] - 1, xs[1] + 2, xs[2] - 3, xs[3]})
+	}
+	lower, upper := []float64{-5, -5, -5, -5}, []float64{5, 5, 5, 5}
+	for _, strategy := range []DEStrategy{RandOneBin, BestOneBin, CurrentToPBestOneBin} {
+		var de DifferentialEvolution
+		de.Init(lower, upper, 4, 40, 0.5, 0.9, strategy, 2000, 1e-14, shifted)
+		de.SetSeed(1)
+		x, f, err := de.Solve()
+		if err != nil {
+			t.Fatalf("strategy %d: error during differential evolution: %v", strategy, err)
+		}
+		if f > 1e-10 || f != shifted(x) {
+			t.Errorf("strategy %d: expected minimum at [1 -2 3 0], got %v, %g", strategy, x, f)
+		}
+	}
+}
+
+func TestDifferentialEvolutionRosenbrock(t *testing.T) {
+	var de DifferentialEvolution
+	de.Init([]float64{-5, -5}, []float64{5, 5}, 2, 20, 0.5, 0.9, CurrentToPBestOneBin, 2000, 1e-14, test_functions.Rosenbrock)
+	de.SetSeed(1)
+	x, f, err := de.Solve()
+	if err != nil {
+		t.Fatalf("error during differential evolution: %v", err)
+	}
+	if f > 1e-8 {
+		t.Errorf("expected minimum at [1 1], got %v, %g", x, f)
+	}
+}
+
+func TestDifferentialEvolutionMaxEvaluations(t *testing.T) {
+	var de DifferentialEvolution
+	de.Init([]float64{-5, -5}, []float64{5, 5}, 2, 20, 0.5, 0.9, RandOneBin, 10000, 0, test_functions.Sphere)
+	de.SetMaxEvaluations(200)
+	_, _, err := de.Solve()
+	if err != nil {
+		t.Fatalf("error during differential evolution: %v", err)
+	}
+	if de.Evaluations() < 200 || de.Evaluations() > 220 {
+		t.Errorf("evaluations limit 200 isn't respected: %d", de.Evaluations())
+	}
+}
+
+func TestDifferentialEvolutionWrongParameters(t *testing.T) {
+	cases := map[string]func(de *DifferentialEvolution){
+		"bounds": func(de *DifferentialEvolution) {
+			de.Init([]float64{5, -5}, []float64{-5, 5}, 2, 20, 0.5, 0.9, RandOneBin, 10, 0, test_functions.Sphere)
+		},
+		"population": func(de *DifferentialEvolution) {
+			de.Init([]float64{-5, -5}, []float64{5, 5}, 2, 3, 0.5, 0.9, RandOneBin, 10, 0, test_functions.Sphere)
+		},
+		"crossover": func(de *DifferentialEvolution) {
+			de.Init([]float64{-5, -5}, []float64{5, 5}, 2, 20, 0.5, 1.5, RandOneBin, 10, 0, test_functions.Sphere)
+		},
+	}
+	for name, init := range cases {
+		var de DifferentialEvolution
+		init(&de)
+		if _, _, err := de.Solve(); err == nil {
+			t.Errorf("wrong %s are accepted", name)
+		}
+	}
+}
@@ -1,10 +1,11 @@
 package genetic_methods
 
 import (
+	"context"
+	"fmt"
 	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
 	"github.com/saskamegaprogrammist/optimization_methods/random_points_gen"
-	"math/rand"
-	"time"
+	"math"
 )
 
 var (
@@ -23,11 +24,12 @@ type GeneticAlgorithm struct {
 	dimension   int
 	targetFunc  func(xs []float64) float64
 	fitnessFunc func(xs []float64) float64
-	generator   random_points_gen.StDistributionGen
+	generator   random_points_gen.Generator
 	//search      many_dimension_search.NelderMeadSearch
 	startPoint []float64
 	alpha      float64
 	beta       float64
+	populationStats
 }
 
 func (ga *GeneticAlgorithm) Init(alpha float64, beta float64, Np int, Mp int, startPoint []float64, dimension int,
@@ -40,10 +42,40 @@ func (ga *GeneticAlgorithm) Init(alpha float64, beta float64, Np int, Mp int, st
 	ga.beta = beta
 	ga.alpha = alpha
 	ga.dimension = dimension
-	ga.generator.Init(alpha, beta, Mp, dimension)
+	ga.initStats()
+	var generator random_points_gen.StDistributionGen
+	generator.Init(alpha, beta, Mp, dimension)
+	ga.SetGenerator(&generator)
+	ga.SetEvaluator(SequentialEvaluator{})
+}
+
+func (ga *GeneticAlgorithm) SetGenerator(generator random_points_gen.Generator) {
+	ga.generator = generator
+	if rg, ok := generator.(random_points_gen.RandomGenerator); ok {
+		rg.SetRandom(ga.random)
+	}
+}
+
+func (ga *GeneticAlgorithm) SetSeed(seed int64) {
+	ga.populationStats.SetSeed(seed)
+	ga.SetGenerator(ga.generator)
+}
+
+func (ga *GeneticAlgorithm) SetEvaluator(evaluator Evaluator) {
+	var memo MemoEvaluator
+	memo.Init(evaluator) // fitness is memoised per individual
+	ga.populationStats.SetEvaluator(&memo)
+}
+
+func (ga *GeneticAlgorithm) SetParallelism(workers int) {
+	ga.SetEvaluator(ParallelEvaluator{Workers: workers})
 }
 
 func (ga *GeneticAlgorithm) Solve() ([]float64, float64, error) {
+	return ga.SolveContext(context.Background())
+}
+
+func (ga *GeneticAlgorithm) SolveContext(ctx context.Context) ([]float64, float64, error) {
 	//var err error
 	var t = 1
 	var k = 1
@@ -51,16 +83,17 @@ func (ga *GeneticAlgorithm) Solve() ([]float64, float64, error) {
 	// generate initial population
 
 	pointsInit := ga.generator.Generate()
+	if len(pointsInit) != ga.Mp {
+		return nil, 0, fmt.Errorf("wrong generated population size: %d", len(pointsInit))
+	}
 	var vectorsInit = make([]la_methods.Vector, ga.Mp)
-	var fitnessVals = make([]float64, ga.Mp)
+	ga.resetStats()
 
 	for i, p := range pointsInit {
 		vectorsInit[i] = la_methods.Vector{
 			Points:    p,
 			Dimension: ga.dimension,
 		}
-		fitnessVals[i] = ga.fitnessFunc(p)
-		//fmt.Println(p, ga.fitnessFunc(p))
 	}
 
 	var max float64
@@ -72,17 +105,16 @@ func (ga *GeneticAlgorithm) Solve() ([]float64, float64, error) {
 		var fitnessValsSum float64
 		var minFitness = float64(100000000)
 		var minFitnessIndex int
-		for ; k <= ga.Mp; k++ {
+		for ; k <= ga.Mp && !ga.stopped(ctx); k++ {
 			//fmt.Println(k)
 			// calculate cumulative probability
 
 			var qs = make([]float64, ga.Mp)
 			var sum float64
-			var fV float64
-			for i, p := range points {
-				fV = ga.fitnessFunc(p)
+			fitnessVals = ga.evaluate(ctx, ga.fitnessFunc, points)
+			for i, fV := range fitnessVals {
 				if fV < minFitness {
-					minFitness = ga.fitnessFunc(p)
+					minFitness = fV
 					minFitnessIndex = i
 				}
 				fitnessValsSum += fV
@@ -94,8 +126,7 @@ func (ga *GeneticAlgorithm) Solve() ([]float64, float64, error) {
 
 			// selection
 
-			randomSource := rand.NewSource(time.Now().UnixNano())
-			random := rand.New(randomSource)
+			random := ga.random
 
 			var indexMap = make(map[int]bool, ga.Mp)
 
@@ -192,7 +223,7 @@ func (ga *GeneticAlgorithm) Solve() ([]float64, float64, error) {
 			var rInt int
 			for i := 0; i < mutationParentPairsLen; i++ {
 				rInt = random.Intn(ga.dimension)
-				r = rand.Float64()*(ga.beta-ga.alpha) + ga.alpha
+				r = random.Float64()*(ga.beta-ga.alpha) + ga.alpha
 				mutationParentPoints[i].point[rInt] = r
 			}
 
@@ -201,7 +232,7 @@ func (ga *GeneticAlgorithm) Solve() ([]float64, float64, error) {
 			if mutationParentPairsLen != 0 {
 				rInt = random.Intn(mutationParentPairsLen)
 				points[minFitnessIndex] = mutationParentPoints[rInt].point
-				fitnessVals[minFitnessIndex] = ga.fitnessFunc(mutationParentPoints[rInt].point)
+				fitnessVals[minFitnessIndex] = ga.evaluate(ctx, ga.fitnessFunc, [][]float64{mutationParentPoints[rInt].point})[0]
 			}
 
 			//for i := 0; i < len(points); i++ {
@@ -211,13 +242,17 @@ func (ga *GeneticAlgorithm) Solve() ([]float64, float64, error) {
 		var maxI int
 		var maxFitness = float64(0)
 		for i := 0; i < ga.Mp; i++ {
-			if fitnessVals[i] > maxFitness {
+			if fitnessVals[i] > maxFitness && !math.IsInf(fitnessVals[i], 1) { // skip not evaluated
 				maxFitness = fitnessVals[i]
 				maxI = i
 			}
 		}
 		max = ga.targetFunc(points[maxI])
 		maxPoint = points[maxI]
+		ga.notify(t, maxPoint, max)
+		if ga.stopped(ctx) {
+			break
+		}
 	}
 
 	return maxPoint, max, nil
@@ -0,0 +1,137 @@
+package genetic_methods
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+type PSOVariant int
+
+const (
+	InertiaWeight PSOVariant = iota // linearly decreasing inertia
+	Constriction                    // clerc constriction factor
+)
+
+const (
+	inertiaStart             = 0.9
+	inertiaEnd               = 0.4
+	inertiaAcceleration      = 2.0
+	constrictionAcceleration = 2.05
+	velocityClamp            = 0.2 // part of bounds width
+)
+
+type ParticleSwarm struct {
+	lower         []float64
+	upper         []float64
+	dimension     int
+	swarmSize     int
+	variant       PSOVariant
+	maxIterations int
+	eps           float64 // personal best values range precision
+	targetFunc    func(xs []float64) float64
+	c1            float64 // cognitive coefficient
+	c2            float64 // social coefficient
+	iterations    int
+	populationStats
+}
+
+func (pso *ParticleSwarm) Init(lower []float64, upper []float64, dimension int, swarmSize int, variant PSOVariant,
+	maxIterations int, eps float64, targetFunc func(xs []float64) float64) {
+	pso.lower = lower
+	pso.upper = upper
+	pso.dimension = dimension
+	pso.swarmSize = swarmSize
+	pso.variant = variant
+	pso.maxIterations = maxIterations
+	pso.eps = eps
+	pso.targetFunc = targetFunc
+	pso.c1, pso.c2 = inertiaAcceleration, inertiaAcceleration
+	if variant == Constriction {
+		pso.c1, pso.c2 = constrictionAcceleration, constrictionAcceleration
+	}
+	pso.initStats()
+}
+
+func (pso *ParticleSwarm) SetCoefficients(c1 float64, c2 float64) {
+	pso.c1 = c1
+	pso.c2 = c2
+}
+
+func (pso *ParticleSwarm) Iterations() int {
+	return pso.iterations
+}
+
+func (pso *ParticleSwarm) Solve() ([]float64, float64, error) {
+	return pso.SolveContext(context.Background())
+}
+
+func (pso *ParticleSwarm) SolveContext(ctx context.Context) ([]float64, float64, error) {
+	err := checkBounds(pso.lower, pso.upper, pso.dimension)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error checking bounds: %w", err)
+	}
+	if pso.swarmSize < 2 {
+		return nil, 0, fmt.Errorf("wrong swarm size: %d", pso.swarmSize)
+	}
+	chi := 1.0
+	if pso.variant == Constriction {
+		phi := pso.c1 + pso.c2
+		if phi <= 4 {
+			return nil, 0, fmt.Errorf("wrong constriction coefficients sum: %f", phi)
+		}
+		chi = 2 / math.Abs(2-phi-math.Sqrt(phi*phi-4*phi))
+	}
+	pso.resetStats()
+	pso.iterations = 0
+	vMax := make([]float64, pso.dimension)
+	for j := range vMax {
+		vMax[j] = velocityClamp * (pso.upper[j] - pso.lower[j])
+	}
+	positions := make([][]float64, pso.swarmSize)
+	velocities := make([][]float64, pso.swarmSize)
+	bestPositions := make([][]float64, pso.swarmSize)
+	for i := range positions {
+		positions[i] = pso.randomPoint(pso.lower, pso.upper)
+		velocities[i] = make([]float64, pso.dimension)
+		for j := range velocities[i] {
+			velocities[i][j] = (2*pso.random.Float64() - 1) * vMax[j]
+		}
+		bestPositions[i] = append([]float64{}, positions[i]...)
+	}
+	bestValues := pso.evaluate(ctx, pso.targetFunc, positions)
+	global := bestIndex(bestValues)
+	for ; pso.iterations < pso.maxIterations; pso.iterations++ {
+		if pso.stopped(ctx) || valuesRange(bestValues) < pso.eps {
+			break
+		}
+		w := 1.0
+		if pso.variant == InertiaWeight {
+			w = inertiaStart - (inertiaStart-inertiaEnd)*float64(pso.iterations)/float64(pso.maxIterations)
+		}
+		for i := range positions {
+			for j := range positions[i] {
+				v := chi * (w*velocities[i][j] +
+					pso.c1*pso.random.Float64()*(bestPositions[i][j]-positions[i][j]) +
+					pso.c2*pso.random.Float64()*(bestPositions[global][j]-positions[i][j]))
+				v = math.Max(-vMax[j], math.Min(v, vMax[j]))
+				x := positions[i][j] + v
+				if x < pso.lower[j] || x > pso.upper[j] {
+					x = math.Max(pso.lower[j], math.Min(x, pso.upper[j]))
+					v = 0 // particle stops on the bound
+				}
+				positions[i][j], velocities[i][j] = x, v
+			}
+		}
+		values := pso.evaluate(ctx, pso.targetFunc, positions)
+		for i, value := range values {
+			if value < bestValues[i] {
+				bestValues[i] = value
+				copy(bestPositions[i], positions[i])
+			}
+		}
+		global = bestIndex(bestValues)
+		pso.notify(pso.iterations, bestPositions[global], bestValues[global])
+	}
+	return bestPositions[global], bestValues[global], nil
+}
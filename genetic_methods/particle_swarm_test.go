@@ -0,0 +1,56 @@
+package genetic_methods
+
+import (
+	"github.com/saskamegaprogrammist/optimization_methods/test_functions"
+	"testing"
+)
+
+func TestParticleSwarmVariants(t *testing.T) {
+	lower, upper := []float64{-5, -5}, []float64{5, 5}
+	for _, variant := range []PSOVariant{InertiaWeight, Constriction} {
+		var pso ParticleSwarm
+		pso.Init(lower, upper, 2, 30, variant, 1000, 1e-14, test_functions.Rosenbrock)
+		pso.SetSeed(1)
+		x, f, err := pso.Solve()
+		if err != nil {
+			t.Fatalf("variant %d: error during particle swarm: %v", variant, err)
+		}
+		if f > 1e-4 || f != test_functions.Rosenbrock(x) {
+			t.Errorf("variant %d: expected minimum at [1 1], got %v, %g", variant, x, f)
+		}
+		for i := range x {
+			if x[i] < lower[i] || x[i] > upper[i] {
+				t.Errorf("variant %d: point %v is out of bounds", variant, x)
+			}
+		}
+	}
+}
+
+func TestParticleSwarmShiftedOptimum(t *testing.T) {
+	shifted := func(xs []float64) float64 { return test_functions.Sphere([]float64{xs[0] - 4.5, xs[1] + 4.5}) }
+	var pso ParticleSwarm
+	pso.Init([]float64{-5, -5}, []float64{5, 5}, 2, 20, Constriction, 500, 1e-14, shifted)
+	pso.SetSeed(3)
+	x, _, err := pso.Solve()
+	if err != nil {
+		t.Fatalf("error during particle swarm: %v", err)
+	}
+	if abs(x[0]-4.5) > 1e-4 || abs(x[1]+4.5) > 1e-4 {
+		t.Errorf("expected minimum at [4.5 -4.5], got %v", x)
+	}
+}
+
+func TestParticleSwarmWrongBounds(t *testing.T) {
+	var pso ParticleSwarm
+	pso.Init([]float64{-5}, []float64{5, 5}, 2, 20, Constriction, 100, 0, test_functions.Sphere)
+	if _, _, err := pso.Solve(); err == nil {
+		t.Errorf("wrong bounds length is accepted")
+	}
+}
+
+func abs(x float64) float64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
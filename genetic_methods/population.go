@@ -0,0 +1,255 @@
+package genetic_methods
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/many_dimension_search"
+	"math"
+	"math/rand"
+	"runtime"
+	"sync"
+	"time"
+)
+
+type Evaluator interface {
+	Evaluate(ctx context.Context, targetFunc func(xs []float64) float64, points [][]float64) []float64
+}
+
+type SequentialEvaluator struct {
+}
+
+func (se SequentialEvaluator) Evaluate(ctx context.Context, targetFunc func(xs []float64) float64, points [][]float64) []float64 {
+	values := make([]float64, len(points))
+	for i, point := range points {
+		if ctx.Err() != nil {
+			values[i] = math.Inf(1) // not evaluated
+			continue
+		}
+		values[i] = targetFunc(point)
+	}
+	return values
+}
+
+type ParallelEvaluator struct {
+	Workers int // zero means GOMAXPROCS, larger values are bound by it
+}
+
+func (pe ParallelEvaluator) Evaluate(ctx context.Context, targetFunc func(xs []float64) float64, points [][]float64) []float64 {
+	workers := runtime.GOMAXPROCS(0)
+	if pe.Workers > 0 && pe.Workers < workers {
+		workers = pe.Workers
+	}
+	values := make([]float64, len(points))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if ctx.Err() != nil {
+					values[i] = math.Inf(1) // not evaluated
+					continue
+				}
+				values[i] = targetFunc(points[i])
+			}
+		}()
+	}
+	for i := range points {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return values
+}
+
+type MemoEvaluator struct {
+	evaluator Evaluator
+	cache     map[string]float64
+	hits      int
+	evaluated int // target function evaluations
+}
+
+func (me *MemoEvaluator) Init(evaluator Evaluator) {
+	me.evaluator = evaluator
+	me.Reset()
+}
+
+func (me *MemoEvaluator) Reset() {
+	me.cache = make(map[string]float64)
+	me.hits = 0
+	me.evaluated = 0
+}
+
+func (me *MemoEvaluator) Hits() int {
+	return me.hits
+}
+
+func (me *MemoEvaluator) Evaluated() int {
+	return me.evaluated
+}
+
+func (me *MemoEvaluator) Evaluate(ctx context.Context, targetFunc func(xs []float64) float64, points [][]float64) []float64 {
+	values := make([]float64, len(points))
+	keys := make([]string, len(points))
+	var missing [][]float64
+	var missingIndexes []int
+	pending := make(map[string]bool)
+	for i, point := range points {
+		keys[i] = pointKey(point)
+		value, ok := me.cache[keys[i]]
+		if ok {
+			values[i] = value
+			me.hits++
+			continue
+		}
+		if !pending[keys[i]] {
+			missing = append(missing, point)
+			pending[keys[i]] = true
+		}
+		missingIndexes = append(missingIndexes, i)
+	}
+	if len(missing) != 0 {
+		evaluated := me.evaluator.Evaluate(ctx, targetFunc, missing)
+		cancelled := ctx.Err() != nil
+		for i, point := range missing {
+			if cancelled && math.IsInf(evaluated[i], 1) {
+				continue // not evaluated
+			}
+			me.cache[pointKey(point)] = evaluated[i]
+			me.evaluated++
+		}
+		for _, i := range missingIndexes {
+			value, ok := me.cache[keys[i]]
+			if !ok {
+				value = math.Inf(1)
+			}
+			values[i] = value
+		}
+	}
+	return values
+}
+
+func pointKey(point []float64) string {
+	key := make([]byte, 8*len(point))
+	for i, v := range point {
+		binary.LittleEndian.PutUint64(key[8*i:], math.Float64bits(v))
+	}
+	return string(key)
+}
+
+type populationStats struct {
+	evaluator      Evaluator
+	random         *rand.Rand
+	evaluations    int
+	maxEvaluations int // zero means no limit
+	observer       many_dimension_search.Observer
+	observed       []float64 // last observed best point
+}
+
+func (ps *populationStats) initStats() {
+	ps.evaluator = SequentialEvaluator{}
+	ps.random = rand.New(rand.NewSource(time.Now().UnixNano()))
+	ps.maxEvaluations = 0
+}
+
+func (ps *populationStats) SetEvaluator(evaluator Evaluator) {
+	ps.evaluator = evaluator
+}
+
+func (ps *populationStats) SetSeed(seed int64) {
+	ps.random = rand.New(rand.NewSource(seed))
+}
+
+func (ps *populationStats) SetMaxEvaluations(maxEvaluations int) {
+	ps.maxEvaluations = maxEvaluations
+}
+
+func (ps *populationStats) Evaluations() int {
+	return ps.evaluations
+}
+
+func (ps *populationStats) SetObserver(observer many_dimension_search.Observer) {
+	ps.observer = observer
+}
+
+func (ps *populationStats) resetStats() {
+	ps.evaluations = 0
+	ps.observed = nil
+	if memo, ok := ps.evaluator.(*MemoEvaluator); ok {
+		memo.Reset() // cached values may belong to another target function
+	}
+}
+
+func (ps *populationStats) notify(k int, x []float64, f float64) {
+	if ps.observer == nil {
+		return
+	}
+	var step float64
+	for i := range ps.observed {
+		step += (x[i] - ps.observed[i]) * (x[i] - ps.observed[i])
+	}
+	ps.observed = append(ps.observed[:0], x...)
+	ps.observer(many_dimension_search.IterationInfo{
+		Iteration:       k,
+		X:               append([]float64{}, x...),
+		F:               f,
+		Step:            math.Sqrt(step), // best point displacement
+		FuncEvaluations: ps.evaluations,
+	})
+}
+
+func (ps *populationStats) evaluate(ctx context.Context, targetFunc func(xs []float64) float64, points [][]float64) []float64 {
+	if memo, ok := ps.evaluator.(*MemoEvaluator); ok {
+		evaluated := memo.Evaluated()
+		values := memo.Evaluate(ctx, targetFunc, points)
+		ps.evaluations += memo.Evaluated() - evaluated // cache hits aren't counted
+		return values
+	}
+	ps.evaluations += len(points)
+	return ps.evaluator.Evaluate(ctx, targetFunc, points)
+}
+
+func (ps *populationStats) stopped(ctx context.Context) bool {
+	return ctx.Err() != nil || ps.maxEvaluations > 0 && ps.evaluations >= ps.maxEvaluations
+}
+
+func (ps *populationStats) randomPoint(lower []float64, upper []float64) []float64 {
+	point := make([]float64, len(lower))
+	for i := range point {
+		point[i] = lower[i] + ps.random.Float64()*(upper[i]-lower[i])
+	}
+	return point
+}
+
+func checkBounds(lower []float64, upper []float64, dimension int) error {
+	if len(lower) != dimension || len(upper) != dimension {
+		return fmt.Errorf("wrong bounds length: %d, %d", len(lower), len(upper))
+	}
+	for i := 0; i < dimension; i++ {
+		if !(lower[i] < upper[i]) || math.IsInf(lower[i], 0) || math.IsInf(upper[i], 0) {
+			return fmt.Errorf("wrong bounds: %f, %f", lower[i], upper[i])
+		}
+	}
+	return nil
+}
+
+func bestIndex(values []float64) int {
+	best := 0
+	for i, v := range values {
+		if v < values[best] {
+			best = i
+		}
+	}
+	return best
+}
+
+func valuesRange(values []float64) float64 {
+	minValue, maxValue := values[0], values[0]
+	for _, v := range values {
+		minValue = math.Min(minValue, v)
+		maxValue = math.Max(maxValue, v)
+	}
+	return maxValue - minValue
+}
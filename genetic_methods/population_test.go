@@ -0,0 +1,107 @@
+package genetic_methods
+
+import (
+	"context"
+	"github.com/saskamegaprogrammist/optimization_methods/test_functions"
+	"math"
+	"testing"
+)
+
+func TestParallelEvaluatorMatchesSequential(t *testing.T) {
+	points := make([][]float64, 100)
+	for i := range points {
+		points[i] = []float64{float64(i), -float64(i) / 3}
+	}
+	sequential := SequentialEvaluator{}.Evaluate(context.Background(), test_functions.Rosenbrock, points)
+	for _, workers := range []int{0, 1, 3, 1000} {
+		parallel := ParallelEvaluator{Workers: workers}.Evaluate(context.Background(), test_functions.Rosenbrock, points)
+		for i := range points {
+			if parallel[i] != sequential[i] {
+				t.Fatalf("%d workers: value %d is %g, expected %g", workers, i, parallel[i], sequential[i])
+			}
+		}
+	}
+}
+
+func TestEvaluatorsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	points := [][]float64{{1, 2}, {3, 4}}
+	for name, evaluator := range map[string]Evaluator{"sequential": SequentialEvaluator{}, "parallel": ParallelEvaluator{}} {
+		for _, value := range evaluator.Evaluate(ctx, test_functions.Sphere, points) {
+			if !math.IsInf(value, 1) {
+				t.Errorf("%s: point is evaluated after cancel: %g", name, value)
+			}
+		}
+	}
+}
+
+func TestMemoEvaluatorCounts(t *testing.T) {
+	calls := 0
+	counted := func(xs []float64) float64 {
+		calls++
+		return test_functions.Sphere(xs)
+	}
+	var memo MemoEvaluator
+	memo.Init(SequentialEvaluator{})
+	values := memo.Evaluate(context.Background(), counted, [][]float64{{1, 2}, {1, 2}, {3, 4}})
+	if values[0] != 5 || values[1] != 5 || values[2] != 25 {
+		t.Errorf("wrong memoised values: %v", values)
+	}
+	memo.Evaluate(context.Background(), counted, [][]float64{{3, 4}, {5, 6}})
+	if calls != 3 || memo.Evaluated() != 3 {
+		t.Errorf("expected 3 evaluations, got %d calls and %d counted", calls, memo.Evaluated())
+	}
+	if memo.Hits() != 1 {
+		t.Errorf("expected 1 cache hit, got %d", memo.Hits())
+	}
+	memo.Reset()
+	memo.Evaluate(context.Background(), counted, [][]float64{{1, 2}})
+	if calls != 4 || memo.Evaluated() != 1 || memo.Hits() != 0 {
+		t.Errorf("cache isn't reset: %d calls, %d evaluated, %d hits", calls, memo.Evaluated(), memo.Hits())
+	}
+}
+
+func TestPopulationEvaluationsWithMemo(t *testing.T) {
+	calls := 0
+	counted := func(xs []float64) float64 {
+		calls++
+		return test_functions.Sphere(xs)
+	}
+	var de DifferentialEvolution
+	de.Init([]float64{-5, -5}, []float64{5, 5}, 2, 10, 0.5, 0.9, RandOneBin, 30, 0, counted)
+	var memo MemoEvaluator
+	memo.Init(ParallelEvaluator{})
+	de.SetEvaluator(&memo)
+	de.SetSeed(1)
+	_, _, err := de.Solve()
+	if err != nil {
+		t.Fatalf("error during differential evolution: %v", err)
+	}
+	if de.Evaluations() != calls {
+		t.Errorf("%d evaluations are counted, target function is called %d times", de.Evaluations(), calls)
+	}
+}
+
+func TestGeneticAlgorithmParallel(t *testing.T) {
+	target := func(xs []float64) float64 { return test_functions.Sphere(xs) }
+	fitness := func(xs []float64) float64 { return 1 / (1 + test_functions.Sphere(xs)) }
+	run := func(workers int) ([]float64, float64) {
+		var ga GeneticAlgorithm
+		ga.Init(-5, 5, 30, 40, []float64{3, 3}, 2, target, fitness)
+		ga.SetSeed(7)
+		if workers > 0 {
+			ga.SetParallelism(workers)
+		}
+		x, f, err := ga.Solve()
+		if err != nil {
+			t.Fatalf("error during genetic algorithm: %v", err)
+		}
+		return x, f
+	}
+	x1, f1 := run(0)
+	x2, f2 := run(4)
+	if f1 != f2 || x1[0] != x2[0] || x1[1] != x2[1] {
+		t.Errorf("parallel run differs from sequential: %v, %g and %v, %g", x2, f2, x1, f1)
+	}
+}
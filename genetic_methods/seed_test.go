@@ -0,0 +1,63 @@
+package genetic_methods
+
+import (
+	"github.com/saskamegaprogrammist/optimization_methods/test_functions"
+	"testing"
+)
+
+type seededSolver interface {
+	SetSeed(seed int64)
+	Solve() ([]float64, float64, error)
+	Evaluations() int
+}
+
+func seededSolvers() map[string]func() seededSolver {
+	lower, upper := []float64{-5, -5}, []float64{5, 5}
+	return map[string]func() seededSolver{
+		"differential evolution": func() seededSolver {
+			var de DifferentialEvolution
+			de.Init(lower, upper, 2, 20, 0.5, 0.9, CurrentToPBestOneBin, 100, 0, test_functions.Rastrigin)
+			return &de
+		},
+		"particle swarm": func() seededSolver {
+			var pso ParticleSwarm
+			pso.Init(lower, upper, 2, 20, InertiaWeight, 100, 0, test_functions.Rastrigin)
+			return &pso
+		},
+		"cma-es": func() seededSolver {
+			var cma CMAES
+			cma.Init([]float64{3, 3}, 2, 1, 0, 1e-10, 3000, test_functions.Rastrigin)
+			cma.SetBounds(lower, upper)
+			cma.SetRestarts(BIPOP, 4)
+			return &cma
+		},
+		"genetic algorithm": func() seededSolver {
+			var ga GeneticAlgorithm
+			ga.Init(-5, 5, 20, 30, []float64{3, 3}, 2, test_functions.Rastrigin, func(xs []float64) float64 { return -test_functions.Rastrigin(xs) })
+			return &ga
+		},
+	}
+}
+
+func TestSeededRunsReproducible(t *testing.T) {
+	for name, create := range seededSolvers() {
+		run := func(seed int64) ([]float64, float64, int) {
+			solver := create()
+			solver.SetSeed(seed)
+			x, f, err := solver.Solve()
+			if err != nil {
+				t.Fatalf("%s: error solving: %v", name, err)
+			}
+			return x, f, solver.Evaluations()
+		}
+		x1, f1, evaluations1 := run(5)
+		x2, f2, evaluations2 := run(5)
+		if f1 != f2 || x1[0] != x2[0] || x1[1] != x2[1] || evaluations1 != evaluations2 {
+			t.Errorf("%s: seeded runs differ: %v, %g, %d and %v, %g, %d", name, x1, f1, evaluations1, x2, f2, evaluations2)
+		}
+		x3, _, _ := run(6)
+		if x1[0] == x3[0] && x1[1] == x3[1] {
+			t.Errorf("%s: different seeds give same point %v", name, x1)
+		}
+	}
+}
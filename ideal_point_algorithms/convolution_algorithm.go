@@ -25,6 +25,11 @@ type ConvolutionMulticriteria struct {
 	genetic    genetic_methods.GeneticAlgorithm
 
 	useGenetic bool
+	seed       int64
+	seeded     bool
+
+	idealPoints [][]float64 // f1 and f2 minimum points found by last solve
+	idealValues []float64
 }
 
 func (cm *ConvolutionMulticriteria) Init(dimension int, startPoint []float64, critPriority [][]float64, targetFuncs []func(xs []float64) float64, penalties []func(xs []float64) float64,
@@ -41,6 +46,11 @@ func (cm *ConvolutionMulticriteria) Init(dimension int, startPoint []float64, cr
 	cm.useGenetic = useGenetic
 }
 
+func (cm *ConvolutionMulticriteria) SetSeed(seed int64) {
+	cm.seed = seed
+	cm.seeded = true
+}
+
 func (cm *ConvolutionMulticriteria) Solve() ([][]float64, [][]float64, error) {
 	var err error
 
@@ -53,6 +63,9 @@ func (cm *ConvolutionMulticriteria) Solve() ([][]float64, [][]float64, error) {
 		cm.genetic.Init(0, 1, 1, 2000, cm.startPoint, cm.dimension, cm.targetFuncs[0], func(xs []float64) float64 {
 			return float64(1) / cm.targetFuncs[0](xs)
 		})
+		if cm.seeded {
+			cm.genetic.SetSeed(cm.seed)
+		}
 		xIdeal1, fIdeal[0], err = cm.genetic.Solve()
 	} else {
 		cm.search.Init(cm.startPoint, 0.1, 3, 0.001, cm.targetFuncs[0])
@@ -60,15 +73,16 @@ func (cm *ConvolutionMulticriteria) Solve() ([][]float64, [][]float64, error) {
 	}
 
 	if err != nil {
-		return nil, nil, fmt.Errorf("error finding first ideal point: %v", err)
+		return nil, nil, fmt.Errorf("error finding first ideal point: %w", err)
 	}
 
-	fmt.Println("xIdeal1 ", xIdeal1)
-
 	if cm.useGenetic {
 		cm.genetic.Init(0, 4, 1, 3000, cm.startPoint, cm.dimension, cm.targetFuncs[1], func(xs []float64) float64 {
 			return float64(1) / cm.targetFuncs[1](xs)
 		})
+		if cm.seeded {
+			cm.genetic.SetSeed(cm.seed)
+		}
 		xIdeal2, fIdeal[1], err = cm.genetic.Solve()
 	} else {
 		cm.search.Init(cm.startPoint, 0.1, 3, 0.001, cm.targetFuncs[1])
@@ -76,12 +90,10 @@ func (cm *ConvolutionMulticriteria) Solve() ([][]float64, [][]float64, error) {
 	}
 
 	if err != nil {
-		return nil, nil, fmt.Errorf("error finding second ideal point: %v", err)
+		return nil, nil, fmt.Errorf("error finding second ideal point: %w", err)
 	}
-
-	fmt.Println("xIdeal2 ", xIdeal2)
-
-	fmt.Println("ideal values: ", fIdeal)
+	cm.idealPoints = [][]float64{xIdeal1, xIdeal2}
+	cm.idealValues = fIdeal
 
 	var frontLen = len(cm.critPriority)
 	for i := 0; i < frontLen; i++ {
@@ -100,12 +112,12 @@ func (cm *ConvolutionMulticriteria) Solve() ([][]float64, [][]float64, error) {
 		if real(values[0]) > real(values[1]) {
 			err = vec.InitWithPoints(2, []float64{real(vectors.At(0, 0)), real(vectors.At(1, 0))})
 			if err != nil {
-				return nil, nil, fmt.Errorf("error initing vector: %v", err)
+				return nil, nil, fmt.Errorf("error initing vector: %w", err)
 			}
 		} else {
 			err = vec.InitWithPoints(2, []float64{real(vectors.At(0, 1)), real(vectors.At(1, 1))})
 			if err != nil {
-				return nil, nil, fmt.Errorf("error initing vector: %v", err)
+				return nil, nil, fmt.Errorf("error initing vector: %w", err)
 			}
 		}
 		for i := 0; i < 2; i++ {
@@ -117,7 +129,6 @@ func (cm *ConvolutionMulticriteria) Solve() ([][]float64, [][]float64, error) {
 		ws[1] = vec.Points[1] / sum
 
 		//fmt.Println(fIdeal)
-		fmt.Println(ws)
 
 		var gradient = []func(xs []float64) float64{
 			func(xs []float64) float64 { return cm.gradient[0](xs, fIdeal, ws) },
@@ -132,10 +143,13 @@ func (cm *ConvolutionMulticriteria) Solve() ([][]float64, [][]float64, error) {
 		}
 		ep.InitSimple(cm.startPoint, cm.dimension, func(xs []float64) float64 { return cm.helpFunc(xs, fIdeal, ws) }, cm.penalties,
 			gradient, cm.gradientConstraint, cm.constraint, 0.0001, 1.618, mthd)
+		if cm.seeded {
+			ep.SetSeed(cm.seed)
+		}
 
 		xMin, _, err = ep.Solve()
 		if err != nil {
-			return nil, nil, fmt.Errorf("error solving external penalty method : %v", err)
+			return nil, nil, fmt.Errorf("error solving external penalty method : %w", err)
 		}
 		xMins = append(xMins, xMin)
 		yMin = append(yMin, []float64{cm.targetFuncs[0](xMin), cm.targetFuncs[1](xMin)})
@@ -144,3 +158,7 @@ func (cm *ConvolutionMulticriteria) Solve() ([][]float64, [][]float64, error) {
 
 	return xMins, yMin, nil
 }
+
+func (cm *ConvolutionMulticriteria) IdealPoints() ([][]float64, []float64) {
+	return cm.idealPoints, cm.idealValues
+}
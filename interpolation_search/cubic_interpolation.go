@@ -1,6 +1,7 @@
 package interpolation_search
 
 import (
+	"context"
 	"math"
 )
 
@@ -11,6 +12,7 @@ type CubicInterpolation struct {
 	precisionEps         float64
 	targetFunc           func(x float64) float64
 	targetFuncDerivative func(x float64) float64
+	searchStats
 }
 
 func (cInt *CubicInterpolation) Init(startPoint float64, step float64, precisionDelta float64, precisionEps float64,
@@ -19,17 +21,23 @@ func (cInt *CubicInterpolation) Init(startPoint float64, step float64, precision
 	cInt.step = step
 	cInt.precisionDelta = precisionDelta
 	cInt.precisionEps = precisionEps
-	cInt.targetFunc = targetFunc
+	cInt.targetFunc = cInt.countFunc(targetFunc)
 	cInt.targetFuncDerivative = targetFuncDerivative
 }
 
 func (cInt *CubicInterpolation) Solve() (float64, float64) {
+	x, f, _ := cInt.SolveContext(context.Background())
+	return x, f
+}
+
+func (cInt *CubicInterpolation) SolveContext(ctx context.Context) (float64, float64, error) {
 	var alph0, alphM, alphM_1 float64
 	var der0 float64
 	var sign, i float64
 	var alph1, alph2 float64
 	var f1, f2, der1, der2 float64
 	var alph, f float64
+	var k int
 	alph0 = cInt.startPoint
 	der0 = cInt.targetFuncDerivative(alph0)
 	if der0 < 0 {
@@ -38,7 +46,11 @@ func (cInt *CubicInterpolation) Solve() (float64, float64) {
 		sign = -1
 	}
 	alphM_1 = alph0
+	cInt.reset(ctx)
 	for {
+		if err := cInt.interrupted(int(i)); err != nil {
+			return alph0, cInt.targetFunc(alph0), err
+		}
 		alphM = alphM_1 + sign*math.Pow(2, i)*cInt.step
 		if cInt.targetFuncDerivative(alphM_1)*cInt.targetFuncDerivative(alphM) <= 0 {
 			break
@@ -48,6 +60,10 @@ func (cInt *CubicInterpolation) Solve() (float64, float64) {
 	alph1 = alphM_1
 	alph2 = alphM
 	for {
+		if err := cInt.interrupted(k); err != nil {
+			return alph1, cInt.targetFunc(alph1), err
+		}
+		k++
 		f1 = cInt.targetFunc(alph1)
 		f2 = cInt.targetFunc(alph2)
 		der1 = cInt.targetFuncDerivative(alph1)
@@ -61,8 +77,8 @@ func (cInt *CubicInterpolation) Solve() (float64, float64) {
 			f = cInt.targetFunc(alph)
 		}
 		der := cInt.targetFuncDerivative(alph)
-		if cInt.checkFirstCondition(der) && cInt.checkSecondCondition(alph, alph1) {
-			return alph, cInt.targetFunc(alph)
+		if der == 0 || cInt.checkFirstCondition(der) && cInt.checkSecondCondition(alph, alph1) { // bracket can't shrink at stationary point
+			return alph, cInt.targetFunc(alph), nil
 		}
 		if der*der1 < 0 {
 			alph2 = alph1
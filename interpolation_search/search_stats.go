@@ -0,0 +1,39 @@
+package interpolation_search
+
+import (
+	"context"
+	"github.com/saskamegaprogrammist/optimization_methods/one_dimension_search"
+	"time"
+)
+
+type searchStats struct {
+	limits          one_dimension_search.Limits
+	ctx             context.Context
+	timeStart       time.Time
+	funcEvaluations int
+}
+
+func (ss *searchStats) SetLimits(limits one_dimension_search.Limits) {
+	ss.limits = limits
+}
+
+func (ss *searchStats) FuncEvaluations() int {
+	return ss.funcEvaluations
+}
+
+func (ss *searchStats) reset(ctx context.Context) {
+	ss.ctx = ctx
+	ss.timeStart = time.Now()
+	ss.funcEvaluations = 0
+}
+
+func (ss *searchStats) interrupted(k int) error {
+	return ss.limits.Exceeded(ss.ctx, k, ss.funcEvaluations, ss.timeStart)
+}
+
+func (ss *searchStats) countFunc(targetFunc func(x float64) float64) func(x float64) float64 {
+	return func(x float64) float64 {
+		ss.funcEvaluations++
+		return targetFunc(x)
+	}
+}
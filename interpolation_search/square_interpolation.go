@@ -1,6 +1,7 @@
 package interpolation_search
 
 import (
+	"context"
 	"math"
 )
 
@@ -10,6 +11,7 @@ type SquareInterpolation struct {
 	precisionDelta float64
 	precisionEps   float64
 	targetFunc     func(x float64) float64
+	searchStats
 }
 
 func (sqInt *SquareInterpolation) Init(startPoint float64, step float64, precisionDelta float64, precisionEps float64, targetFunc func(x float64) float64) {
@@ -17,15 +19,22 @@ func (sqInt *SquareInterpolation) Init(startPoint float64, step float64, precisi
 	sqInt.step = step
 	sqInt.precisionDelta = precisionDelta
 	sqInt.precisionEps = precisionEps
-	sqInt.targetFunc = targetFunc
+	sqInt.targetFunc = sqInt.countFunc(targetFunc)
 }
 
 func (sqInt *SquareInterpolation) Solve() (float64, float64) {
+	x, f, _ := sqInt.SolveContext(context.Background())
+	return x, f
+}
+
+func (sqInt *SquareInterpolation) SolveContext(ctx context.Context) (float64, float64, error) {
 	var alph1, alph2, alph3 float64
 	var f1, f2, f3 float64
 	var min, minAlph, interpol, interpolAlph float64
 	var isNotZero bool
+	var k int
 	alph1 = sqInt.startPoint
+	sqInt.reset(ctx)
 OUTER:
 	for {
 		alph2 = alph1 + sqInt.step
@@ -39,13 +48,17 @@ OUTER:
 		f3 = sqInt.targetFunc(alph3)
 		for {
 			min, minAlph = sqInt.findMin(f1, f2, f3, alph1, alph2, alph3)
+			if err := sqInt.interrupted(k); err != nil {
+				return minAlph, min, err
+			}
+			k++
 			isNotZero, interpol, interpolAlph = sqInt.interpolate(f1, f2, f3, alph1, alph2, alph3)
 			if !isNotZero {
 				alph1 = minAlph
 				continue OUTER
 			}
 			if sqInt.checkFirstCond(min, interpol) && sqInt.checkSecondCond(minAlph, interpolAlph) {
-				return interpolAlph, interpol
+				return interpolAlph, interpol, nil
 			} else if checkInInterval(interpolAlph, alph1, alph3) {
 				alph1, f1, alph2, f2, alph3, f3 = sqInt.findBest(alph1, alph2, alph3,
 					min, interpol,
@@ -0,0 +1,80 @@
+package la_methods
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
+)
+
+func TestVectorDimensionMismatch(t *testing.T) {
+	var a, b Vector
+	a.Init(2)
+	b.Init(3)
+	_, err := a.Add(b)
+	if !errors.Is(err, optimization_errors.ErrDimensionMismatch) {
+		t.Errorf("adding vectors: got %v, want dimension mismatch", err)
+	}
+	_, err = a.Mul(b)
+	if !errors.Is(err, optimization_errors.ErrDimensionMismatch) {
+		t.Errorf("multiplying vectors: got %v, want dimension mismatch", err)
+	}
+	err = a.InitWithPoints(2, []float64{1, 2, 3})
+	if !errors.Is(err, optimization_errors.ErrDimensionMismatch) {
+		t.Errorf("initializing vector: got %v, want dimension mismatch", err)
+	}
+}
+
+func TestMatrixDimensionMismatch(t *testing.T) {
+	var m Matrix
+	var v Vector
+	m.Init(2, 3)
+	v.Init(2)
+	_, err := m.MulV(v)
+	var dimensionError *optimization_errors.DimensionError
+	if !errors.As(err, &dimensionError) {
+		t.Fatalf("multiplying matrix and vector: got %v, want dimension error", err)
+	}
+	if dimensionError.Expected != 3 || dimensionError.Actual != 2 {
+		t.Errorf("wrong dimensions: %d, %d", dimensionError.Expected, dimensionError.Actual)
+	}
+}
+
+func TestSingularMatrix(t *testing.T) {
+	var m Matrix
+	err := m.InitWithPoints(2, 2, [][]float64{{1, 2}, {2, 4}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = m.Inverted()
+	if !errors.Is(err, optimization_errors.ErrSingularMatrix) {
+		t.Errorf("inverting singular matrix: got %v, want singular matrix", err)
+	}
+}
+
+func TestInverted(t *testing.T) {
+	var m Matrix
+	err := m.InitWithPoints(2, 2, [][]float64{{4, 7}, {2, 6}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inverse, err := m.Inverted()
+	if err != nil {
+		t.Fatal(err)
+	}
+	product, err := m.MulM(inverse)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			if d := product.Points[i][j] - want; d > 1e-12 || d < -1e-12 {
+				t.Errorf("product[%d][%d] = %g, want %g", i, j, product.Points[i][j], want)
+			}
+		}
+	}
+}
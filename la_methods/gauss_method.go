@@ -2,6 +2,7 @@ package la_methods
 
 import (
 	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
 )
 
 type GaussMethod struct {
@@ -35,11 +36,14 @@ func (gm *GaussMethod) backwards(system Matrix) (Vector, error) {
 		answerCopy = answer.Copy()
 		err = row.InitWithPoints(system.DimensionRows, system.Points[i][:system.DimensionRows])
 		if err != nil {
-			return Vector{}, fmt.Errorf("error during vector initializing: %v", err)
+			return Vector{}, fmt.Errorf("error during vector initializing: %w", err)
 		}
 		answerCopy, err = answerCopy.MulVFrom(row, i)
 		if err != nil {
-			return Vector{}, fmt.Errorf("error during vector multiplying: %v", err)
+			return Vector{}, fmt.Errorf("error during vector multiplying: %w", err)
+		}
+		if system.Points[i][i] == 0 {
+			return Vector{}, fmt.Errorf("%w: zero pivot in row %d", optimization_errors.ErrSingularMatrix, i)
 		}
 		elementSum := answerCopy.ElemsSum(i)
 		answer.Points[i] = (system.Points[i][system.DimensionRows] - elementSum) / system.Points[i][i]
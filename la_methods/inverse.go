@@ -0,0 +1,20 @@
+package la_methods
+
+import (
+	"errors"
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
+	"gonum.org/v1/gonum/mat"
+	"math"
+)
+
+func InverseError(err error) error {
+	var condition mat.Condition
+	if errors.As(err, &condition) && !math.IsInf(float64(condition), 1) {
+		return nil // ill conditioned matrix is still inverted
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", optimization_errors.ErrSingularMatrix, err)
+	}
+	return nil
+}
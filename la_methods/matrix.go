@@ -2,6 +2,7 @@ package la_methods
 
 import (
 	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
 	"math"
 )
 
@@ -22,10 +23,10 @@ func (m *Matrix) Init(dimensionRows int, dimensionColumns int) {
 
 func (m *Matrix) InitWithPoints(dimensionRows int, dimensionColumns int, points [][]float64) error {
 	if len(points) != dimensionRows {
-		return fmt.Errorf("dimension rows doesn't match points length: %d", len(points))
+		return &optimization_errors.DimensionError{Expected: dimensionRows, Actual: len(points)}
 	}
 	if len(points[0]) != dimensionColumns {
-		return fmt.Errorf("dimension columns doesn't match points[0] length: %d", len(points))
+		return &optimization_errors.DimensionError{Expected: dimensionColumns, Actual: len(points[0])}
 	}
 	m.DimensionRows = dimensionRows
 	m.DimensionColumns = dimensionColumns
@@ -35,7 +36,7 @@ func (m *Matrix) InitWithPoints(dimensionRows int, dimensionColumns int, points
 
 func (m *Matrix) InitWithVectorRow(dimensionRows int, dimensionColumns int, vector Vector) error {
 	if vector.Dimension != dimensionColumns {
-		return fmt.Errorf("dimension vector doesn't match dimension columns: %d", vector.Dimension)
+		return &optimization_errors.DimensionError{Expected: dimensionColumns, Actual: vector.Dimension}
 	}
 	m.DimensionRows = dimensionRows
 	m.DimensionColumns = dimensionColumns
@@ -51,7 +52,7 @@ func (m *Matrix) InitWithVectorRow(dimensionRows int, dimensionColumns int, vect
 
 func (m *Matrix) InitWithVectorColumn(dimensionRows int, dimensionColumns int, vector Vector) error {
 	if vector.Dimension != dimensionRows {
-		return fmt.Errorf("dimension vector doesn't match dimension rows: %d", vector.Dimension)
+		return &optimization_errors.DimensionError{Expected: dimensionRows, Actual: vector.Dimension}
 	}
 	m.DimensionRows = dimensionRows
 	m.DimensionColumns = dimensionColumns
@@ -81,7 +82,7 @@ func (m *Matrix) MulV(vector Vector) (Vector, error) {
 	var mulVector Vector
 	var sum float64
 	if m.DimensionColumns != vector.Dimension {
-		return mulVector, fmt.Errorf("vector dimension doesn't match matrix columns dimension: %d", vector.Dimension)
+		return mulVector, &optimization_errors.DimensionError{Expected: m.DimensionColumns, Actual: vector.Dimension}
 	}
 	mulVector.Init(m.DimensionRows)
 	for i := 0; i < m.DimensionRows; i++ {
@@ -98,7 +99,7 @@ func (m *Matrix) MulM(matrix Matrix) (Matrix, error) {
 	var mulM Matrix
 	var sum float64
 	if m.DimensionColumns != matrix.DimensionRows {
-		return mulM, fmt.Errorf("matrix dimensions doesn't match")
+		return mulM, &optimization_errors.DimensionError{Expected: m.DimensionColumns, Actual: matrix.DimensionRows}
 	}
 	mulM.Init(m.DimensionRows, matrix.DimensionColumns)
 	for i := 0; i < m.DimensionRows; i++ {
@@ -128,8 +129,11 @@ func (m *Matrix) MulVal(val float64) Matrix {
 
 func (m *Matrix) AddM(matrix Matrix) (Matrix, error) {
 	var newM Matrix
-	if m.DimensionColumns != matrix.DimensionColumns || m.DimensionRows != matrix.DimensionRows {
-		return Matrix{}, fmt.Errorf("matrix dimensions doesn't match: %d, %d", matrix.DimensionRows, matrix.DimensionColumns)
+	if m.DimensionRows != matrix.DimensionRows {
+		return Matrix{}, &optimization_errors.DimensionError{Expected: m.DimensionRows, Actual: matrix.DimensionRows}
+	}
+	if m.DimensionColumns != matrix.DimensionColumns {
+		return Matrix{}, &optimization_errors.DimensionError{Expected: m.DimensionColumns, Actual: matrix.DimensionColumns}
 	}
 	_ = newM.InitWithPoints(m.DimensionRows, m.DimensionColumns, m.Points)
 	for i := 0; i < m.DimensionRows; i++ {
@@ -264,7 +268,7 @@ func (m *Matrix) MakeNullColumnEBackwards(diagonalIndex int, newMatrix *Matrix)
 
 func (m *Matrix) LU() (Matrix, Matrix, error) {
 	if m.DimensionRows != m.DimensionColumns {
-		return Matrix{}, Matrix{}, fmt.Errorf("matrix dimensions don't match")
+		return Matrix{}, Matrix{}, fmt.Errorf("matrix is not square: %w", &optimization_errors.DimensionError{Expected: m.DimensionRows, Actual: m.DimensionColumns})
 	}
 	var size int
 	size = m.DimensionColumns
@@ -296,6 +300,9 @@ func (m *Matrix) LU() (Matrix, Matrix, error) {
 				for k := 0; k <= j-1; k++ {
 					sum += lMatrix.Points[i][k] * uMatrix.Points[k][j]
 				}
+				if uMatrix.Points[j][j] == 0 {
+					return Matrix{}, Matrix{}, fmt.Errorf("%w: zero pivot in row %d", optimization_errors.ErrSingularMatrix, j)
+				}
 				lMatrix.Points[i][j] = (m.Points[i][j] - sum) / uMatrix.Points[j][j]
 			}
 		}
@@ -312,7 +319,7 @@ func (m *Matrix) Inverted() (Matrix, error) {
 	zeidelMethod.Init(0.0001)
 	lM, uM, err = m.LU()
 	if err != nil {
-		return Matrix{}, fmt.Errorf("error during LU: %v", err)
+		return Matrix{}, fmt.Errorf("error during LU: %w", err)
 	}
 	//checkM, _ := lM.MulM(uM)
 	//checkM.Print()
@@ -323,13 +330,13 @@ func (m *Matrix) Inverted() (Matrix, error) {
 		gV, err = gaussMethod.Solve(lM, vecOne)
 		//gV.Print()
 		if err != nil {
-			return Matrix{}, fmt.Errorf("error during gauss solving: %v", err)
+			return Matrix{}, fmt.Errorf("error during gauss solving: %w", err)
 		}
 
 		zV, err = zeidelMethod.Solve(uM, gV)
 		//zV.Print()
 		if err != nil {
-			return Matrix{}, fmt.Errorf("error during zeidel solving: %v", err)
+			return Matrix{}, fmt.Errorf("error during zeidel solving: %w", err)
 		}
 		for j := 0; j < m.DimensionRows; j++ {
 			inverted.Points[j][i] = zV.Points[j]
@@ -366,7 +373,7 @@ func (m *Matrix) RemoveRows(rows []int) (Matrix, error) {
 	var newMatrix Matrix
 	err = newMatrix.InitWithPoints(len(newPoints), m.DimensionColumns, newPoints)
 	if err != nil {
-		return Matrix{}, fmt.Errorf("error initializing vector: %v", err)
+		return Matrix{}, fmt.Errorf("error initializing vector: %w", err)
 	}
 	return newMatrix, nil
 }
@@ -2,6 +2,7 @@ package la_methods
 
 import (
 	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
 	"math"
 )
 
@@ -17,7 +18,7 @@ func (v *Vector) Init(dimension int) {
 
 func (v *Vector) InitWithPoints(dimension int, points []float64) error {
 	if len(points) != dimension {
-		return fmt.Errorf("dimension doesn't match points length: %d", len(points))
+		return &optimization_errors.DimensionError{Expected: dimension, Actual: len(points)}
 	}
 	v.Dimension = dimension
 	v.Points = points
@@ -44,7 +45,7 @@ func (v *Vector) MulOnValue(val float64) Vector {
 func (v *Vector) Add(vector Vector) (Vector, error) {
 	var sumVector Vector
 	if vector.Dimension != v.Dimension {
-		return sumVector, fmt.Errorf("vector dimensions doesn't match: %d", vector.Dimension)
+		return sumVector, &optimization_errors.DimensionError{Expected: v.Dimension, Actual: vector.Dimension}
 	}
 	sumVector.Init(v.Dimension)
 	for i, point := range v.Points {
@@ -56,7 +57,7 @@ func (v *Vector) Add(vector Vector) (Vector, error) {
 func (v *Vector) AddKOnIndex(k float64, i int) (Vector, error) {
 	var sumVector Vector
 	if i >= v.Dimension {
-		return sumVector, fmt.Errorf("vector dimensions doesn't match: %d", i)
+		return sumVector, fmt.Errorf("index is out of range: %d", i)
 	}
 	sumVector = v.Copy()
 	sumVector.Points[i] += k
@@ -75,7 +76,7 @@ func (v *Vector) AddK(k float64) Vector {
 func (v *Vector) Sub(vector Vector) (Vector, error) {
 	var subVector Vector
 	if vector.Dimension != v.Dimension {
-		return subVector, fmt.Errorf("vector dimensions doesn't match: %d", vector.Dimension)
+		return subVector, &optimization_errors.DimensionError{Expected: v.Dimension, Actual: vector.Dimension}
 	}
 	subVector.Init(v.Dimension)
 	for i, point := range v.Points {
@@ -87,7 +88,7 @@ func (v *Vector) Sub(vector Vector) (Vector, error) {
 func (v *Vector) SubKOnIndex(k float64, i int) (Vector, error) {
 	var subVector Vector
 	if i >= v.Dimension {
-		return subVector, fmt.Errorf("vector dimensions doesn't match: %d", i)
+		return subVector, fmt.Errorf("index is out of range: %d", i)
 	}
 	subVector = v.Copy()
 	subVector.Points[i] -= k
@@ -105,7 +106,7 @@ func (v *Vector) SubK(k float64) Vector {
 
 func (v *Vector) Mul(vec Vector) (float64, error) {
 	if vec.Dimension != v.Dimension {
-		return 0, fmt.Errorf("vectors dimensions are different: %d", vec.Dimension)
+		return 0, &optimization_errors.DimensionError{Expected: v.Dimension, Actual: vec.Dimension}
 	}
 	var sum float64
 	for i, point := range v.Points {
@@ -122,7 +123,7 @@ func (v *Vector) MulVFrom(vec Vector, ind int) (Vector, error) {
 	var mulVector Vector
 	mulVector.Init(vec.Dimension)
 	if vec.Dimension != v.Dimension {
-		return mulVector, fmt.Errorf("vectors dimensions are different: %d", vec.Dimension)
+		return mulVector, &optimization_errors.DimensionError{Expected: v.Dimension, Actual: vec.Dimension}
 	}
 	for i := ind; i < v.Dimension; i++ {
 		mulVector.Points[i] = v.Points[i] * vec.Points[i]
@@ -163,7 +164,7 @@ func (v *Vector) Print() {
 
 func (v *Vector) EqDist(vec Vector) (float64, error) {
 	if v.Dimension != vec.Dimension {
-		return 0, fmt.Errorf("vectors have different dimensions %d and %d", v.Dimension, vec.Dimension)
+		return 0, &optimization_errors.DimensionError{Expected: v.Dimension, Actual: vec.Dimension}
 	}
 	var dist float64
 	for i, point := range v.Points {
@@ -2,8 +2,11 @@ package la_methods
 
 import (
 	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
 )
 
+const maxZeidelIterations = 10000
+
 type ZeidelMethod struct {
 	precision float64
 }
@@ -29,19 +32,22 @@ func (zm *ZeidelMethod) solve(matrix Matrix, vec Vector) (Vector, error) {
 	var stop bool
 	var initial, nextIter Vector
 	if matrix.DimensionRows != matrix.DimensionColumns {
-		return Vector{}, fmt.Errorf("matrix dimensions don't match")
+		return Vector{}, fmt.Errorf("matrix is not square: %w", &optimization_errors.DimensionError{Expected: matrix.DimensionRows, Actual: matrix.DimensionColumns})
 	}
 	dimension = matrix.DimensionRows
 	if dimension != vec.Dimension {
-		return Vector{}, fmt.Errorf("matrix and vector dimensions don't match")
+		return Vector{}, &optimization_errors.DimensionError{Expected: dimension, Actual: vec.Dimension}
 	}
 	initial, err = zm.setInitial(matrix, vec)
 	if err != nil {
-		return Vector{}, fmt.Errorf("error during vector initializing: %v", err)
+		return Vector{}, fmt.Errorf("error during vector initializing: %w", err)
 	}
 	nextIter.Init(dimension)
 	for {
 		k++
+		if k > maxZeidelIterations {
+			return Vector{}, fmt.Errorf("%w: zeidel method doesn't converge", optimization_errors.ErrMaxIterations)
+		}
 		//nextIter.Print()
 		for i := 0; i < dimension; i++ {
 			numerator := vec.Points[i]
@@ -55,7 +61,7 @@ func (zm *ZeidelMethod) solve(matrix Matrix, vec Vector) (Vector, error) {
 		}
 		stop, err = zm.checkStop(initial, nextIter)
 		if err != nil {
-			return Vector{}, fmt.Errorf("error during checking condition: %v", err)
+			return Vector{}, fmt.Errorf("error during checking condition: %w", err)
 		}
 		if stop {
 			break
@@ -68,7 +74,7 @@ func (zm *ZeidelMethod) solve(matrix Matrix, vec Vector) (Vector, error) {
 func (zm *ZeidelMethod) checkStop(initial Vector, nextIter Vector) (bool, error) {
 	diff, err := nextIter.Sub(initial)
 	if err != nil {
-		return false, fmt.Errorf("error substractiong vectors: %v", err)
+		return false, fmt.Errorf("error substractiong vectors: %w", err)
 	}
 	return diff.Len() < zm.precision, nil
 }
@@ -78,7 +84,7 @@ func (zm *ZeidelMethod) setInitial(matrix Matrix, vec Vector) (Vector, error) {
 	initial.Init(vec.Dimension)
 	for i := 0; i < matrix.DimensionColumns; i++ {
 		if matrix.Points[i][i] == 0 {
-			return Vector{}, fmt.Errorf("main matrix has zero on diagonal")
+			return Vector{}, fmt.Errorf("%w: main matrix has zero on diagonal", optimization_errors.ErrSingularMatrix)
 		}
 		initial.Points[i] = vec.Points[i] / matrix.Points[i][i]
 	}
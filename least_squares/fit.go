@@ -0,0 +1,192 @@
+package least_squares
+
+import (
+	"context"
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/finite_differences"
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"github.com/saskamegaprogrammist/optimization_methods/many_dimension_search"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/distuv"
+	"math"
+	"time"
+)
+
+type Fit struct {
+	X                   []float64
+	Residuals           []float64
+	ResidualNorm        float64
+	Iterations          int
+	ResidualEvaluations int
+	Reason              many_dimension_search.TerminationReason
+	Covariance          la_methods.Matrix
+	StandardErrors      []float64
+	ConfidenceIntervals [][]float64 // lower and upper bound for every parameter
+}
+
+type fitStats struct {
+	residuals           func(xs []float64) []float64
+	observedResiduals   func(xs []float64) []float64 // not counted residuals
+	jacobian            func(xs []float64) la_methods.Matrix
+	x                   []float64
+	iterations          int
+	residualEvaluations int
+	reason              many_dimension_search.TerminationReason
+	ctx                 context.Context
+	limits              many_dimension_search.Limits
+	timeStart           time.Time
+	observer            many_dimension_search.Observer
+}
+
+func (fs *fitStats) setFunctions(residuals func(xs []float64) []float64, jacobian func(xs []float64) la_methods.Matrix, dimension int) {
+	fs.observedResiduals = residuals
+	fs.residuals = func(xs []float64) []float64 {
+		fs.residualEvaluations++
+		return residuals(xs)
+	}
+	fs.jacobian = numericalJacobian(residuals, jacobian, dimension)
+}
+
+func (fs *fitStats) Reason() many_dimension_search.TerminationReason {
+	return fs.reason
+}
+
+func (fs *fitStats) SetLimits(limits many_dimension_search.Limits) {
+	fs.limits = limits
+}
+
+func (fs *fitStats) SetObserver(observer many_dimension_search.Observer) {
+	fs.observer = observer
+}
+
+func (fs *fitStats) notify(k int, x []float64, residualNorm float64, gradNorm float64, step float64) {
+	if fs.observer == nil {
+		return
+	}
+	fs.observer(many_dimension_search.IterationInfo{
+		Iteration:       k,
+		X:               append([]float64{}, x...),
+		F:               residualNorm,
+		GradNorm:        gradNorm,
+		Step:            step,
+		FuncEvaluations: fs.residualEvaluations,
+	})
+}
+
+func (fs *fitStats) reset(ctx context.Context) {
+	fs.ctx = ctx
+	fs.timeStart = time.Now()
+	fs.x = nil
+	fs.iterations = 0
+	fs.residualEvaluations = 0
+	fs.reason = many_dimension_search.NotTerminated
+}
+
+func (fs *fitStats) finish(k int, x []float64, reason many_dimension_search.TerminationReason) {
+	fs.iterations = k
+	fs.x = x
+	fs.reason = reason
+}
+
+func (fs *fitStats) interrupted(k int, x []float64) bool {
+	reason := fs.limits.Exceeded(fs.ctx, k, fs.residualEvaluations, fs.timeStart)
+	if reason == many_dimension_search.NotTerminated {
+		return false
+	}
+	fs.finish(k, x, reason)
+	return true
+}
+
+func (fs *fitStats) Fit(confidence float64) (Fit, error) {
+	if fs.x == nil {
+		return Fit{}, fmt.Errorf("problem isn't solved")
+	}
+	if confidence <= 0 || confidence >= 1 {
+		return Fit{}, fmt.Errorf("wrong confidence level: %f", confidence)
+	}
+	residuals := fs.observedResiduals(fs.x)
+	jacobian := fs.jacobian(fs.x)
+	err := checkJacobian(jacobian, len(residuals), len(fs.x))
+	if err != nil {
+		return Fit{}, fmt.Errorf("error checking jacobian: %w", err)
+	}
+	covariance, err := Covariance(jacobian, residuals)
+	if err != nil {
+		return Fit{}, fmt.Errorf("error calculating covariance: %w", err)
+	}
+	degrees := float64(len(residuals) - len(fs.x))
+	t := distuv.StudentsT{Mu: 0, Sigma: 1, Nu: degrees}.Quantile(1 - (1-confidence)/2)
+	fit := Fit{
+		X:                   fs.x,
+		Residuals:           residuals,
+		ResidualNorm:        floats.Norm(residuals, 2),
+		Iterations:          fs.iterations,
+		ResidualEvaluations: fs.residualEvaluations,
+		Reason:              fs.reason,
+		Covariance:          covariance,
+		StandardErrors:      make([]float64, len(fs.x)),
+		ConfidenceIntervals: make([][]float64, len(fs.x)),
+	}
+	for i, x := range fs.x {
+		fit.StandardErrors[i] = math.Sqrt(covariance.Points[i][i])
+		fit.ConfidenceIntervals[i] = []float64{x - t*fit.StandardErrors[i], x + t*fit.StandardErrors[i]}
+	}
+	return fit, nil
+}
+
+func Covariance(jacobian la_methods.Matrix, residuals []float64) (la_methods.Matrix, error) {
+	var jtj mat.SymDense
+	var inverse mat.Dense
+	var covariance la_methods.Matrix
+	degrees := len(residuals) - jacobian.DimensionColumns
+	if degrees <= 0 {
+		return la_methods.Matrix{}, fmt.Errorf("residuals number %d should be greater than parameters number %d", len(residuals), jacobian.DimensionColumns)
+	}
+	jtj.SymOuterK(1, toDense(jacobian).T())
+	err := la_methods.InverseError(inverse.Inverse(&jtj))
+	if err != nil {
+		return la_methods.Matrix{}, fmt.Errorf("error inverting jacobian product: %w", err)
+	}
+	variance := floats.Dot(residuals, residuals) / float64(degrees)
+	covariance.Init(jacobian.DimensionColumns, jacobian.DimensionColumns)
+	for i := 0; i < jacobian.DimensionColumns; i++ {
+		for j := 0; j < jacobian.DimensionColumns; j++ {
+			covariance.Points[i][j] = variance * inverse.At(i, j)
+		}
+	}
+	return covariance, nil
+}
+
+func numericalJacobian(residuals func(xs []float64) []float64, jacobian func(xs []float64) la_methods.Matrix,
+	dimension int) func(xs []float64) la_methods.Matrix {
+	if jacobian != nil {
+		return jacobian
+	}
+	var fd finite_differences.Differentiator
+	fd.Init(finite_differences.Central, 0)
+	return fd.Jacobian(residuals, dimension)
+}
+
+func checkJacobian(jacobian la_methods.Matrix, rows int, columns int) error {
+	if jacobian.DimensionRows != rows {
+		return fmt.Errorf("jacobian rows: %w", &optimization_errors.DimensionError{Expected: rows, Actual: jacobian.DimensionRows})
+	}
+	if jacobian.DimensionColumns != columns {
+		return fmt.Errorf("jacobian columns: %w", &optimization_errors.DimensionError{Expected: columns, Actual: jacobian.DimensionColumns})
+	}
+	return nil
+}
+
+func toDense(matrix la_methods.Matrix) *mat.Dense {
+	dense := mat.NewDense(matrix.DimensionRows, matrix.DimensionColumns, nil)
+	for i := 0; i < matrix.DimensionRows; i++ {
+		dense.SetRow(i, matrix.Points[i])
+	}
+	return dense
+}
+
+func stepConverged(step []float64, x []float64, eps float64) bool {
+	return floats.Norm(step, 2) < eps*(floats.Norm(x, 2)+eps)
+}
@@ -0,0 +1,103 @@
+package least_squares
+
+import (
+	"context"
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"github.com/saskamegaprogrammist/optimization_methods/many_dimension_search"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+const maxStepHalvings = 30
+
+type GaussNewtonSearch struct {
+	startPoint    []float64
+	dimension     int
+	eps1          float64 // gradient precision
+	eps2          float64 // step precision
+	maxIterations int
+	fitStats
+}
+
+func (gns *GaussNewtonSearch) Init(startPoint []float64, dimension int,
+	residuals func(xs []float64) []float64, jacobian func(xs []float64) la_methods.Matrix,
+	eps1 float64, eps2 float64, maxIterations int) {
+	gns.startPoint = startPoint
+	gns.dimension = dimension
+	gns.setFunctions(residuals, jacobian, dimension)
+	gns.eps1 = eps1
+	gns.eps2 = eps2
+	gns.maxIterations = maxIterations
+}
+
+func (gns *GaussNewtonSearch) Solve() ([]float64, float64, error) {
+	return gns.SolveContext(context.Background())
+}
+
+func (gns *GaussNewtonSearch) SolveContext(ctx context.Context) ([]float64, float64, error) {
+	var k int
+	var p, g mat.VecDense
+	gns.reset(ctx)
+	x := make([]float64, gns.dimension)
+	copy(x, gns.startPoint)
+	r := gns.residuals(x)
+	if len(r) < gns.dimension {
+		return nil, 0, fmt.Errorf("residuals number %d is less than parameters number %d", len(r), gns.dimension)
+	}
+	for {
+		if gns.interrupted(k, x) {
+			return x, floats.Norm(r, 2), nil
+		}
+		jacobian := gns.jacobian(x)
+		err := checkJacobian(jacobian, len(r), gns.dimension)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error checking jacobian: %w", err)
+		}
+		J := toDense(jacobian)
+		rv := mat.NewVecDense(len(r), r)
+		g.MulVec(J.T(), rv)
+		if mat.Norm(&g, 2) < gns.eps1 {
+			gns.finish(k, x, many_dimension_search.GradientConverged)
+			return x, floats.Norm(r, 2), nil
+		}
+		if k >= gns.maxIterations {
+			gns.finish(k, x, many_dimension_search.MaxIterationsReached)
+			return x, floats.Norm(r, 2), nil
+		}
+		err = la_methods.InverseError(p.SolveVec(J, rv)) // least squares solution of J p = r
+		if err != nil {
+			return nil, 0, fmt.Errorf("error solving gauss newton system: %w", err)
+		}
+		step := make([]float64, gns.dimension)
+		floats.ScaleTo(step, -1, p.RawVector().Data)
+		xNew, rNew, ok := gns.dampedStep(x, r, step)
+		k++
+		if !ok {
+			gns.finish(k, x, many_dimension_search.StepConverged)
+			return x, floats.Norm(r, 2), nil
+		}
+		floats.SubTo(step, xNew, x)
+		x = xNew
+		r = rNew
+		gns.notify(k, x, floats.Norm(r, 2), mat.Norm(&g, 2), floats.Norm(step, 2))
+		if stepConverged(step, x, gns.eps2) {
+			gns.finish(k, x, many_dimension_search.StepConverged)
+			return x, floats.Norm(r, 2), nil
+		}
+	}
+}
+
+func (gns *GaussNewtonSearch) dampedStep(x []float64, r []float64, step []float64) ([]float64, []float64, bool) {
+	cost := floats.Dot(r, r)
+	xNew := make([]float64, len(x))
+	for i := 0; i < maxStepHalvings; i++ {
+		floats.AddScaledTo(xNew, x, 1, step)
+		rNew := gns.residuals(xNew)
+		if floats.Dot(rNew, rNew) < cost {
+			return xNew, rNew, true
+		}
+		floats.Scale(0.5, step)
+	}
+	return nil, nil, false
+}
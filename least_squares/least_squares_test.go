@@ -0,0 +1,151 @@
+package least_squares
+
+import (
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"github.com/saskamegaprogrammist/optimization_methods/many_dimension_search"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+type solver interface {
+	Solve() ([]float64, float64, error)
+	Fit(confidence float64) (Fit, error)
+	SetLimits(limits many_dimension_search.Limits)
+	Reason() many_dimension_search.TerminationReason
+}
+
+func exponentialResiduals(ts []float64, ys []float64) func(xs []float64) []float64 {
+	return func(xs []float64) []float64 {
+		r := make([]float64, len(ts))
+		for i, t := range ts {
+			r[i] = xs[0]*math.Exp(-xs[1]*t) - ys[i]
+		}
+		return r
+	}
+}
+
+func exponentialJacobian(ts []float64) func(xs []float64) la_methods.Matrix {
+	return func(xs []float64) la_methods.Matrix {
+		var jacobian la_methods.Matrix
+		jacobian.Init(len(ts), 2)
+		for i, t := range ts {
+			jacobian.Points[i][0] = math.Exp(-xs[1] * t)
+			jacobian.Points[i][1] = -xs[0] * t * math.Exp(-xs[1]*t)
+		}
+		return jacobian
+	}
+}
+
+func exponentialData(noise float64) ([]float64, []float64) {
+	random := rand.New(rand.NewSource(1))
+	ts := make([]float64, 30)
+	ys := make([]float64, 30)
+	for i := range ts {
+		ts[i] = float64(i) * 0.2
+		ys[i] = 3*math.Exp(-0.7*ts[i]) + noise*random.NormFloat64()
+	}
+	return ts, ys
+}
+
+func fitSolvers(ts []float64, ys []float64, jacobian func(xs []float64) la_methods.Matrix) map[string]solver {
+	var gns GaussNewtonSearch
+	gns.Init([]float64{1, 0.1}, 2, exponentialResiduals(ts, ys), jacobian, 1e-12, 1e-12, 100)
+	var lms LevenbergMarquardtSearch
+	lms.Init([]float64{1, 0.1}, 2, exponentialResiduals(ts, ys), jacobian, 0.001, 1e-12, 1e-12, 100, false)
+	var geodesic LevenbergMarquardtSearch
+	geodesic.Init([]float64{1, 0.1}, 2, exponentialResiduals(ts, ys), jacobian, 0.001, 1e-12, 1e-12, 100, true)
+	return map[string]solver{"gauss newton": &gns, "levenberg marquardt": &lms, "geodesic levenberg marquardt": &geodesic}
+}
+
+func TestExactExponentialFit(t *testing.T) {
+	ts, ys := exponentialData(0)
+	for _, jacobian := range []func(xs []float64) la_methods.Matrix{exponentialJacobian(ts), nil} {
+		for name, s := range fitSolvers(ts, ys, jacobian) {
+			x, norm, err := s.Solve()
+			if err != nil {
+				t.Fatalf("error during %s fit: %v", name, err)
+			}
+			if math.Abs(x[0]-3) > 1e-6 || math.Abs(x[1]-0.7) > 1e-6 || norm > 1e-6 {
+				t.Errorf("%s: expected parameters [3 0.7], got %v, residual norm %g", name, x, norm)
+			}
+		}
+	}
+}
+
+func TestNoisyExponentialFit(t *testing.T) {
+	ts, ys := exponentialData(0.05)
+	for name, s := range fitSolvers(ts, ys, exponentialJacobian(ts)) {
+		_, _, err := s.Solve()
+		if err != nil {
+			t.Fatalf("error during %s fit: %v", name, err)
+		}
+		fit, err := s.Fit(0.99)
+		if err != nil {
+			t.Fatalf("%s: error calculating fit statistics: %v", name, err)
+		}
+		for i, expected := range []float64{3, 0.7} {
+			interval := fit.ConfidenceIntervals[i]
+			if expected < interval[0] || expected > interval[1] {
+				t.Errorf("%s: parameter %d interval %v doesn't contain %f", name, i, interval, expected)
+			}
+			if fit.StandardErrors[i] <= 0 || fit.StandardErrors[i] > 0.1 {
+				t.Errorf("%s: wrong standard error %f", name, fit.StandardErrors[i])
+			}
+		}
+		if len(fit.Residuals) != len(ts) || fit.ResidualEvaluations == 0 {
+			t.Errorf("%s: fit statistics aren't filled: %+v", name, fit)
+		}
+	}
+}
+
+func TestFitLimits(t *testing.T) {
+	ts, ys := exponentialData(0)
+	for name, s := range fitSolvers(ts, ys, nil) {
+		s.SetLimits(many_dimension_search.Limits{MaxFuncEvaluations: 3})
+		_, _, err := s.Solve()
+		if err != nil {
+			t.Fatalf("error during %s fit: %v", name, err)
+		}
+		fit, err := s.Fit(0.95)
+		if err != nil {
+			t.Fatalf("%s: error calculating fit statistics: %v", name, err)
+		}
+		if s.Reason() != many_dimension_search.MaxFuncEvaluationsReached || fit.ResidualEvaluations > 4 {
+			t.Errorf("%s: evaluations limit is ignored: %v after %d evaluations", name, s.Reason(), fit.ResidualEvaluations)
+		}
+		s.SetLimits(many_dimension_search.Limits{MaxTime: time.Nanosecond})
+		if _, _, err = s.Solve(); err != nil {
+			t.Fatalf("error during %s fit: %v", name, err)
+		}
+		if s.Reason() != many_dimension_search.TimeLimitReached {
+			t.Errorf("%s: time limit is ignored: %v", name, s.Reason())
+		}
+	}
+}
+
+func TestFitErrors(t *testing.T) {
+	ts, ys := exponentialData(0)
+	for name, s := range fitSolvers(ts, ys, nil) {
+		if _, err := s.Fit(0.95); err == nil {
+			t.Errorf("%s: fit before solving is accepted", name)
+		}
+		_, _, err := s.Solve()
+		if err != nil {
+			t.Fatalf("error during %s fit: %v", name, err)
+		}
+		if _, err = s.Fit(1.5); err == nil {
+			t.Errorf("%s: wrong confidence is accepted", name)
+		}
+	}
+}
+
+func TestTooFewResiduals(t *testing.T) {
+	ts, ys := exponentialData(0)
+	for name, s := range fitSolvers(ts[:1], ys[:1], nil) {
+		if _, _, err := s.Solve(); err == nil {
+			t.Errorf("%s: underdetermined problem is accepted", name)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package least_squares
+
+import (
+	"context"
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"github.com/saskamegaprogrammist/optimization_methods/many_dimension_search"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"math"
+)
+
+const (
+	geodesicStep     = 0.1  // finite difference step for second directional derivative
+	geodesicMaxRatio = 0.75 // acceleration to velocity ratio limit
+	maxDamping       = 1e16
+)
+
+type LevenbergMarquardtSearch struct {
+	startPoint    []float64
+	dimension     int
+	tau           float64 // initial damping relative to jacobian product diagonal
+	eps1          float64 // gradient precision
+	eps2          float64 // step precision
+	maxIterations int
+	geodesic      bool // geodesic acceleration
+	fitStats
+}
+
+func (lms *LevenbergMarquardtSearch) Init(startPoint []float64, dimension int,
+	residuals func(xs []float64) []float64, jacobian func(xs []float64) la_methods.Matrix,
+	tau float64, eps1 float64, eps2 float64, maxIterations int, geodesic bool) {
+	lms.startPoint = startPoint
+	lms.dimension = dimension
+	lms.setFunctions(residuals, jacobian, dimension)
+	lms.tau = tau
+	lms.eps1 = eps1
+	lms.eps2 = eps2
+	lms.maxIterations = maxIterations
+	lms.geodesic = geodesic
+}
+
+func (lms *LevenbergMarquardtSearch) Solve() ([]float64, float64, error) {
+	return lms.SolveContext(context.Background())
+}
+
+func (lms *LevenbergMarquardtSearch) SolveContext(ctx context.Context) ([]float64, float64, error) {
+	var k int
+	var g mat.VecDense
+	var jtj mat.SymDense
+	var damping float64
+	lms.reset(ctx)
+	x := make([]float64, lms.dimension)
+	copy(x, lms.startPoint)
+	r := lms.residuals(x)
+	if len(r) < lms.dimension {
+		return nil, 0, fmt.Errorf("residuals number %d is less than parameters number %d", len(r), lms.dimension)
+	}
+	scale := make([]float64, lms.dimension) // marquardt diagonal scaling
+	nu := 2.0
+	for {
+		if lms.interrupted(k, x) {
+			return x, floats.Norm(r, 2), nil
+		}
+		jacobian := lms.jacobian(x)
+		err := checkJacobian(jacobian, len(r), lms.dimension)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error checking jacobian: %w", err)
+		}
+		J := toDense(jacobian)
+		g.MulVec(J.T(), mat.NewVecDense(len(r), r))
+		if mat.Norm(&g, 2) < lms.eps1 {
+			lms.finish(k, x, many_dimension_search.GradientConverged)
+			return x, floats.Norm(r, 2), nil
+		}
+		if k >= lms.maxIterations {
+			lms.finish(k, x, many_dimension_search.MaxIterationsReached)
+			return x, floats.Norm(r, 2), nil
+		}
+		jtj.SymOuterK(1, J.T())
+		for i := 0; i < lms.dimension; i++ {
+			scale[i] = math.Max(scale[i], jtj.At(i, i))
+		}
+		if k == 0 {
+			damping = lms.tau * floats.Max(scale)
+		}
+		for {
+			if lms.interrupted(k, x) {
+				return x, floats.Norm(r, 2), nil
+			}
+			if damping > maxDamping {
+				lms.finish(k, x, many_dimension_search.StepConverged)
+				return x, floats.Norm(r, 2), nil
+			}
+			step, ok, err := lms.step(J, &jtj, &g, scale, damping, x, r)
+			if err != nil {
+				return nil, 0, fmt.Errorf("error calculating levenberg marquardt step: %w", err)
+			}
+			if !ok {
+				damping *= nu
+				nu *= 2
+				continue
+			}
+			if stepConverged(step, x, lms.eps2) {
+				lms.finish(k, x, many_dimension_search.StepConverged)
+				return x, floats.Norm(r, 2), nil
+			}
+			xNew := make([]float64, lms.dimension)
+			floats.AddTo(xNew, x, step)
+			rNew := lms.residuals(xNew)
+			ratio := lms.gainRatio(J, r, rNew, step)
+			if ratio <= 0 {
+				damping *= nu
+				nu *= 2
+				continue
+			}
+			damping *= math.Max(1.0/3, 1-math.Pow(2*ratio-1, 3))
+			nu = 2
+			x = xNew
+			r = rNew
+			lms.notify(k, x, floats.Norm(r, 2), mat.Norm(&g, 2), floats.Norm(step, 2))
+			break
+		}
+		k++
+	}
+}
+
+func (lms *LevenbergMarquardtSearch) step(J *mat.Dense, jtj *mat.SymDense, g *mat.VecDense, scale []float64,
+	damping float64, x []float64, r []float64) ([]float64, bool, error) {
+	var chol mat.Cholesky
+	var velocity, acceleration, jv, rhs mat.VecDense
+	system := mat.NewSymDense(lms.dimension, nil)
+	system.CopySym(jtj)
+	for i := 0; i < lms.dimension; i++ {
+		system.SetSym(i, i, system.At(i, i)+damping*scale[i])
+	}
+	if !chol.Factorize(system) {
+		return nil, false, nil
+	}
+	err := la_methods.InverseError(chol.SolveVecTo(&velocity, g))
+	if err != nil {
+		return nil, false, fmt.Errorf("error solving damped system: %w", err)
+	}
+	velocity.ScaleVec(-1, &velocity)
+	step := make([]float64, lms.dimension)
+	copy(step, velocity.RawVector().Data)
+	if !lms.geodesic {
+		return step, true, nil
+	}
+	xh := make([]float64, lms.dimension)
+	floats.AddScaledTo(xh, x, geodesicStep, step)
+	rh := lms.residuals(xh)
+	jv.MulVec(J, &velocity)
+	rvv := make([]float64, len(r)) // second directional derivative of residuals
+	for i := range rvv {
+		rvv[i] = 2 / geodesicStep * ((rh[i]-r[i])/geodesicStep - jv.AtVec(i))
+	}
+	rhs.MulVec(J.T(), mat.NewVecDense(len(rvv), rvv))
+	err = la_methods.InverseError(chol.SolveVecTo(&acceleration, &rhs))
+	if err != nil {
+		return nil, false, fmt.Errorf("error solving damped system: %w", err)
+	}
+	if 2*mat.Norm(&acceleration, 2) > geodesicMaxRatio*mat.Norm(&velocity, 2) {
+		return nil, false, nil
+	}
+	floats.AddScaled(step, -0.5, acceleration.RawVector().Data)
+	return step, true, nil
+}
+
+func (lms *LevenbergMarquardtSearch) gainRatio(J *mat.Dense, r []float64, rNew []float64, step []float64) float64 {
+	var predictedR mat.VecDense
+	predictedR.MulVec(J, mat.NewVecDense(len(step), step))
+	predictedR.AddVec(&predictedR, mat.NewVecDense(len(r), r))
+	cost := floats.Dot(r, r)
+	predicted := cost - mat.Dot(&predictedR, &predictedR)
+	if predicted <= 0 {
+		return -1
+	}
+	return (cost - floats.Dot(rNew, rNew)) / predicted
+}
@@ -0,0 +1,163 @@
+package line_search
+
+import (
+	"context"
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
+	"math"
+)
+
+const (
+	hzTheta     = 0.5  // bisection coefficient of interval update
+	hzGamma     = 0.66 // required interval shrinking after secant step
+	hzExpansion = 5.0  // bracket expansion coefficient
+)
+
+type HagerZhangSearch struct {
+	step    float64 // initial trial step, previous alpha is used when zero
+	delta   float64 // sufficient decrease coefficient
+	sigma   float64 // curvature coefficient
+	epsilon float64 // relative error of approximate wolfe conditions
+}
+
+func (hz *HagerZhangSearch) Init(step float64, delta float64, sigma float64, epsilon float64) {
+	hz.step = step
+	hz.delta = delta
+	hz.sigma = sigma
+	hz.epsilon = epsilon
+}
+
+type hzPoint struct {
+	alpha float64
+	phi   float64
+	dPhi  float64
+}
+
+type hzState struct {
+	phi         func(alpha float64) float64
+	dPhi        func(alpha float64) float64
+	zero        hzPoint
+	eps         float64
+	delta       float64
+	sigma       float64
+	evaluations int
+	found       bool
+	result      hzPoint
+}
+
+func (hz *HagerZhangSearch) Search(ctx context.Context, phi func(alpha float64) float64, dPhi func(alpha float64) float64, alpha float64) (float64, error) {
+	var a, b hzPoint
+	if dPhi == nil {
+		return 0, fmt.Errorf("hager zhang search requires derivative")
+	}
+	state := hzState{phi: phi, dPhi: dPhi, delta: hz.delta, sigma: hz.sigma}
+	state.zero = hzPoint{0, phi(0), dPhi(0)}
+	if state.zero.dPhi >= 0 {
+		return 0, fmt.Errorf("direction is not a descent direction: %f", state.zero.dPhi)
+	}
+	state.eps = hz.epsilon * math.Abs(state.zero.phi)
+	a, b = state.bracket(initialStep(hz.step, alpha))
+	for !state.found && state.evaluations < maxInexactIterations {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		aNew, bNew := state.secant2(a, b)
+		if state.found {
+			break
+		}
+		if bNew.alpha-aNew.alpha > hzGamma*(b.alpha-a.alpha) {
+			aNew, bNew = state.update(aNew, bNew, state.evaluate((aNew.alpha+bNew.alpha)/2))
+		}
+		a, b = aNew, bNew
+		if b.alpha-a.alpha <= minInexactStep {
+			return a.alpha, nil
+		}
+	}
+	if !state.found {
+		return 0, fmt.Errorf("%w: approximate wolfe conditions are not satisfied after %d evaluations", optimization_errors.ErrMaxIterations, state.evaluations)
+	}
+	return state.result.alpha, nil
+}
+
+func (st *hzState) evaluate(alpha float64) hzPoint {
+	point := hzPoint{alpha, st.phi(alpha), st.dPhi(alpha)}
+	st.evaluations++
+	if !st.found && st.satisfied(point) {
+		st.found = true
+		st.result = point
+	}
+	return point
+}
+
+func (st *hzState) satisfied(c hzPoint) bool {
+	wolfe := c.phi <= st.zero.phi+st.delta*c.alpha*st.zero.dPhi && c.dPhi >= st.sigma*st.zero.dPhi
+	approxWolfe := (2*st.delta-1)*st.zero.dPhi >= c.dPhi && c.dPhi >= st.sigma*st.zero.dPhi &&
+		c.phi <= st.zero.phi+st.eps
+	return wolfe || approxWolfe
+}
+
+func (st *hzState) bracket(alpha float64) (hzPoint, hzPoint) {
+	a := st.zero
+	c := st.evaluate(alpha)
+	for !st.found && st.evaluations < maxInexactIterations {
+		if c.dPhi >= 0 {
+			return a, c
+		}
+		if c.phi > st.zero.phi+st.eps {
+			return st.bisect(st.zero, c)
+		}
+		a = c
+		c = st.evaluate(hzExpansion * c.alpha)
+	}
+	return a, c
+}
+
+func (st *hzState) update(a hzPoint, b hzPoint, c hzPoint) (hzPoint, hzPoint) {
+	if st.found || c.alpha <= a.alpha || c.alpha >= b.alpha {
+		return a, b
+	}
+	if c.dPhi >= 0 {
+		return a, c
+	}
+	if c.phi <= st.zero.phi+st.eps {
+		return c, b
+	}
+	return st.bisect(a, c)
+}
+
+func (st *hzState) bisect(a hzPoint, b hzPoint) (hzPoint, hzPoint) {
+	for !st.found && st.evaluations < maxInexactIterations {
+		d := st.evaluate((1-hzTheta)*a.alpha + hzTheta*b.alpha)
+		if d.dPhi >= 0 {
+			return a, d
+		}
+		if d.phi <= st.zero.phi+st.eps {
+			a = d
+		} else {
+			b = d
+		}
+	}
+	return a, b
+}
+
+func (st *hzState) secant2(a hzPoint, b hzPoint) (hzPoint, hzPoint) {
+	c := st.evaluate(secant(a, b))
+	aNew, bNew := st.update(a, b, c)
+	if st.found {
+		return aNew, bNew
+	}
+	if c.alpha == bNew.alpha {
+		return st.update(aNew, bNew, st.evaluate(secant(b, bNew)))
+	}
+	if c.alpha == aNew.alpha {
+		return st.update(aNew, bNew, st.evaluate(secant(a, aNew)))
+	}
+	return aNew, bNew
+}
+
+func secant(a hzPoint, b hzPoint) float64 {
+	if b.dPhi == a.dPhi {
+		return (a.alpha + b.alpha) / 2
+	}
+	return (a.alpha*b.dPhi - b.alpha*a.dPhi) / (b.dPhi - a.dPhi)
+}
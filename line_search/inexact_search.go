@@ -0,0 +1,59 @@
+package line_search
+
+import (
+	"context"
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
+)
+
+const (
+	maxInexactIterations = 100
+	minInexactStep       = 1e-20
+)
+
+type ArmijoSearch struct {
+	step float64 // initial trial step, previous alpha is used when zero
+	c1   float64 // sufficient decrease coefficient
+	rho  float64 // backtracking coefficient
+}
+
+func (as *ArmijoSearch) Init(step float64, c1 float64, rho float64) {
+	as.step = step
+	as.c1 = c1
+	as.rho = rho
+}
+
+func (as *ArmijoSearch) Search(ctx context.Context, phi func(alpha float64) float64, dPhi func(alpha float64) float64, alpha float64) (float64, error) {
+	if dPhi == nil {
+		return 0, fmt.Errorf("armijo search requires derivative")
+	}
+	phi0 := phi(0)
+	dPhi0 := dPhi(0)
+	if dPhi0 >= 0 {
+		return 0, fmt.Errorf("direction is not a descent direction: %f", dPhi0)
+	}
+	step := initialStep(as.step, alpha)
+	for k := 0; k < maxInexactIterations; k++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		if phi(step) <= phi0+as.c1*step*dPhi0 {
+			return step, nil
+		}
+		step *= as.rho
+		if step < minInexactStep {
+			return 0, fmt.Errorf("step is less than minimum step: %g", step)
+		}
+	}
+	return 0, fmt.Errorf("%w: armijo condition is not satisfied", optimization_errors.ErrMaxIterations)
+}
+
+func initialStep(step float64, alpha float64) float64 {
+	if step > 0 {
+		return step
+	}
+	if alpha > 0 {
+		return alpha
+	}
+	return 1
+}
@@ -0,0 +1,78 @@
+package line_search
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+func exponential(alpha float64) float64 {
+	return math.Exp(alpha) - 5*alpha
+}
+
+func exponentialDerivative(alpha float64) float64 {
+	return math.Exp(alpha) - 5
+}
+
+func TestArmijoSufficientDecrease(t *testing.T) {
+	var as ArmijoSearch
+	as.Init(10, 0.0001, 0.5)
+	alpha, err := as.Search(context.Background(), exponential, exponentialDerivative, 0)
+	if err != nil {
+		t.Fatalf("error during armijo search: %v", err)
+	}
+	if exponential(alpha) > exponential(0)+0.0001*alpha*exponentialDerivative(0) {
+		t.Errorf("armijo condition isn't satisfied at %f", alpha)
+	}
+	if alpha >= 10 {
+		t.Errorf("step isn't reduced: %f", alpha)
+	}
+}
+
+func TestMoreThuenteStrongWolfe(t *testing.T) {
+	var mt MoreThuenteSearch
+	mt.Init(10, 0.0001, 0.1, 1e-10)
+	alpha, err := mt.Search(context.Background(), exponential, exponentialDerivative, 0)
+	if err != nil {
+		t.Fatalf("error during more thuente search: %v", err)
+	}
+	if exponential(alpha) > exponential(0)+0.0001*alpha*exponentialDerivative(0) {
+		t.Errorf("sufficient decrease isn't satisfied at %f", alpha)
+	}
+	if math.Abs(exponentialDerivative(alpha)) > 0.1*math.Abs(exponentialDerivative(0)) {
+		t.Errorf("strong curvature condition isn't satisfied at %f", alpha)
+	}
+}
+
+func TestHagerZhangWolfe(t *testing.T) {
+	var hz HagerZhangSearch
+	hz.Init(10, 0.1, 0.9, 1e-6)
+	alpha, err := hz.Search(context.Background(), exponential, exponentialDerivative, 0)
+	if err != nil {
+		t.Fatalf("error during hager zhang search: %v", err)
+	}
+	phi0, dPhi0 := exponential(0), exponentialDerivative(0)
+	dPhi := exponentialDerivative(alpha)
+	wolfe := exponential(alpha) <= phi0+0.1*alpha*dPhi0 && dPhi >= 0.9*dPhi0
+	approxWolfe := (2*0.1-1)*dPhi0 >= dPhi && dPhi >= 0.9*dPhi0 && exponential(alpha) <= phi0+1e-6*math.Abs(phi0)
+	if !wolfe && !approxWolfe {
+		t.Errorf("wolfe conditions aren't satisfied at %f", alpha)
+	}
+}
+
+func TestInexactSearchesRejectAscent(t *testing.T) {
+	ascent := func(alpha float64) float64 { return -exponential(alpha) }
+	ascentDerivative := func(alpha float64) float64 { return -exponentialDerivative(alpha) }
+	for _, method := range []Method{Armijo, MoreThuente, HagerZhang} {
+		search, err := New(method, 1, 1e-6)
+		if err != nil {
+			t.Fatalf("error creating %s search: %v", method, err)
+		}
+		if _, err = search.Search(context.Background(), ascent, ascentDerivative, 0); err == nil {
+			t.Errorf("%s search accepted ascent direction", method)
+		}
+		if _, err = search.Search(context.Background(), exponential, nil, 0); err == nil {
+			t.Errorf("%s search accepted missing derivative", method)
+		}
+	}
+}
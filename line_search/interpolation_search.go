@@ -0,0 +1,50 @@
+package line_search
+
+import (
+	"context"
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/interpolation_search"
+)
+
+type SquareInterpolationSearch struct {
+	step      float64
+	precision float64
+}
+
+func (sqrInt *SquareInterpolationSearch) Init(step float64, precision float64) {
+	sqrInt.step = step
+	sqrInt.precision = precision
+}
+
+func (sqrInt *SquareInterpolationSearch) Search(ctx context.Context, phi func(alpha float64) float64, dPhi func(alpha float64) float64, alpha float64) (float64, error) {
+	var search interpolation_search.SquareInterpolation
+	search.Init(alpha, sqrInt.step, sqrInt.precision, sqrInt.precision, phi)
+	min, _, err := search.SolveContext(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error during square interpolation: %w", err)
+	}
+	return min, nil
+}
+
+type CubicInterpolationSearch struct {
+	step      float64
+	precision float64
+}
+
+func (cInt *CubicInterpolationSearch) Init(step float64, precision float64) {
+	cInt.step = step
+	cInt.precision = precision
+}
+
+func (cInt *CubicInterpolationSearch) Search(ctx context.Context, phi func(alpha float64) float64, dPhi func(alpha float64) float64, alpha float64) (float64, error) {
+	var search interpolation_search.CubicInterpolation
+	if dPhi == nil {
+		return 0, fmt.Errorf("cubic interpolation requires derivative")
+	}
+	search.Init(alpha, cInt.step, cInt.precision, cInt.precision, phi, dPhi)
+	min, _, err := search.SolveContext(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error during cubic interpolation: %w", err)
+	}
+	return min, nil
+}
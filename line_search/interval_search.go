@@ -0,0 +1,79 @@
+package line_search
+
+import (
+	"context"
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/one_dimension_search"
+)
+
+type BreakInTwoSearch struct {
+	step      float64
+	precision float64
+}
+
+func (bit *BreakInTwoSearch) Init(step float64, precision float64) {
+	bit.step = step
+	bit.precision = precision
+}
+
+func (bit *BreakInTwoSearch) Search(ctx context.Context, phi func(alpha float64) float64, dPhi func(alpha float64) float64, alpha float64) (float64, error) {
+	var search one_dimension_search.BreakInTwoSearch
+	a, b, err := findBounds(ctx, bit.step, alpha, phi)
+	if err != nil {
+		return 0, err
+	}
+	search.Init(a, b, bit.precision, phi)
+	min, _, err := search.SolveContext(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error during break in two search: %w", err)
+	}
+	return min, nil
+}
+
+type GoldenRatioSearch struct {
+	step      float64
+	precision float64
+}
+
+func (gr *GoldenRatioSearch) Init(step float64, precision float64) {
+	gr.step = step
+	gr.precision = precision
+}
+
+func (gr *GoldenRatioSearch) Search(ctx context.Context, phi func(alpha float64) float64, dPhi func(alpha float64) float64, alpha float64) (float64, error) {
+	var search one_dimension_search.GoldenRatioSearch
+	a, b, err := findBounds(ctx, gr.step, alpha, phi)
+	if err != nil {
+		return 0, err
+	}
+	search.Init(a, b, gr.precision, phi)
+	min, _, err := search.SolveContext(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error during golden ratio search: %w", err)
+	}
+	return min, nil
+}
+
+type FibonacciSearch struct {
+	step      float64
+	precision float64
+}
+
+func (fs *FibonacciSearch) Init(step float64, precision float64) {
+	fs.step = step
+	fs.precision = precision
+}
+
+func (fs *FibonacciSearch) Search(ctx context.Context, phi func(alpha float64) float64, dPhi func(alpha float64) float64, alpha float64) (float64, error) {
+	var search one_dimension_search.FibonacciSearch
+	a, b, err := findBounds(ctx, fs.step, alpha, phi)
+	if err != nil {
+		return 0, err
+	}
+	search.Init(a, b, fs.precision, fs.precision, phi)
+	min, _, err := search.SolveContext(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error during fibonacci search: %w", err)
+	}
+	return min, nil
+}
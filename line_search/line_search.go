@@ -0,0 +1,92 @@
+package line_search
+
+import (
+	"context"
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/one_dimension_search"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
+)
+
+type LineSearch interface {
+	Search(ctx context.Context, phi func(alpha float64) float64, dPhi func(alpha float64) float64, alpha float64) (float64, error)
+}
+
+type Method string
+
+const (
+	BreakInTwo          Method = "break in two"
+	GoldenRatio         Method = "golden ratio"
+	Fibonacci           Method = "fibonacci"
+	SquareInterpolation Method = "square interpolation"
+	CubicInterpolation  Method = "cubic interpolation"
+	Armijo              Method = "armijo"
+	MoreThuente         Method = "more thuente"
+	HagerZhang          Method = "hager zhang"
+)
+
+type Constructor func(step float64, precision float64) LineSearch
+
+var registry = map[Method]Constructor{
+	BreakInTwo: func(step float64, precision float64) LineSearch {
+		var bit BreakInTwoSearch
+		bit.Init(step, precision)
+		return &bit
+	},
+	GoldenRatio: func(step float64, precision float64) LineSearch {
+		var gr GoldenRatioSearch
+		gr.Init(step, precision)
+		return &gr
+	},
+	Fibonacci: func(step float64, precision float64) LineSearch {
+		var fs FibonacciSearch
+		fs.Init(step, precision)
+		return &fs
+	},
+	SquareInterpolation: func(step float64, precision float64) LineSearch {
+		var sqrInt SquareInterpolationSearch
+		sqrInt.Init(step, precision)
+		return &sqrInt
+	},
+	CubicInterpolation: func(step float64, precision float64) LineSearch {
+		var cInt CubicInterpolationSearch
+		cInt.Init(step, precision)
+		return &cInt
+	},
+	Armijo: func(step float64, precision float64) LineSearch {
+		var as ArmijoSearch
+		as.Init(step, 0.0001, 0.5)
+		return &as
+	},
+	MoreThuente: func(step float64, precision float64) LineSearch {
+		var mt MoreThuenteSearch
+		mt.Init(step, 0.0001, 0.9, precision)
+		return &mt
+	},
+	HagerZhang: func(step float64, precision float64) LineSearch {
+		var hz HagerZhangSearch
+		hz.Init(step, 0.1, 0.9, precision)
+		return &hz
+	},
+}
+
+func Register(method Method, constructor Constructor) {
+	registry[method] = constructor
+}
+
+func New(method Method, step float64, precision float64) (LineSearch, error) {
+	constructor, ok := registry[method]
+	if !ok {
+		return nil, &optimization_errors.MethodError{Method: string(method)}
+	}
+	return constructor(step, precision), nil
+}
+
+func findBounds(ctx context.Context, step float64, alpha float64, phi func(alpha float64) float64) (float64, float64, error) {
+	var svennAlgorithm one_dimension_search.Svenn
+	svennAlgorithm.Init(step, alpha, phi)
+	a, b, err := svennAlgorithm.SolveContext(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error during svenn algorithm: %w", err)
+	}
+	return a, b, nil
+}
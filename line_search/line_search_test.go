@@ -0,0 +1,84 @@
+package line_search
+
+import (
+	"context"
+	"errors"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
+	"math"
+	"testing"
+)
+
+func parabola(alpha float64) float64 {
+	return math.Pow(alpha-2, 2) + 1
+}
+
+func parabolaDerivative(alpha float64) float64 {
+	return 2 * (alpha - 2)
+}
+
+func TestExactSearchesFindMinimum(t *testing.T) {
+	methods := []Method{BreakInTwo, GoldenRatio, Fibonacci, SquareInterpolation, CubicInterpolation}
+	for _, method := range methods {
+		search, err := New(method, 0.1, 1e-6)
+		if err != nil {
+			t.Fatalf("error creating %s search: %v", method, err)
+		}
+		alpha, err := search.Search(context.Background(), parabola, parabolaDerivative, 0)
+		if err != nil {
+			t.Fatalf("error during %s search: %v", method, err)
+		}
+		if math.Abs(alpha-2) > 1e-3 {
+			t.Errorf("%s search: expected minimum at 2, got %f", method, alpha)
+		}
+	}
+}
+
+func TestExactSearchesCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	methods := []Method{BreakInTwo, GoldenRatio, Fibonacci, SquareInterpolation, CubicInterpolation}
+	for _, method := range methods {
+		search, err := New(method, 0.1, 1e-6)
+		if err != nil {
+			t.Fatalf("error creating %s search: %v", method, err)
+		}
+		_, err = search.Search(ctx, parabola, parabolaDerivative, 0)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("%s search: expected cancelled context error, got %v", method, err)
+		}
+	}
+}
+
+func TestNewUnknownMethod(t *testing.T) {
+	_, err := New("unknown", 0.1, 1e-6)
+	if !errors.Is(err, optimization_errors.ErrUnknownMethod) {
+		t.Fatalf("expected unknown method error, got %v", err)
+	}
+	var methodError *optimization_errors.MethodError
+	if !errors.As(err, &methodError) || methodError.Method != "unknown" {
+		t.Errorf("method error isn't extracted: %v", err)
+	}
+}
+
+type fixedSearch struct {
+	step float64
+}
+
+func (fs *fixedSearch) Search(ctx context.Context, phi func(alpha float64) float64, dPhi func(alpha float64) float64, alpha float64) (float64, error) {
+	return fs.step, nil
+}
+
+func TestRegisterCustomMethod(t *testing.T) {
+	var custom Method = "fixed step"
+	Register(custom, func(step float64, precision float64) LineSearch {
+		return &fixedSearch{step: step}
+	})
+	search, err := New(custom, 0.25, 1e-6)
+	if err != nil {
+		t.Fatalf("error creating registered search: %v", err)
+	}
+	alpha, err := search.Search(context.Background(), parabola, parabolaDerivative, 0)
+	if err != nil || alpha != 0.25 {
+		t.Errorf("registered search returned %f, %v", alpha, err)
+	}
+}
@@ -0,0 +1,214 @@
+package line_search
+
+import (
+	"context"
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
+	"math"
+)
+
+const (
+	xTrapLower = 1.1
+	xTrapUpper = 4.0
+	maxStep    = 1e20
+)
+
+type MoreThuenteSearch struct {
+	step float64 // initial trial step, previous alpha is used when zero
+	fTol float64 // sufficient decrease coefficient
+	gTol float64 // curvature coefficient
+	xTol float64 // relative width of uncertainty interval
+}
+
+func (mt *MoreThuenteSearch) Init(step float64, fTol float64, gTol float64, xTol float64) {
+	mt.step = step
+	mt.fTol = fTol
+	mt.gTol = gTol
+	mt.xTol = xTol
+}
+
+func (mt *MoreThuenteSearch) Search(ctx context.Context, phi func(alpha float64) float64, dPhi func(alpha float64) float64, alpha float64) (float64, error) {
+	var interval uncertaintyInterval
+	var stMin, stMax float64
+	if dPhi == nil {
+		return 0, fmt.Errorf("more thuente search requires derivative")
+	}
+	fInit := phi(0)
+	gInit := dPhi(0)
+	if gInit >= 0 {
+		return 0, fmt.Errorf("direction is not a descent direction: %f", gInit)
+	}
+	stp := math.Min(initialStep(mt.step, alpha), maxStep)
+	stage := 1
+	gTest := mt.fTol * gInit
+	width := maxStep
+	width1 := 2 * width
+	interval.stx, interval.fx, interval.gx = 0, fInit, gInit
+	interval.sty, interval.fy, interval.gy = 0, fInit, gInit
+	stMax = stp + xTrapUpper*stp
+	for k := 0; k < maxInexactIterations; k++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		f := phi(stp)
+		g := dPhi(stp)
+		fTest := fInit + stp*gTest
+		if stage == 1 && f <= fTest && g >= 0 {
+			stage = 2
+		}
+		if f <= fTest && math.Abs(g) <= mt.gTol*(-gInit) {
+			return stp, nil
+		}
+		if interval.bracketed && (stp <= stMin || stp >= stMax) {
+			return stp, nil
+		}
+		if interval.bracketed && stMax-stMin <= mt.xTol*stMax {
+			return stp, nil
+		}
+		if stp == maxStep && f <= fTest && g <= gTest {
+			return stp, nil
+		}
+		if stp == 0 && (f > fTest || g >= gTest) {
+			return 0, fmt.Errorf("step is less than minimum step: %g", stp)
+		}
+		if stage == 1 && f <= interval.fx && f > fTest {
+			modified := uncertaintyInterval{
+				stx: interval.stx, fx: interval.fx - interval.stx*gTest, gx: interval.gx - gTest,
+				sty: interval.sty, fy: interval.fy - interval.sty*gTest, gy: interval.gy - gTest,
+				bracketed: interval.bracketed,
+			}
+			stp = modified.update(stp, f-stp*gTest, g-gTest, stMin, stMax)
+			interval.stx, interval.fx, interval.gx = modified.stx, modified.fx+modified.stx*gTest, modified.gx+gTest
+			interval.sty, interval.fy, interval.gy = modified.sty, modified.fy+modified.sty*gTest, modified.gy+gTest
+			interval.bracketed = modified.bracketed
+		} else {
+			stp = interval.update(stp, f, g, stMin, stMax)
+		}
+		if interval.bracketed {
+			if math.Abs(interval.sty-interval.stx) >= 0.66*width1 {
+				stp = interval.stx + 0.5*(interval.sty-interval.stx)
+			}
+			width1 = width
+			width = math.Abs(interval.sty - interval.stx)
+			stMin = math.Min(interval.stx, interval.sty)
+			stMax = math.Max(interval.stx, interval.sty)
+		} else {
+			stMin = stp + xTrapLower*(stp-interval.stx)
+			stMax = stp + xTrapUpper*(stp-interval.stx)
+		}
+		stp = math.Min(math.Max(stp, 0), maxStep)
+		if interval.bracketed && (stp <= stMin || stp >= stMax || stMax-stMin <= mt.xTol*stMax) {
+			stp = interval.stx
+		}
+	}
+	return 0, fmt.Errorf("%w: strong wolfe conditions are not satisfied", optimization_errors.ErrMaxIterations)
+}
+
+type uncertaintyInterval struct {
+	stx, fx, gx float64 // best step so far
+	sty, fy, gy float64 // other endpoint of interval
+	bracketed   bool
+}
+
+func (ui *uncertaintyInterval) update(stp float64, fp float64, dp float64, stpMin float64, stpMax float64) float64 {
+	var stpf float64
+	sgnd := dp * (ui.gx / math.Abs(ui.gx))
+	if fp > ui.fx {
+		theta := 3*(ui.fx-fp)/(stp-ui.stx) + ui.gx + dp
+		s := math.Max(math.Abs(theta), math.Max(math.Abs(ui.gx), math.Abs(dp)))
+		gamma := s * math.Sqrt((theta/s)*(theta/s)-(ui.gx/s)*(dp/s))
+		if stp < ui.stx {
+			gamma = -gamma
+		}
+		p := (gamma - ui.gx) + theta
+		q := ((gamma - ui.gx) + gamma) + dp
+		stpc := ui.stx + p/q*(stp-ui.stx)
+		stpq := ui.stx + ((ui.gx/((ui.fx-fp)/(stp-ui.stx)+ui.gx))/2)*(stp-ui.stx)
+		if math.Abs(stpc-ui.stx) < math.Abs(stpq-ui.stx) {
+			stpf = stpc
+		} else {
+			stpf = stpc + (stpq-stpc)/2
+		}
+		ui.bracketed = true
+	} else if sgnd < 0 {
+		theta := 3*(ui.fx-fp)/(stp-ui.stx) + ui.gx + dp
+		s := math.Max(math.Abs(theta), math.Max(math.Abs(ui.gx), math.Abs(dp)))
+		gamma := s * math.Sqrt((theta/s)*(theta/s)-(ui.gx/s)*(dp/s))
+		if stp > ui.stx {
+			gamma = -gamma
+		}
+		p := (gamma - dp) + theta
+		q := ((gamma - dp) + gamma) + ui.gx
+		stpc := stp + p/q*(ui.stx-stp)
+		stpq := stp + (dp/(dp-ui.gx))*(ui.stx-stp)
+		if math.Abs(stpc-stp) > math.Abs(stpq-stp) {
+			stpf = stpc
+		} else {
+			stpf = stpq
+		}
+		ui.bracketed = true
+	} else if math.Abs(dp) < math.Abs(ui.gx) {
+		var stpc float64
+		theta := 3*(ui.fx-fp)/(stp-ui.stx) + ui.gx + dp
+		s := math.Max(math.Abs(theta), math.Max(math.Abs(ui.gx), math.Abs(dp)))
+		gamma := s * math.Sqrt(math.Max(0, (theta/s)*(theta/s)-(ui.gx/s)*(dp/s)))
+		if stp > ui.stx {
+			gamma = -gamma
+		}
+		p := (gamma - dp) + theta
+		q := (gamma + (ui.gx - dp)) + gamma
+		r := p / q
+		if r < 0 && gamma != 0 {
+			stpc = stp + r*(ui.stx-stp)
+		} else if stp > ui.stx {
+			stpc = stpMax
+		} else {
+			stpc = stpMin
+		}
+		stpq := stp + (dp/(dp-ui.gx))*(ui.stx-stp)
+		if ui.bracketed {
+			if math.Abs(stpc-stp) < math.Abs(stpq-stp) {
+				stpf = stpc
+			} else {
+				stpf = stpq
+			}
+			if stp > ui.stx {
+				stpf = math.Min(stp+0.66*(ui.sty-stp), stpf)
+			} else {
+				stpf = math.Max(stp+0.66*(ui.sty-stp), stpf)
+			}
+		} else {
+			if math.Abs(stpc-stp) > math.Abs(stpq-stp) {
+				stpf = stpc
+			} else {
+				stpf = stpq
+			}
+			stpf = math.Max(stpMin, math.Min(stpMax, stpf))
+		}
+	} else {
+		if ui.bracketed {
+			theta := 3*(fp-ui.fy)/(ui.sty-stp) + ui.gy + dp
+			s := math.Max(math.Abs(theta), math.Max(math.Abs(ui.gy), math.Abs(dp)))
+			gamma := s * math.Sqrt((theta/s)*(theta/s)-(ui.gy/s)*(dp/s))
+			if stp > ui.sty {
+				gamma = -gamma
+			}
+			p := (gamma - dp) + theta
+			q := ((gamma - dp) + gamma) + ui.gy
+			stpf = stp + p/q*(ui.sty-stp)
+		} else if stp > ui.stx {
+			stpf = stpMax
+		} else {
+			stpf = stpMin
+		}
+	}
+	if fp > ui.fx {
+		ui.sty, ui.fy, ui.gy = stp, fp, dp
+	} else {
+		if sgnd < 0 {
+			ui.sty, ui.fy, ui.gy = ui.stx, ui.fx, ui.gx
+		}
+		ui.stx, ui.fx, ui.gx = stp, fp, dp
+	}
+	return stpf
+}
@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/autodiff"
 	"github.com/saskamegaprogrammist/optimization_methods/constraint_methods"
 	"github.com/saskamegaprogrammist/optimization_methods/genetic_methods"
 	"github.com/saskamegaprogrammist/optimization_methods/ideal_point_algorithms"
 	"github.com/saskamegaprogrammist/optimization_methods/interpolation_search"
 	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"github.com/saskamegaprogrammist/optimization_methods/line_search"
 	"github.com/saskamegaprogrammist/optimization_methods/many_criteria_optimization"
 	"github.com/saskamegaprogrammist/optimization_methods/many_dimension_search"
 	"github.com/saskamegaprogrammist/optimization_methods/one_dimension_search"
@@ -15,30 +18,29 @@ import (
 	"time"
 )
 
-func rozenbrokeOneDimensionFunction(a float64, b float64, f float64) func(xs []float64) float64 {
-	return func(xs []float64) float64 {
-		var result float64
+func rozenbrokeFunction(a float64, b float64, f float64) autodiff.Function {
+	return func(xs []autodiff.HyperDual) autodiff.HyperDual {
+		result := autodiff.HyperDual{Real: f}
 		n := len(xs)
 		for i, x := range xs {
 			if i != n-1 {
-				result += a*math.Pow(math.Pow(x, 2)-xs[i+1], 2) + b*math.Pow(x-1, 2)
+				result = result.Add(x.Mul(x).Sub(xs[i+1]).PowK(2).MulK(a)).Add(x.SubK(1).PowK(2).MulK(b))
 			}
 		}
-		result += f
 		return result
 	}
 }
 
-func shekelFunc(a float64, b float64, f []float64, x0 [][]float64) func(xs []float64) float64 {
-	return func(xs []float64) float64 {
-		var result float64
+func shekelFunction(a float64, b float64, f []float64, x0 [][]float64) autodiff.Function {
+	return func(xs []autodiff.HyperDual) autodiff.HyperDual {
+		var result autodiff.HyperDual
 		n := len(xs)
 		for i := 0; i < n; i++ {
-			var s float64
+			var s autodiff.HyperDual
 			for j := 0; j < n; j++ {
-				s += math.Pow(xs[j]-x0[i][j], 2)
+				s = s.Add(xs[j].SubK(x0[i][j]).PowK(2))
 			}
-			result -= a / (f[i] + b*s)
+			result = result.Sub(s.MulK(b).AddK(f[i]).Inv().MulK(a))
 		}
 		return result
 	}
@@ -66,167 +68,6 @@ func fitnessFuncForGeneticAlg() func(xs []float64) float64 {
 	}
 }
 
-func rozenbrokeOneDimensionFunction2() func(xs []float64) float64 {
-	var a, b, f float64
-	a = 158
-	b = 2
-	f = 40
-	return func(xs []float64) float64 {
-		var result float64
-		n := len(xs)
-		for i, x := range xs {
-			if i != n-1 {
-				result += a*math.Pow(math.Pow(x, 2)-xs[i+1], 2) + b*math.Pow(x-1, 2)
-			}
-		}
-		result += f
-		return result
-	}
-}
-
-func rozenbrokeFirstGrad(a, b, f float64) func(xs []float64) float64 {
-	return func(xs []float64) float64 {
-		return a*2*2*xs[0]*(math.Pow(xs[0], 2)-xs[1]) + 2*b*(xs[0]-1)
-	}
-}
-
-func rozenbrokeFirstGrad2() func(xs []float64) float64 {
-	var a, b float64
-	a = 158
-	b = 2
-	return func(xs []float64) float64 {
-		return a*2*2*xs[0]*(math.Pow(xs[0], 2)-xs[1]) + 2*b*(xs[0]-1)
-	}
-}
-
-func rozenbrokeFirstGradFirst(a float64, b float64, f float64) func(xs []float64) float64 {
-	return func(xs []float64) float64 {
-		return a*2*2*(3*math.Pow(xs[0], 2)-xs[1]) + 2*b
-	}
-}
-
-func rozenbrokeFirstGradFirst2() func(xs []float64) float64 {
-	var a, b float64
-	a = 158
-	b = 2
-	return func(xs []float64) float64 {
-		return a*2*2*(3*math.Pow(xs[0], 2)-xs[1]) + 2*b
-	}
-}
-
-func rozenbrokeFirstGradSecond(a float64, b float64, f float64) func(xs []float64) float64 {
-	return func(xs []float64) float64 {
-		return (-1) * a * 2 * 2 * xs[0]
-	}
-}
-
-func rozenbrokeFirstGradSecond2() func(xs []float64) float64 {
-	var a float64 = 158
-	return func(xs []float64) float64 {
-		return (-1) * a * 2 * 2 * xs[0]
-	}
-}
-
-func rozenbrokeSecondGrad(a float64, b float64, f float64) func(xs []float64) float64 {
-	return func(xs []float64) float64 {
-		return -a*2*(math.Pow(xs[0], 2)-xs[1]) + 2*b*(xs[1]-1) + 2*2*a*xs[1]*(math.Pow(xs[1], 2)-xs[2])
-	}
-}
-
-func rozenbrokeSecondGradFirst(a float64, b float64, f float64) func(xs []float64) float64 {
-	return func(xs []float64) float64 {
-		return -2 * 2 * a * xs[0]
-	}
-}
-
-func rozenbrokeSecondGradSecond(a float64, b float64, f float64) func(xs []float64) float64 {
-	return func(xs []float64) float64 {
-		return 12*a*math.Pow(xs[1], 2) - 4*a*xs[2] + 2*a + 2*b
-	}
-}
-
-func rozenbrokeSecondGradThird(a float64, b float64, f float64) func(xs []float64) float64 {
-	return func(xs []float64) float64 {
-		return -2 * 2 * a * xs[1]
-	}
-}
-
-func rozenbrokeThirdGrad(a float64, b float64, f float64) func(xs []float64) float64 {
-	return func(xs []float64) float64 {
-		return -a * 2 * (math.Pow(xs[1], 2) - xs[2])
-	}
-}
-
-func rozenbrokeThirdGradSecond(a float64, b float64, f float64) func(xs []float64) float64 {
-	return func(xs []float64) float64 {
-		return -2 * 2 * a * xs[1]
-	}
-}
-
-func rozenbrokeThirdGradThird(a float64, b float64, f float64) func(xs []float64) float64 {
-	return func(xs []float64) float64 {
-		return 2 * a
-	}
-}
-
-func rozenbroke2SecondGrad() func(xs []float64) float64 {
-	var a float64 = 158
-	return func(xs []float64) float64 {
-		return -a * 2 * (math.Pow(xs[0], 2) - xs[1])
-	}
-}
-
-func rozenbroke2SecondGradFirst() func(xs []float64) float64 {
-	var a float64 = 158
-	return func(xs []float64) float64 {
-		return -2 * 2 * a * xs[0]
-	}
-}
-
-func rozenbroke2SecondGradSecond() func(xs []float64) float64 {
-	var a float64 = 158
-	return func(xs []float64) float64 {
-		return 2 * a
-	}
-}
-
-func hess(a float64, b float64, f float64) func(xs []float64) la_methods.Matrix {
-	return func(xs []float64) la_methods.Matrix {
-		return hessian(xs, a, b, f)
-	}
-}
-
-func hess2() func(xs []float64) la_methods.Matrix {
-	return func(xs []float64) la_methods.Matrix {
-		return hessian2(xs)
-	}
-}
-
-func hessian(xs []float64, a float64, b float64, f float64) la_methods.Matrix {
-	var hess la_methods.Matrix
-	dim := len(xs)
-	hess.Init(dim, dim)
-	hess.Points[0][0] = rozenbrokeFirstGradFirst(a, b, f)(xs)
-	hess.Points[0][1] = rozenbrokeFirstGradSecond(a, b, f)(xs)
-	hess.Points[1][0] = rozenbrokeSecondGradFirst(a, b, f)(xs)
-	hess.Points[1][1] = rozenbrokeSecondGradSecond(a, b, f)(xs)
-	hess.Points[1][2] = rozenbrokeSecondGradThird(a, b, f)(xs)
-	hess.Points[2][1] = rozenbrokeThirdGradSecond(a, b, f)(xs)
-	hess.Points[2][1] = rozenbrokeThirdGradThird(a, b, f)(xs)
-	return hess
-}
-
-func hessian2(xs []float64) la_methods.Matrix {
-	var hess la_methods.Matrix
-	dim := len(xs)
-	hess.Init(dim, dim)
-	hess.Points[0][0] = rozenbrokeFirstGradFirst2()(xs)
-	hess.Points[0][1] = rozenbrokeFirstGradSecond2()(xs)
-	hess.Points[1][0] = rozenbroke2SecondGradFirst()(xs)
-	hess.Points[1][1] = rozenbroke2SecondGradSecond()(xs)
-	return hess
-}
-
 func targetFunction(x float64) float64 {
 	return 100*math.Pow(math.Pow(x, 2)-2, 3) + math.Pow(x-1, 2) - math.Abs(10+x)
 }
@@ -329,15 +170,21 @@ func third() {
 	var timeStart, timeEnd time.Time
 	var xMin []float64
 	var yMin float64
+	var search line_search.LineSearch
 	precision := 0.0001
 	alphaPrecision := 0.0000001
 	oneDStep := 0.1
-	rFunc := rozenbrokeOneDimensionFunction(100, 2, 45)
+	rFunc := autodiff.Func(rozenbrokeFunction(100, 2, 45))
 
 	var hjs many_dimension_search.HookeJeevesSearch
 	timeStart = time.Now()
+	search, err = line_search.New(line_search.BreakInTwo, precision, oneDStep)
+	if err != nil {
+		fmt.Printf("error creating line search: %v\n", err)
+		return
+	}
 	hjs.Init([]float64{0, 0, 0}, precision*10, 3, 2, precision, alphaPrecision,
-		oneDStep, rFunc, "break in two")
+		rFunc, search)
 	xMin, yMin, err = hjs.Solve()
 	if err != nil {
 		fmt.Printf("error solving hooke jeeves: %v\n", err)
@@ -354,8 +201,13 @@ func third() {
 	fmt.Printf("hooke jeeves search break in two algorithm took : %v\n", timeEnd.Sub(timeStart))
 
 	timeStart = time.Now()
+	search, err = line_search.New(line_search.GoldenRatio, precision, oneDStep)
+	if err != nil {
+		fmt.Printf("error creating line search: %v\n", err)
+		return
+	}
 	hjs.Init([]float64{0, 0, 0}, precision*10, 3, 2, precision, alphaPrecision,
-		oneDStep, rFunc, "golden ratio")
+		rFunc, search)
 	xMin, yMin, err = hjs.Solve()
 	if err != nil {
 		fmt.Printf("error solving hooke jeeves: %v\n", err)
@@ -372,8 +224,13 @@ func third() {
 	fmt.Printf("hooke jeeves search golden ratio algorithm took : %v\n", timeEnd.Sub(timeStart))
 
 	timeStart = time.Now()
+	search, err = line_search.New(line_search.Fibonacci, precision, oneDStep)
+	if err != nil {
+		fmt.Printf("error creating line search: %v\n", err)
+		return
+	}
 	hjs.Init([]float64{0, 0, 0}, precision*10, 3, 2, precision, alphaPrecision,
-		oneDStep, rFunc, "fibonacci")
+		rFunc, search)
 	xMin, yMin, err = hjs.Solve()
 	if err != nil {
 		fmt.Printf("error solving hooke jeeves: %v\n", err)
@@ -390,8 +247,10 @@ func third() {
 	fmt.Printf("hooke jeeves search fibonacci algorithm took : %v\n", timeEnd.Sub(timeStart))
 
 	var nms many_dimension_search.NelderMeadSearch
+	var recorder many_dimension_search.Recorder
 	timeStart = time.Now()
 	nms.Init([]float64{0, 0, 0}, 0.1, 3, precision, rFunc)
+	nms.SetObserver(recorder.Observe)
 	xMin, yMin, err = nms.Solve()
 	if err != nil {
 		fmt.Printf("error solving nelder mead: %v\n", err)
@@ -399,6 +258,10 @@ func third() {
 	}
 	timeEnd = time.Now()
 
+	for _, info := range recorder.Trajectory {
+		fmt.Printf("iteration %d: f = %f, step = %f\n", info.Iteration, info.F, info.Step)
+	}
+
 	fmt.Printf("minimum: %f\n", yMin)
 	for _, p := range xMin {
 		fmt.Printf("minimum point: %f ", p)
@@ -413,6 +276,7 @@ func fourth() {
 	var timeStart, timeEnd time.Time
 	var xMin []float64
 	var yMin float64
+	var search line_search.LineSearch
 	precision := 0.000001
 	alphaPrecision := 0.000001
 	oneDStepFib := 0.001
@@ -420,17 +284,24 @@ func fourth() {
 	oneDStepInterp := 0.01
 	maxIter := 5000
 
-	rFunc := rozenbrokeOneDimensionFunction(100, 2, 45)
-	gradFunctions := []func(xs []float64) float64{rozenbrokeFirstGrad(100, 2, 45),
-		rozenbrokeSecondGrad(100, 2, 45), rozenbrokeThirdGrad(100, 2, 45)}
-	hessian := hess(100, 2, 45)
+	rozenbroke := rozenbrokeFunction(100, 2, 45)
+	rFunc := autodiff.Func(rozenbroke)
+	gradFunctions := autodiff.Gradient(rozenbroke, 3)
+	hessian := autodiff.Hessian(rozenbroke, 3)
 	var fgd many_dimension_search.FastGradientDescendSearch
 	var frs many_dimension_search.FletcherReevesSearch
 	var dfps many_dimension_search.DavidonFletcherPowellSearch
+	var bfgs many_dimension_search.BFGSSearch
+	var lbfgs many_dimension_search.LBFGSSearch
 	var lms many_dimension_search.LevenbergMarkkvadratSearch
 
 	timeStart = time.Now()
-	fgd.Init([]float64{0, 0, 0}, precision, precision, rFunc, gradFunctions, 3, precision, alphaPrecision, "break in two")
+	search, err = line_search.New(line_search.BreakInTwo, precision, precision)
+	if err != nil {
+		fmt.Printf("error creating line search: %v\n", err)
+		return
+	}
+	fgd.Init([]float64{0, 0, 0}, precision, precision, rFunc, gradFunctions, 3, alphaPrecision, search)
 	xMin, yMin, err = fgd.Solve()
 	if err != nil {
 		fmt.Printf("error solving fast gradient descent: %v\n", err)
@@ -447,7 +318,12 @@ func fourth() {
 	fmt.Printf("fast gradient descent search break in two algorithm took : %v\n", timeEnd.Sub(timeStart))
 
 	timeStart = time.Now()
-	fgd.Init([]float64{0, 0, 0}, precision, precision, rFunc, gradFunctions, 3, precision, alphaPrecision, "golden ratio")
+	search, err = line_search.New(line_search.GoldenRatio, precision, precision)
+	if err != nil {
+		fmt.Printf("error creating line search: %v\n", err)
+		return
+	}
+	fgd.Init([]float64{0, 0, 0}, precision, precision, rFunc, gradFunctions, 3, alphaPrecision, search)
 	xMin, yMin, err = fgd.Solve()
 	if err != nil {
 		fmt.Printf("error solving fast gradient descent: %v\n", err)
@@ -464,7 +340,12 @@ func fourth() {
 	fmt.Printf("fast gradient descent golden ratio algorithm took : %v\n", timeEnd.Sub(timeStart))
 
 	timeStart = time.Now()
-	fgd.Init([]float64{0, 0, 0}, precision, precision, rFunc, gradFunctions, 3, precision, alphaPrecision, "square interpolation")
+	search, err = line_search.New(line_search.SquareInterpolation, 0.001, 0.0001)
+	if err != nil {
+		fmt.Printf("error creating line search: %v\n", err)
+		return
+	}
+	fgd.Init([]float64{0, 0, 0}, precision, precision, rFunc, gradFunctions, 3, alphaPrecision, search)
 	xMin, yMin, err = fgd.Solve()
 	if err != nil {
 		fmt.Printf("error solving fast gradient descent: %v\n", err)
@@ -481,7 +362,12 @@ func fourth() {
 	fmt.Printf("fast gradient descent square interpolation algorithm took : %v\n", timeEnd.Sub(timeStart))
 
 	timeStart = time.Now()
-	fgd.Init([]float64{0, 0, 0}, precision, precision, rFunc, gradFunctions, 3, precision, alphaPrecision, "fibonacci")
+	search, err = line_search.New(line_search.Fibonacci, precision, precision)
+	if err != nil {
+		fmt.Printf("error creating line search: %v\n", err)
+		return
+	}
+	fgd.Init([]float64{0, 0, 0}, precision, precision, rFunc, gradFunctions, 3, alphaPrecision, search)
 	xMin, yMin, err = fgd.Solve()
 	if err != nil {
 		fmt.Printf("error solving fast gradient descent: %v\n", err)
@@ -498,7 +384,12 @@ func fourth() {
 	fmt.Printf("fast gradient descent fibonacci algorithm took : %v\n", timeEnd.Sub(timeStart))
 
 	timeStart = time.Now()
-	frs.Init([]float64{0, 0, 0}, alphaPrecision, 3, precision, precision, alphaPrecision, oneDStepFib, maxIter, rFunc, gradFunctions, "break in two", true)
+	search, err = line_search.New(line_search.BreakInTwo, precision, oneDStepFib)
+	if err != nil {
+		fmt.Printf("error creating line search: %v\n", err)
+		return
+	}
+	frs.Init([]float64{0, 0, 0}, alphaPrecision, 3, precision, precision, alphaPrecision, maxIter, rFunc, gradFunctions, search, true)
 	xMin, yMin, err = frs.Solve()
 	if err != nil {
 		fmt.Printf("error solving pollak : %v\n", err)
@@ -515,7 +406,12 @@ func fourth() {
 	fmt.Printf("pollak break in two algorithm took : %v\n", timeEnd.Sub(timeStart))
 
 	timeStart = time.Now()
-	frs.Init([]float64{0, 0, 0}, 0.0001, 3, precision, precision, alphaPrecision, 0.01, maxIter, rFunc, gradFunctions, "golden ratio", true)
+	search, err = line_search.New(line_search.GoldenRatio, precision, 0.01)
+	if err != nil {
+		fmt.Printf("error creating line search: %v\n", err)
+		return
+	}
+	frs.Init([]float64{0, 0, 0}, 0.0001, 3, precision, precision, alphaPrecision, maxIter, rFunc, gradFunctions, search, true)
 	xMin, yMin, err = frs.Solve()
 	if err != nil {
 		fmt.Printf("error solving pollak : %v\n", err)
@@ -532,7 +428,12 @@ func fourth() {
 	fmt.Printf("pollak golden ratio algorithm took : %v\n", timeEnd.Sub(timeStart))
 
 	timeStart = time.Now()
-	frs.Init([]float64{0, 0, 0}, alphaPrecision, 3, precision, precision, alphaPrecision, oneDStepFib, maxIter, rFunc, gradFunctions, "fibonacci", true)
+	search, err = line_search.New(line_search.Fibonacci, precision, oneDStepFib)
+	if err != nil {
+		fmt.Printf("error creating line search: %v\n", err)
+		return
+	}
+	frs.Init([]float64{0, 0, 0}, alphaPrecision, 3, precision, precision, alphaPrecision, maxIter, rFunc, gradFunctions, search, true)
 	xMin, yMin, err = frs.Solve()
 	if err != nil {
 		fmt.Printf("error solving pollak : %v\n", err)
@@ -549,7 +450,31 @@ func fourth() {
 	fmt.Printf("pollak fibonacci algorithm took : %v\n", timeEnd.Sub(timeStart))
 
 	timeStart = time.Now()
-	frs.Init([]float64{0, 0, 0}, alphaPrecision, 3, precision, precision, alphaPrecision, oneDStepFib, maxIter, rFunc, gradFunctions, "break in two", false)
+	var mt line_search.MoreThuenteSearch
+	mt.Init(1, 0.0001, 0.1, precision)
+	frs.Init([]float64{0, 0, 0}, alphaPrecision, 3, precision, precision, alphaPrecision, maxIter, rFunc, gradFunctions, &mt, true)
+	xMin, yMin, err = frs.Solve()
+	if err != nil {
+		fmt.Printf("error solving pollak : %v\n", err)
+		return
+	}
+	timeEnd = time.Now()
+
+	fmt.Printf("minimum: %f\n", yMin)
+	for _, p := range xMin {
+		fmt.Printf("minimum point: %f ", p)
+
+	}
+	fmt.Println()
+	fmt.Printf("pollak more thuente algorithm took : %v\n", timeEnd.Sub(timeStart))
+
+	timeStart = time.Now()
+	search, err = line_search.New(line_search.BreakInTwo, precision, oneDStepFib)
+	if err != nil {
+		fmt.Printf("error creating line search: %v\n", err)
+		return
+	}
+	frs.Init([]float64{0, 0, 0}, alphaPrecision, 3, precision, precision, alphaPrecision, maxIter, rFunc, gradFunctions, search, false)
 	xMin, yMin, err = frs.Solve()
 	if err != nil {
 		fmt.Printf("error solving fletcher reeves : %v\n", err)
@@ -566,7 +491,12 @@ func fourth() {
 	fmt.Printf("fletcher reeves break in two algorithm took : %v\n", timeEnd.Sub(timeStart))
 
 	timeStart = time.Now()
-	frs.Init([]float64{0, 0, 0}, 0.0001, 3, precision, precision, alphaPrecision, 0.01, maxIter, rFunc, gradFunctions, "golden ratio", false)
+	search, err = line_search.New(line_search.GoldenRatio, precision, 0.01)
+	if err != nil {
+		fmt.Printf("error creating line search: %v\n", err)
+		return
+	}
+	frs.Init([]float64{0, 0, 0}, 0.0001, 3, precision, precision, alphaPrecision, maxIter, rFunc, gradFunctions, search, false)
 	xMin, yMin, err = frs.Solve()
 	if err != nil {
 		fmt.Printf("error solving fletcher reeves : %v\n", err)
@@ -583,7 +513,12 @@ func fourth() {
 	fmt.Printf("fletcher reeves golden ratio algorithm took : %v\n", timeEnd.Sub(timeStart))
 
 	timeStart = time.Now()
-	frs.Init([]float64{0, 0, 0}, alphaPrecision, 3, precision, precision, alphaPrecision, oneDStepFib, maxIter, rFunc, gradFunctions, "fibonacci", false)
+	search, err = line_search.New(line_search.Fibonacci, precision, oneDStepFib)
+	if err != nil {
+		fmt.Printf("error creating line search: %v\n", err)
+		return
+	}
+	frs.Init([]float64{0, 0, 0}, alphaPrecision, 3, precision, precision, alphaPrecision, maxIter, rFunc, gradFunctions, search, false)
 	xMin, yMin, err = frs.Solve()
 	if err != nil {
 		fmt.Printf("error solving fletcher reeves : %v\n", err)
@@ -600,7 +535,12 @@ func fourth() {
 	fmt.Printf("fletcher reeves fibonacci algorithm took : %v\n", timeEnd.Sub(timeStart))
 
 	timeStart = time.Now()
-	dfps.Init([]float64{0, 0, 0}, alphaPrecision, 3, precisionInterp, precisionInterp, precisionInterp, oneDStepInterp, maxIter, rFunc, gradFunctions, "break in two")
+	search, err = line_search.New(line_search.BreakInTwo, precisionInterp, oneDStepInterp)
+	if err != nil {
+		fmt.Printf("error creating line search: %v\n", err)
+		return
+	}
+	dfps.Init([]float64{0, 0, 0}, alphaPrecision, 3, precisionInterp, precisionInterp, precisionInterp, maxIter, rFunc, gradFunctions, search)
 	xMin, yMin, err = dfps.Solve()
 	if err != nil {
 		fmt.Printf("error solving davidon fletcher powell : %v\n", err)
@@ -617,7 +557,12 @@ func fourth() {
 	fmt.Printf("davidon fletcher powell break in two algorithm took : %v\n", timeEnd.Sub(timeStart))
 
 	timeStart = time.Now()
-	dfps.Init([]float64{0, 0, 0}, alphaPrecision, 3, precisionInterp, precisionInterp, precisionInterp, oneDStepInterp, maxIter, rFunc, gradFunctions, "golden ratio")
+	search, err = line_search.New(line_search.GoldenRatio, precisionInterp, oneDStepInterp)
+	if err != nil {
+		fmt.Printf("error creating line search: %v\n", err)
+		return
+	}
+	dfps.Init([]float64{0, 0, 0}, alphaPrecision, 3, precisionInterp, precisionInterp, precisionInterp, maxIter, rFunc, gradFunctions, search)
 	xMin, yMin, err = dfps.Solve()
 	if err != nil {
 		fmt.Printf("error solving davidon fletcher powell : %v\n", err)
@@ -634,7 +579,12 @@ func fourth() {
 	fmt.Printf("davidon fletcher powell golden ratio algorithm took : %v\n", timeEnd.Sub(timeStart))
 
 	timeStart = time.Now()
-	dfps.Init([]float64{0, 0, 0}, alphaPrecision, 3, precisionInterp, precisionInterp, precisionInterp, oneDStepInterp, maxIter, rFunc, gradFunctions, "fibonacci")
+	search, err = line_search.New(line_search.Fibonacci, precisionInterp, oneDStepInterp)
+	if err != nil {
+		fmt.Printf("error creating line search: %v\n", err)
+		return
+	}
+	dfps.Init([]float64{0, 0, 0}, alphaPrecision, 3, precisionInterp, precisionInterp, precisionInterp, maxIter, rFunc, gradFunctions, search)
 	xMin, yMin, err = dfps.Solve()
 	if err != nil {
 		fmt.Printf("error solving davidon fletcher powell : %v\n", err)
@@ -651,7 +601,12 @@ func fourth() {
 	fmt.Printf("davidon fletcher powell fibonacci algorithm took : %v\n", timeEnd.Sub(timeStart))
 
 	timeStart = time.Now()
-	dfps.Init([]float64{0, 0, 0}, alphaPrecision, 3, precisionInterp, precisionInterp, precisionInterp, oneDStepInterp, maxIter, rFunc, gradFunctions, "square interpolation")
+	search, err = line_search.New(line_search.SquareInterpolation, oneDStepInterp, precisionInterp)
+	if err != nil {
+		fmt.Printf("error creating line search: %v\n", err)
+		return
+	}
+	dfps.Init([]float64{0, 0, 0}, alphaPrecision, 3, precisionInterp, precisionInterp, precisionInterp, maxIter, rFunc, gradFunctions, search)
 	xMin, yMin, err = dfps.Solve()
 	if err != nil {
 		fmt.Printf("error solving davidon fletcher powell : %v\n", err)
@@ -667,6 +622,45 @@ func fourth() {
 	fmt.Println()
 	fmt.Printf("davidon fletcher powell square interpolation algorithm took : %v\n", timeEnd.Sub(timeStart))
 
+	timeStart = time.Now()
+	search, err = line_search.New(line_search.MoreThuente, 1, precision)
+	if err != nil {
+		fmt.Printf("error creating line search: %v\n", err)
+		return
+	}
+	bfgs.Init([]float64{0, 0, 0}, alphaPrecision, 3, precision, precision, alphaPrecision, maxIter, rFunc, gradFunctions, search)
+	xMin, yMin, err = bfgs.Solve()
+	if err != nil {
+		fmt.Printf("error solving bfgs : %v\n", err)
+		return
+	}
+	timeEnd = time.Now()
+
+	fmt.Printf("minimum: %f\n", yMin)
+	for _, p := range xMin {
+		fmt.Printf("minimum point: %f ", p)
+
+	}
+	fmt.Println()
+	fmt.Printf("bfgs more thuente algorithm took : %v\n", timeEnd.Sub(timeStart))
+
+	timeStart = time.Now()
+	lbfgs.Init([]float64{0, 0, 0}, alphaPrecision, 3, precision, precision, alphaPrecision, maxIter, rFunc, gradFunctions, search, 5)
+	xMin, yMin, err = lbfgs.Solve()
+	if err != nil {
+		fmt.Printf("error solving l-bfgs : %v\n", err)
+		return
+	}
+	timeEnd = time.Now()
+
+	fmt.Printf("minimum: %f\n", yMin)
+	for _, p := range xMin {
+		fmt.Printf("minimum point: %f ", p)
+
+	}
+	fmt.Println()
+	fmt.Printf("l-bfgs more thuente algorithm took : %v\n", timeEnd.Sub(timeStart))
+
 	timeStart = time.Now()
 	lms.Init([]float64{0, 0, 0}, 3, rFunc, gradFunctions, hessian, 10000, 100000, 0.001)
 	xMin, yMin, err = lms.Solve()
@@ -683,6 +677,23 @@ func fourth() {
 	}
 	fmt.Println()
 	fmt.Printf("levenberg markkvadrat algorithm took : %v\n", timeEnd.Sub(timeStart))
+
+	timeStart = time.Now()
+	lms.Init([]float64{0, 0, 0}, 3, rFunc, nil, nil, 10000, 100000, 0.001)
+	xMin, yMin, err = lms.Solve()
+	if err != nil {
+		fmt.Printf("error solving levenberg markkvadrat method : %v\n", err)
+		return
+	}
+	timeEnd = time.Now()
+
+	fmt.Printf("minimum: %f\n", yMin)
+	for _, p := range xMin {
+		fmt.Printf("minimum point: %f ", p)
+
+	}
+	fmt.Println()
+	fmt.Printf("levenberg markkvadrat finite differences algorithm took : %v\n", timeEnd.Sub(timeStart))
 }
 
 func testLMS() {
@@ -691,10 +702,10 @@ func testLMS() {
 	var xMin []float64
 	var yMin float64
 
-	rFunc := rozenbrokeOneDimensionFunction(158, 2, 40)
-	gradFunctions := []func(xs []float64) float64{rozenbrokeFirstGrad(158, 2, 40),
-		rozenbrokeSecondGrad(158, 2, 40), rozenbrokeThirdGrad(158, 2, 40)}
-	hessian := hess(158, 2, 40)
+	rozenbroke := rozenbrokeFunction(158, 2, 40)
+	rFunc := autodiff.Func(rozenbroke)
+	gradFunctions := autodiff.Gradient(rozenbroke, 3)
+	hessian := autodiff.Hessian(rozenbroke, 3)
 	var lms many_dimension_search.LevenbergMarkkvadratSearch
 	timeStart = time.Now()
 	lms.Init([]float64{0, 0, 0}, 3, rFunc, gradFunctions, hessian, 10000, 100000, 0.001)
@@ -731,10 +742,10 @@ func fifth() {
 	var yMin float64
 	precision := 0.001
 
-	rFunc := rozenbrokeOneDimensionFunction2()
-	gradFunctions := []func(xs []float64) float64{rozenbrokeFirstGrad2(),
-		rozenbroke2SecondGrad()}
-	hessian := hess2()
+	rozenbroke := rozenbrokeFunction(158, 2, 40)
+	rFunc := autodiff.Func(rozenbroke)
+	gradFunctions := autodiff.Gradient(rozenbroke, 2)
+	hessian := autodiff.Hessian(rozenbroke, 2)
 
 	constraintExtFunc := constraintExtFunc(firstExtConstraintMod, secondExtConstraintMod, thirdExtConstraintMod)
 	constraintExtGradFunctions := []func(xs []float64, r float64) float64{constraintExtFuncFirstGrad(),
@@ -863,17 +874,23 @@ func sixth() {
 	var timeStart, timeEnd time.Time
 	var xMin []float64
 	var yMin float64
+	var search line_search.LineSearch
 	precision := 0.001
 
-	rFunc := rozenbrokeOneDimensionFunction2()
-	gradFunctions := []func(xs []float64) float64{rozenbrokeFirstGrad2(),
-		rozenbroke2SecondGrad()}
+	rozenbroke := rozenbrokeFunction(158, 2, 40)
+	rFunc := autodiff.Func(rozenbroke)
+	gradFunctions := autodiff.Gradient(rozenbroke, 2)
 
 	var gm constraint_methods.GradientMethod
 
 	timeStart = time.Now()
+	search, err = line_search.New(line_search.BreakInTwo, 0.0001, 0.00001)
+	if err != nil {
+		fmt.Printf("error creating line search: %v\n", err)
+		return
+	}
 	gm.Init([]float64{2, 1}, 2, rFunc,
-		[]func(xs []float64) float64{firstConstraint, secondConstraint, thirdConstraint}, gradFunctions, A(3, 2), -10, precision, 30, "break in two")
+		[]func(xs []float64) float64{firstConstraint, secondConstraint, thirdConstraint}, gradFunctions, A(3, 2), -10, precision, 30, search)
 
 	xMin, yMin, err = gm.Solve()
 	if err != nil {
@@ -986,7 +1003,7 @@ func eighth() {
 	var kmm many_criteria_optimization.KMeansMultistart
 	var cpm many_criteria_optimization.CompetitivePointsMultistart
 
-	shF := shekelFunc(a, b, f, x0)
+	shF := autodiff.Func(shekelFunction(a, b, f, x0))
 
 	timeStart = time.Now()
 	kmm.Init([]float64{1, 2, 1}, 10, 3, 0, 5, shF)
@@ -1004,6 +1021,9 @@ func eighth() {
 
 	}
 	fmt.Println()
+	for _, m := range kmm.Minima() {
+		fmt.Printf("local minimum: %f, point: %f, starts: %d, basin size: %d\n", m.F, m.X, m.Starts, m.BasinSize)
+	}
 	fmt.Printf("k means algorithm took : %v\n", timeEnd.Sub(timeStart))
 
 	timeStart = time.Now()
@@ -1022,6 +1042,9 @@ func eighth() {
 
 	}
 	fmt.Println()
+	for _, m := range cpm.Minima() {
+		fmt.Printf("local minimum: %f, point: %f, starts: %d, basin size: %d\n", m.F, m.X, m.Starts, m.BasinSize)
+	}
 	fmt.Printf("competitive points algorithm took : %v\n", timeEnd.Sub(timeStart))
 
 	//for i := 0; i < 10; i++ {
@@ -1074,6 +1097,12 @@ func tenth() {
 	}
 	timeEnd = time.Now()
 
+	xIdeals, fIdeal := cm.IdealPoints()
+	for i, xIdeal := range xIdeals {
+		fmt.Printf("ideal point %d: %f\n", i+1, xIdeal)
+	}
+	fmt.Println("ideal values: ", fIdeal)
+
 	for i, xMin := range xMins {
 		fmt.Printf("minimum: %f ", yMins[i])
 		for _, p := range xMin {
@@ -1086,6 +1115,67 @@ func tenth() {
 	fmt.Printf("convolution multicriteria algorithm took : %v\n", timeEnd.Sub(timeStart))
 }
 
+func eleventh() {
+	var err error
+	var result many_dimension_search.Result
+	var xMin []float64
+	var yMin float64
+	var timeStart, timeEnd time.Time
+	x0 := [][]float64{{1, 2, 3}, {1, 2, 3}, {1, 2, 3}}
+	shF := autodiff.Func(shekelFunction(2, 3, []float64{1, 1, 1}, x0))
+
+	problems := []many_dimension_search.Problem{
+		{TargetFunc: shF, Dimension: 3, StartPoint: []float64{1, 2, 1},
+			Bounds: many_dimension_search.Bounds{Lower: []float64{0, 0, 0}, Upper: []float64{5, 5, 5}}},
+		{TargetFunc: funcForGeneticAlg(), Dimension: 4, StartPoint: []float64{10, 10, 10, 10},
+			Bounds: many_dimension_search.Bounds{Lower: []float64{0.8, 0.8, 0.8, 0.8}, Upper: []float64{20, 20, 20, 20}}},
+	}
+	settings := many_dimension_search.DefaultSettings()
+	settings.MaxIter = 20000
+	settings.ExploreStep = 1
+	settings.Restarts = 5
+	for _, cooling := range []many_dimension_search.CoolingSchedule{many_dimension_search.BoltzmannCooling,
+		many_dimension_search.CauchyCooling, many_dimension_search.ExponentialCooling} {
+		settings.Cooling = cooling
+		for _, problem := range problems {
+			var sa many_dimension_search.SimulatedAnnealingSearch
+			result, err = sa.SolveProblem(context.Background(), problem, settings)
+			if err != nil {
+				fmt.Printf("error solving simulated annealing: %v", err)
+				return
+			}
+			fmt.Printf("%s cooling minimum: %f, point: %f, evaluations: %d, reason: %v\n",
+				cooling, result.F, result.X, result.FuncEvaluations, result.Reason)
+			fmt.Printf("simulated annealing took : %v\n", result.Time)
+		}
+	}
+
+	var ga genetic_methods.GeneticAlgorithm
+	timeStart = time.Now()
+	ga.Init(0, 5, 3, 1000, []float64{1, 2, 1}, 3, shF, func(xs []float64) float64 {
+		return -shF(xs)
+	})
+	xMin, yMin, err = ga.Solve()
+	if err != nil {
+		fmt.Printf("error solving genetic algorithm: %v", err)
+		return
+	}
+	timeEnd = time.Now()
+	fmt.Printf("genetic algorithm minimum: %f, point: %f\n", yMin, xMin)
+	fmt.Printf("genetic algorithm took : %v\n", timeEnd.Sub(timeStart))
+
+	timeStart = time.Now()
+	ga.Init(0.8, 20, 3, 1000, []float64{1, 1, 1}, 4, funcForGeneticAlg(), fitnessFuncForGeneticAlg())
+	xMin, yMin, err = ga.Solve()
+	if err != nil {
+		fmt.Printf("error solving genetic algorithm: %v", err)
+		return
+	}
+	timeEnd = time.Now()
+	fmt.Printf("genetic algorithm minimum: %f, point: %f\n", yMin, xMin)
+	fmt.Printf("genetic algorithm took : %v\n", timeEnd.Sub(timeStart))
+}
+
 func main() {
 	tenth()
 }
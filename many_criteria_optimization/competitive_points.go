@@ -5,6 +5,7 @@ import (
 	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
 	"github.com/saskamegaprogrammist/optimization_methods/many_dimension_search"
 	"github.com/saskamegaprogrammist/optimization_methods/random_points_gen"
+	"math/rand"
 )
 
 //var nPow = 5
@@ -14,8 +15,11 @@ type CompetitivePointsMultistart struct {
 	beta       float64
 	dimension  int
 	targetFunc func(xs []float64) float64
-	generator  random_points_gen.StDistributionGen
+	samples    int
+	generator  random_points_gen.Generator // uniform distribution with samples points when not set
+	random     *rand.Rand
 	search     many_dimension_search.NelderMeadSearch
+	minima     minimaSet // distinct minima found by last solve
 }
 
 func (cpm *CompetitivePointsMultistart) Init(dimension int,
@@ -24,13 +28,43 @@ func (cpm *CompetitivePointsMultistart) Init(dimension int,
 	cpm.dimension = dimension
 	cpm.alpha = alpha
 	cpm.beta = beta
-	cpm.generator.Init(alpha, beta, n, dimension)
+	cpm.samples = defaultSamples
+	cpm.generator = nil
+	cpm.random = nil
+	cpm.minima.tolerance = minimaTolerance
+}
+
+func (cpm *CompetitivePointsMultistart) SetTolerance(tolerance float64) {
+	cpm.minima.tolerance = tolerance
+}
+
+func (cpm *CompetitivePointsMultistart) Minima() []LocalMinimum {
+	return cpm.minima.sorted()
+}
+
+func (cpm *CompetitivePointsMultistart) SetGenerator(generator random_points_gen.Generator) {
+	cpm.generator = generator
+}
+
+func (cpm *CompetitivePointsMultistart) SetSamples(samples int) {
+	cpm.samples = samples
+}
+
+func (cpm *CompetitivePointsMultistart) SetSeed(seed int64) {
+	cpm.random = rand.New(rand.NewSource(seed))
+	if rg, ok := cpm.generator.(random_points_gen.RandomGenerator); ok {
+		rg.SetRandom(cpm.random)
+	}
 }
 
 func (cpm *CompetitivePointsMultistart) Solve() ([]float64, float64, error) {
 	var err error
-	var clustersLen = n
-	points := cpm.generator.Generate()
+	cpm.minima.reset()
+	points := cpm.generate()
+	var clustersLen = len(points)
+	if clustersLen == 0 {
+		return nil, 0, fmt.Errorf("no start points generated")
+	}
 
 	var vectors = make([]la_methods.Vector, clustersLen)
 
@@ -44,13 +78,15 @@ func (cpm *CompetitivePointsMultistart) Solve() ([]float64, float64, error) {
 	var clusterMin = make([]float64, clustersLen)
 	var clusterMinV = make([]la_methods.Vector, clustersLen)
 	var clusters = make([][]la_methods.Vector, clustersLen)
+	var members = make([]int, clustersLen) // sample points sharing cluster minimum
 	for i := 0; i < clustersLen; i++ {
 		clusters[i] = append(clusters[i], vectors[i])
+		members[i] = 1
 		clusterMinV[i] = vectors[i]
 		clusterMin[i] = cpm.targetFunc(vectors[i].Points)
 	}
 
-	for clustersLen != 1 {
+	for round := 0; clustersLen != 1; round++ {
 		var xMin []float64
 		var yMin float64
 
@@ -59,12 +95,18 @@ func (cpm *CompetitivePointsMultistart) Solve() ([]float64, float64, error) {
 			xMin, yMin, err = cpm.search.Solve()
 			//fmt.Println(yMin)
 			if err != nil {
-				return nil, 0, fmt.Errorf("error solver nelder mead: %v", err)
+				return nil, 0, fmt.Errorf("error solver nelder mead: %w", err)
 			}
 			newV := la_methods.Vector{
 				Points:    xMin,
 				Dimension: cpm.dimension,
 			}
+			if round == 0 { // later rounds restart from cluster minima, not sample points
+				err = cpm.minima.add(xMin, yMin, 1, 0) // basins are added when clusters merge
+				if err != nil {
+					return nil, 0, fmt.Errorf("error adding minimum: %w", err)
+				}
+			}
 			clusters[i][0] = newV
 			clusterMin[i] = yMin
 			clusterMinV[i] = newV
@@ -83,7 +125,7 @@ func (cpm *CompetitivePointsMultistart) Solve() ([]float64, float64, error) {
 					for r := 0; r < len2; r++ {
 						dist, err = clusters[i][k].EqDist(clusters[j][r])
 						if err != nil {
-							return nil, 0, fmt.Errorf("error caluclating dist: %v", err)
+							return nil, 0, fmt.Errorf("error caluclating dist: %w", err)
 						}
 						if dist < minDistInner {
 							minDistInner = dist
@@ -91,8 +133,8 @@ func (cpm *CompetitivePointsMultistart) Solve() ([]float64, float64, error) {
 					}
 				}
 
-				if dist < minDist {
-					minDist = dist
+				if minDistInner < minDist {
+					minDist = minDistInner
 					minI1 = i
 					minI2 = j
 				}
@@ -101,27 +143,56 @@ func (cpm *CompetitivePointsMultistart) Solve() ([]float64, float64, error) {
 		var newClusters [][]la_methods.Vector
 		var newClustersMin []float64
 		var newClustersMinV []la_methods.Vector
+		var newMembers []int
 		clustersLen--
 		for i, c := range clusters {
 			if i != minI2 && i != minI1 {
 				newClusters = append(newClusters, c)
 				newClustersMin = append(newClustersMin, clusterMin[i])
 				newClustersMinV = append(newClustersMinV, clusterMinV[i])
+				newMembers = append(newMembers, members[i])
 			}
 		}
+		winner, loser := minI2, minI1
 		if clusterMin[minI1] < clusterMin[minI2] {
-			newClustersMin = append(newClustersMin, clusterMin[minI1])
-			newClustersMinV = append(newClustersMinV, clusterMinV[minI1])
-			newClusters = append(newClusters, clusters[minI1])
-
+			winner, loser = minI1, minI2
+		}
+		newClustersMin = append(newClustersMin, clusterMin[winner])
+		newClustersMinV = append(newClustersMinV, clusterMinV[winner])
+		newClusters = append(newClusters, clusters[winner])
+		dist, err = clusterMinV[winner].EqDist(clusterMinV[loser])
+		if err != nil {
+			return nil, 0, fmt.Errorf("error caluclating dist: %w", err)
+		}
+		if dist < cpm.minima.tolerance {
+			newMembers = append(newMembers, members[winner]+members[loser])
 		} else {
-			newClustersMin = append(newClustersMin, clusterMin[minI2])
-			newClustersMinV = append(newClustersMinV, clusterMinV[minI2])
-			newClusters = append(newClusters, clusters[minI2])
+			newMembers = append(newMembers, members[winner])
+			err = cpm.minima.add(clusterMinV[loser].Points, clusterMin[loser], 0, members[loser]) // loser basin is complete
+			if err != nil {
+				return nil, 0, fmt.Errorf("error adding minimum: %w", err)
+			}
 		}
 		clusters = newClusters
 		clusterMinV = newClustersMinV
 		clusterMin = newClustersMin
+		members = newMembers
+	}
+	err = cpm.minima.add(clusterMinV[0].Points, clusterMin[0], 0, members[0])
+	if err != nil {
+		return nil, 0, fmt.Errorf("error adding minimum: %w", err)
 	}
 	return clusterMinV[0].Points, clusterMin[0], nil
 }
+
+func (cpm *CompetitivePointsMultistart) generate() [][]float64 {
+	if cpm.generator != nil {
+		return cpm.generator.Generate()
+	}
+	var generator random_points_gen.StDistributionGen
+	generator.Init(cpm.alpha, cpm.beta, cpm.samples, cpm.dimension)
+	if cpm.random != nil {
+		generator.SetRandom(cpm.random)
+	}
+	return generator.Generate()
+}
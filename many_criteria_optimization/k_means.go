@@ -1,21 +1,43 @@
 package many_criteria_optimization
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"github.com/saskamegaprogrammist/optimization_methods/many_dimension_search"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
 	"github.com/saskamegaprogrammist/optimization_methods/random_points_gen"
+	"math"
+	"sort"
 )
 
-var n = 20
+type Clustering int
+
+const (
+	SingleLinkage           Clustering = iota // points closer than delta form one cluster
+	MultiLevelSingleLinkage                   // rinnooy kan and timmer critical distance
+)
+
+const (
+	defaultSamples = 20
+	mlslSigma      = 4.0 // critical distance coefficient, more than 2 bounds local searches number
+	reducedSample  = 0.2 // part of best points used as mlsl start candidates
+)
 
 type KMeansMultistart struct {
-	startPoint []float64
-	alpha      float64
-	beta       float64
-	delta      float64
-	dimension  int
-	targetFunc func(xs []float64) float64
-	generator  random_points_gen.LinGen
+	startPoint  []float64
+	alpha       float64
+	beta        float64
+	delta       float64 // clustering distance
+	dimension   int
+	samples     int
+	targetFunc  func(xs []float64) float64
+	generator   random_points_gen.Generator // start point and sobol or halton sequence with samples points when not set
+	clustering  Clustering
+	localSolver many_dimension_search.Solver
+	settings    many_dimension_search.Settings
+	minima      minimaSet // distinct minima found by last solve
 }
 
 func (kmm *KMeansMultistart) Init(startPoint []float64, delta float64, dimension int,
@@ -26,89 +48,227 @@ func (kmm *KMeansMultistart) Init(startPoint []float64, delta float64, dimension
 	kmm.dimension = dimension
 	kmm.alpha = alpha
 	kmm.beta = beta
-	kmm.generator.Init(startPoint, []float64{1, 5, 3}, []float64{9, 2, 1}, beta, n)
+	kmm.samples = defaultSamples
+	kmm.generator = nil
+	kmm.clustering = SingleLinkage
+	kmm.localSolver = &many_dimension_search.NelderMeadSearch{}
+	kmm.settings = many_dimension_search.DefaultSettings()
+	kmm.minima.tolerance = minimaTolerance
+}
+
+func (kmm *KMeansMultistart) SetTolerance(tolerance float64) {
+	kmm.minima.tolerance = tolerance
+}
+
+func (kmm *KMeansMultistart) SetGenerator(generator random_points_gen.Generator) {
+	kmm.generator = generator
+}
+
+func (kmm *KMeansMultistart) SetSamples(samples int) {
+	kmm.samples = samples
+}
+
+func (kmm *KMeansMultistart) SetClustering(clustering Clustering) {
+	kmm.clustering = clustering
+}
+
+func (kmm *KMeansMultistart) SetLocalSolver(solver many_dimension_search.Solver, settings many_dimension_search.Settings) {
+	kmm.localSolver = solver
+	kmm.settings = settings
+}
+
+func (kmm *KMeansMultistart) Minima() []LocalMinimum {
+	return kmm.minima.sorted()
 }
 
 func (kmm *KMeansMultistart) Solve() ([]float64, float64, error) {
+	return kmm.SolveContext(context.Background())
+}
+
+func (kmm *KMeansMultistart) SolveContext(ctx context.Context) ([]float64, float64, error) {
 	var err error
-	var clustersLen = n
-	var vectors = make([]la_methods.Vector, clustersLen)
-	points := kmm.generator.Generate()
+	kmm.minima.reset()
+	points, err := kmm.generate()
+	if err != nil {
+		return nil, 0, fmt.Errorf("error generating points: %w", err)
+	}
+	var vectors = make([]la_methods.Vector, len(points))
+	var values = make([]float64, len(points))
 	for i, p := range points {
-		vectors[i] = la_methods.Vector{
-			Points:    p,
-			Dimension: kmm.dimension,
+		err = vectors[i].InitWithPoints(kmm.dimension, p)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error initing vector: %w", err)
 		}
+		values[i] = kmm.targetFunc(p)
 	}
 
-	var clusterMin = make([]float64, clustersLen)
-	var clusterMinV = make([]la_methods.Vector, clustersLen)
-	var clusters = make([][]la_methods.Vector, clustersLen)
-	for i := 0; i < clustersLen; i++ {
-		clusters[i] = append(clusters[i], vectors[i])
-		clusterMinV[i] = vectors[i]
-		clusterMin[i] = kmm.targetFunc(vectors[i].Points)
+	var starts []la_methods.Vector
+	var basins []int
+	switch kmm.clustering {
+	case SingleLinkage:
+		starts, basins, err = kmm.singleLinkage(vectors, values)
+	case MultiLevelSingleLinkage:
+		starts, basins, err = kmm.multiLevelSingleLinkage(vectors, values)
+	default:
+		err = fmt.Errorf("unknown clustering: %d", kmm.clustering)
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("error clustering points: %w", err)
 	}
 
-	//fmt.Println(clusterMin)
-
-	for clustersLen != 1 {
-		minDist := float64(1000000000)
-		var dist float64
-		var minI1, minI2 int
-		for i := 0; i < clustersLen; i++ {
-			for j := i + 1; j < clustersLen; j++ {
-
-				len1 := len(clusters[i])
-				len2 := len(clusters[j])
-				minDistInner := float64(1000000000)
-				for k := 0; k < len1; k++ {
-					for r := 0; r < len2; r++ {
-						dist, err = clusters[i][k].EqDist(clusters[j][r])
-						if err != nil {
-							return nil, 0, fmt.Errorf("error caluclating dist: %v", err)
-						}
-						if dist < minDistInner {
-							minDistInner = dist
-						}
-					}
-				}
+	bounds := kmm.bounds()
+	for i, start := range starts {
+		if ctx.Err() != nil {
+			break
+		}
+		problem := many_dimension_search.Problem{
+			TargetFunc: kmm.targetFunc,
+			Dimension:  kmm.dimension,
+			StartPoint: start.Points,
+			Bounds:     bounds,
+		}
+		result, err := kmm.localSolver.SolveProblem(ctx, problem, kmm.settings)
+		if errors.Is(err, optimization_errors.ErrBoundsUnsupported) {
+			bounds = many_dimension_search.Bounds{} // unconstrained local solver
+			problem.Bounds = bounds
+			result, err = kmm.localSolver.SolveProblem(ctx, problem, kmm.settings)
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("error solving local problem: %w", err)
+		}
+		err = kmm.minima.add(result.X, result.F, 1, basins[i])
+		if err != nil {
+			return nil, 0, fmt.Errorf("error adding minimum: %w", err)
+		}
+	}
+	minima := kmm.minima.sorted()
+	if len(minima) == 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, 0, err
+		}
+		return nil, 0, fmt.Errorf("no local minima found")
+	}
+	return minima[0].X, minima[0].F, nil
+}
+
+func (kmm *KMeansMultistart) bounds() many_dimension_search.Bounds {
+	lower := make([]float64, kmm.dimension)
+	upper := make([]float64, kmm.dimension)
+	for i := 0; i < kmm.dimension; i++ {
+		lower[i], upper[i] = kmm.alpha, kmm.beta
+	}
+	return many_dimension_search.Bounds{Lower: lower, Upper: upper}
+}
+
+func (kmm *KMeansMultistart) generate() ([][]float64, error) {
+	var points [][]float64
+	if kmm.generator != nil {
+		points = kmm.generator.Generate()
+	} else {
+		var generator random_points_gen.BoxGenerator = &random_points_gen.SobolGen{}
+		if kmm.dimension > random_points_gen.SobolMaxDimension {
+			generator = &random_points_gen.HaltonGen{}
+		}
+		bounds := kmm.bounds()
+		err := generator.Init(bounds.Lower, bounds.Upper, kmm.samples)
+		if err != nil {
+			return nil, fmt.Errorf("error initializing default generator: %w", err)
+		}
+		points = append([][]float64{kmm.startPoint}, generator.Generate()...)
+	}
+	if len(points) == 0 {
+		return nil, fmt.Errorf("no start points generated")
+	}
+	for _, p := range points {
+		if len(p) != kmm.dimension {
+			return nil, fmt.Errorf("wrong generated point length: %d", len(p))
+		}
+	}
+	return points, nil
+}
 
-				if dist < minDist {
-					minDist = dist
-					minI1 = i
-					minI2 = j
+func (kmm *KMeansMultistart) singleLinkage(vectors []la_methods.Vector, values []float64) ([]la_methods.Vector, []int, error) {
+	var starts []la_methods.Vector
+	var basins []int
+	clustered := make([]bool, len(vectors))
+	for i := range vectors {
+		if clustered[i] {
+			continue
+		}
+		clustered[i] = true
+		best, size := i, 1
+		queue := []int{i}
+		for len(queue) != 0 {
+			current := queue[0]
+			queue = queue[1:]
+			for j := range vectors {
+				if clustered[j] {
+					continue
+				}
+				dist, err := vectors[current].EqDist(vectors[j])
+				if err != nil {
+					return nil, nil, fmt.Errorf("error caluclating dist: %w", err)
+				}
+				if dist < kmm.delta {
+					clustered[j] = true
+					size++
+					queue = append(queue, j)
+					if values[j] < values[best] {
+						best = j
+					}
 				}
 			}
 		}
-		var newClusters [][]la_methods.Vector
-		var newClustersMin []float64
-		var newClustersMinV []la_methods.Vector
-		if minDist < kmm.delta {
-			clustersLen--
-			for i, c := range clusters {
-				if i != minI2 && i != minI1 {
-					newClusters = append(newClusters, c)
-					newClustersMin = append(newClustersMin, clusterMin[i])
-					newClustersMinV = append(newClustersMinV, clusterMinV[i])
-				}
+		starts = append(starts, vectors[best])
+		basins = append(basins, size)
+	}
+	return starts, basins, nil
+}
+
+func (kmm *KMeansMultistart) multiLevelSingleLinkage(vectors []la_methods.Vector, values []float64) ([]la_methods.Vector, []int, error) {
+	order := make([]int, len(vectors))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool { return values[order[i]] < values[order[j]] })
+	reduced := int(math.Ceil(reducedSample * float64(len(order))))
+	order = order[:reduced]
+	radius := kmm.criticalDistance(vectors)
+	var starts []la_methods.Vector
+	var basins []int
+	root := make(map[int]int) // reduced sample point to its start index
+	for k, i := range order {
+		root[i] = len(starts)
+		for _, j := range order[:k] { // better points
+			dist, err := vectors[i].EqDist(vectors[j])
+			if err != nil {
+				return nil, nil, fmt.Errorf("error caluclating dist: %w", err)
 			}
-			var newCluster []la_methods.Vector
-			newCluster = clusters[minI1]
-			newCluster = append(newCluster, clusters[minI2]...)
-			if clusterMin[minI1] < clusterMin[minI2] {
-				newClustersMin = append(newClustersMin, clusterMin[minI1])
-				newClustersMinV = append(newClustersMinV, clusterMinV[minI1])
-			} else {
-				newClustersMin = append(newClustersMin, clusterMin[minI2])
-				newClustersMinV = append(newClustersMinV, clusterMinV[minI2])
+			if values[j] < values[i] && dist <= radius {
+				root[i] = root[j]
+				break
 			}
-			newClusters = append(newClusters, newCluster)
 		}
-		clusters = newClusters
-		clusterMinV = newClustersMinV
-		clusterMin = newClustersMin
+		if root[i] == len(starts) {
+			starts = append(starts, vectors[i])
+			basins = append(basins, 0)
+		}
+		basins[root[i]]++
 	}
+	return starts, basins, nil
+}
 
-	return clusterMinV[0].Points, clusterMin[0], nil
+func (kmm *KMeansMultistart) criticalDistance(vectors []la_methods.Vector) float64 {
+	measure := 1.0 // sample bounding box volume
+	for j := 0; j < kmm.dimension; j++ {
+		lower, upper := vectors[0].Points[j], vectors[0].Points[j]
+		for _, v := range vectors {
+			lower = math.Min(lower, v.Points[j])
+			upper = math.Max(upper, v.Points[j])
+		}
+		measure *= upper - lower
+	}
+	samples := float64(len(vectors))
+	dimension := float64(kmm.dimension)
+	return math.Pow(math.Gamma(1+dimension/2)*measure*mlslSigma*math.Log(samples)/samples, 1/dimension) / math.Sqrt(math.Pi)
 }
@@ -0,0 +1,148 @@
+package many_criteria_optimization
+
+import (
+	"context"
+	"errors"
+	"github.com/saskamegaprogrammist/optimization_methods/many_dimension_search"
+	"github.com/saskamegaprogrammist/optimization_methods/random_points_gen"
+	"github.com/saskamegaprogrammist/optimization_methods/test_functions"
+	"math"
+	"testing"
+)
+
+var himmelblauMinima = [][]float64{{3, 2}, {-2.805118, 3.131312}, {-3.779310, -3.283186}, {3.584428, -1.848126}}
+
+func himmelblau(xs []float64) float64 {
+	return math.Pow(xs[0]*xs[0]+xs[1]-11, 2) + math.Pow(xs[0]+xs[1]*xs[1]-7, 2)
+}
+
+func nearestDistance(x []float64, points [][]float64) float64 {
+	nearest := math.Inf(1)
+	for _, p := range points {
+		nearest = math.Min(nearest, math.Hypot(x[0]-p[0], x[1]-p[1]))
+	}
+	return nearest
+}
+
+func TestKMeansGlobalMinimum(t *testing.T) {
+	for _, clustering := range []Clustering{SingleLinkage, MultiLevelSingleLinkage} {
+		var kmm KMeansMultistart
+		kmm.Init([]float64{4.5, 4.5}, 0.5, 2, -5.12, 5.12, test_functions.Rastrigin)
+		kmm.SetSamples(256)
+		kmm.SetClustering(clustering)
+		x, f, err := kmm.Solve()
+		if err != nil {
+			t.Fatalf("clustering %d: error during k means multistart: %v", clustering, err)
+		}
+		if f > 1e-4 || math.Hypot(x[0], x[1]) > 1e-2 {
+			t.Errorf("clustering %d: expected global minimum at [0 0], got %v, %g", clustering, x, f)
+		}
+	}
+}
+
+func TestKMeansDefaultSamples(t *testing.T) {
+	var kmm KMeansMultistart
+	kmm.Init([]float64{0, 0}, 1e-9, 2, -5, 5, himmelblau)
+	var cheap many_dimension_search.NelderMeadSearch
+	settings := many_dimension_search.DefaultSettings()
+	settings.MaxIter = 1
+	kmm.SetLocalSolver(&cheap, settings)
+	for _, samples := range []int{0, 50} {
+		if samples > 0 {
+			kmm.SetSamples(samples)
+		}
+		_, _, err := kmm.Solve()
+		if err != nil {
+			t.Fatalf("error during k means multistart: %v", err)
+		}
+		expected := defaultSamples + 1
+		if samples > 0 {
+			expected = samples + 1
+		}
+		basins := 0
+		for _, m := range kmm.Minima() {
+			basins += m.BasinSize
+		}
+		if basins != expected {
+			t.Errorf("expected %d sample points with start point, got %d", expected, basins)
+		}
+	}
+}
+
+func TestKMeansHighDimension(t *testing.T) {
+	dimension := random_points_gen.SobolMaxDimension + 4
+	var kmm KMeansMultistart
+	kmm.Init(make([]float64, dimension), 1e-9, dimension, -1, 1, test_functions.Sphere)
+	var cheap many_dimension_search.NelderMeadSearch
+	settings := many_dimension_search.DefaultSettings()
+	settings.MaxIter = 1
+	kmm.SetLocalSolver(&cheap, settings)
+	if _, _, err := kmm.Solve(); err != nil {
+		t.Fatalf("error during k means multistart: %v", err)
+	}
+}
+
+func TestKMeansStaysInBounds(t *testing.T) {
+	shifted := func(xs []float64) float64 {
+		return math.Pow(xs[0]-3, 2) + math.Pow(xs[1]+3, 2)
+	}
+	var kmm KMeansMultistart
+	kmm.Init([]float64{0, 0}, 0.5, 2, -1, 1, shifted)
+	x, _, err := kmm.Solve()
+	if err != nil {
+		t.Fatalf("error during k means multistart: %v", err)
+	}
+	if nearestDistance(x, [][]float64{{1, -1}}) > 1e-3 {
+		t.Errorf("expected minimum at box corner [1 -1], got %v", x)
+	}
+	for _, m := range kmm.Minima() {
+		if math.Abs(m.X[0]) > 1 || math.Abs(m.X[1]) > 1 {
+			t.Errorf("minimum %v is outside sampled box", m.X)
+		}
+	}
+}
+
+func TestKMeansCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var kmm KMeansMultistart
+	kmm.Init([]float64{0, 0}, 1, 2, -5, 5, himmelblau)
+	if _, _, err := kmm.SolveContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected cancelled context error, got %v", err)
+	}
+}
+
+func TestKMeansLocalSolver(t *testing.T) {
+	var kmm KMeansMultistart
+	kmm.Init([]float64{0, 0}, 1, 2, -5, 5, himmelblau)
+	kmm.SetSamples(64)
+	var bfgs many_dimension_search.BFGSSearch
+	settings := many_dimension_search.DefaultSettings()
+	settings.Eps1 = 1e-8
+	kmm.SetLocalSolver(&bfgs, settings)
+	_, f, err := kmm.Solve()
+	if err != nil {
+		t.Fatalf("error during k means multistart: %v", err)
+	}
+	if f > 1e-8 {
+		t.Errorf("global minimum isn't found: %g", f)
+	}
+	for _, m := range kmm.Minima() {
+		if m.F < 1e-6 && nearestDistance(m.X, himmelblauMinima) > 1e-3 {
+			t.Errorf("unknown minimum %v", m.X)
+		}
+	}
+}
+
+func TestKMeansWrongParameters(t *testing.T) {
+	var kmm KMeansMultistart
+	kmm.Init([]float64{0, 0}, 1, 2, -5, 5, himmelblau)
+	kmm.SetClustering(Clustering(10))
+	if _, _, err := kmm.Solve(); err == nil {
+		t.Errorf("unknown clustering is accepted")
+	}
+	kmm.Init([]float64{0, 0}, 1, 2, 5, -5, himmelblau)
+	if _, _, err := kmm.Solve(); err == nil {
+		t.Errorf("wrong bounds are accepted")
+	}
+}
@@ -0,0 +1,64 @@
+package many_criteria_optimization
+
+import (
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"sort"
+)
+
+const minimaTolerance = 0.1 // default distance between distinct minima
+
+type LocalMinimum struct {
+	X         []float64
+	F         float64
+	Starts    int // local searches converged into minimum
+	BasinSize int // sample points attributed to minimum basin
+}
+
+type minimaSet struct {
+	tolerance float64
+	minima    []LocalMinimum
+}
+
+func (ms *minimaSet) reset() {
+	ms.minima = nil
+}
+
+func (ms *minimaSet) add(x []float64, f float64, starts int, basinSize int) error {
+	var v la_methods.Vector
+	err := v.InitWithPoints(len(x), x)
+	if err != nil {
+		return fmt.Errorf("error initing vector: %w", err)
+	}
+	for i := range ms.minima {
+		var m la_methods.Vector
+		err = m.InitWithPoints(len(ms.minima[i].X), ms.minima[i].X)
+		if err != nil {
+			return fmt.Errorf("error initing vector: %w", err)
+		}
+		dist, err := v.EqDist(m)
+		if err != nil {
+			return fmt.Errorf("error caluclating dist: %w", err)
+		}
+		if dist < ms.tolerance {
+			ms.minima[i].Starts += starts
+			ms.minima[i].BasinSize += basinSize
+			if f < ms.minima[i].F {
+				ms.minima[i].X, ms.minima[i].F = x, f
+			}
+			return nil
+		}
+	}
+	ms.minima = append(ms.minima, LocalMinimum{X: x, F: f, Starts: starts, BasinSize: basinSize})
+	return nil
+}
+
+func (ms *minimaSet) sorted() []LocalMinimum {
+	sort.SliceStable(ms.minima, func(i, j int) bool { return ms.minima[i].F < ms.minima[j].F })
+	minima := make([]LocalMinimum, len(ms.minima))
+	for i, m := range ms.minima {
+		minima[i] = m
+		minima[i].X = append([]float64{}, m.X...)
+	}
+	return minima
+}
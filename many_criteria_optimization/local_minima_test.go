@@ -0,0 +1,97 @@
+package many_criteria_optimization
+
+import (
+	"github.com/saskamegaprogrammist/optimization_methods/test_functions"
+	"testing"
+)
+
+func TestKMeansHimmelblauMinima(t *testing.T) {
+	var kmm KMeansMultistart
+	kmm.Init([]float64{0, 0}, 1, 2, -5, 5, himmelblau)
+	kmm.SetSamples(128)
+	_, _, err := kmm.Solve()
+	if err != nil {
+		t.Fatalf("error during k means multistart: %v", err)
+	}
+	minima := kmm.Minima()
+	found := 0
+	for i, m := range minima {
+		if i > 0 && m.F < minima[i-1].F {
+			t.Errorf("minima aren't sorted: %g after %g", m.F, minima[i-1].F)
+		}
+		if m.F < 1e-3 && nearestDistance(m.X, himmelblauMinima) < 0.01 {
+			found++
+		}
+		if m.Starts < 1 {
+			t.Errorf("minimum %v has no starts", m.X)
+		}
+	}
+	if found != len(himmelblauMinima) {
+		t.Errorf("expected %d global minima, found %d: %v", len(himmelblauMinima), found, minima)
+	}
+}
+
+func TestCompetitivePointsBasinSizes(t *testing.T) {
+	var cpm CompetitivePointsMultistart
+	cpm.Init(2, -5.12, 5.12, test_functions.Rastrigin)
+	cpm.SetSamples(40)
+	cpm.SetSeed(3)
+	x, f, err := cpm.Solve()
+	if err != nil {
+		t.Fatalf("error during competitive points multistart: %v", err)
+	}
+	minima := cpm.Minima()
+	basins, starts := 0, 0
+	for _, m := range minima {
+		basins += m.BasinSize
+		starts += m.Starts
+	}
+	if basins != 40 {
+		t.Errorf("basin sizes sum to %d, expected 40 sample points", basins)
+	}
+	if starts != 40 {
+		t.Errorf("starts sum to %d, expected one start per sample point", starts)
+	}
+	if minima[0].F != f || minima[0].X[0] != x[0] || minima[0].X[1] != x[1] {
+		t.Errorf("best minimum %v, %g isn't solve result %v, %g", minima[0].X, minima[0].F, x, f)
+	}
+}
+
+func TestMinimaAreCopies(t *testing.T) {
+	var cpm CompetitivePointsMultistart
+	cpm.Init(2, -5, 5, himmelblau)
+	cpm.SetSamples(10)
+	cpm.SetSeed(1)
+	_, _, err := cpm.Solve()
+	if err != nil {
+		t.Fatalf("error during competitive points multistart: %v", err)
+	}
+	minima := cpm.Minima()
+	x0, f0 := minima[0].X[0], minima[0].F
+	minima[0].X[0] = 1000
+	minima[0].F = -1
+	again := cpm.Minima()
+	if again[0].X[0] != x0 || again[0].F != f0 {
+		t.Errorf("minima are changed through returned slice: %v", again[0])
+	}
+}
+
+func TestMinimaSetMerges(t *testing.T) {
+	ms := minimaSet{tolerance: 0.1}
+	points := []struct {
+		x []float64
+		f float64
+	}{{[]float64{1, 1}, 2}, {[]float64{1.05, 1}, 1}, {[]float64{3, 3}, 0.5}}
+	for _, p := range points {
+		if err := ms.add(p.x, p.f, 1, 2); err != nil {
+			t.Fatalf("error adding minimum: %v", err)
+		}
+	}
+	minima := ms.sorted()
+	if len(minima) != 2 {
+		t.Fatalf("expected 2 distinct minima, got %d", len(minima))
+	}
+	if minima[1].X[0] != 1.05 || minima[1].F != 1 || minima[1].Starts != 2 || minima[1].BasinSize != 4 {
+		t.Errorf("close minima aren't merged: %+v", minima[1])
+	}
+}
@@ -0,0 +1,178 @@
+package many_dimension_search
+
+import (
+	"context"
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"github.com/saskamegaprogrammist/optimization_methods/line_search"
+	"math"
+	"time"
+)
+
+type BFGSSearch struct {
+	startPoint     []float64
+	eps1           float64
+	eps2           float64
+	delta          float64
+	alphaPrecision float64
+	dimension      int
+	targetFunc     func(xs []float64) float64
+	gradient       []func(xs []float64) float64
+	lineSearch     line_search.LineSearch
+	maxIter        int
+	solverStats
+}
+
+func (bfgs *BFGSSearch) Init(startPoint []float64, delta float64, dimension int,
+	eps1 float64, eps2 float64, alphaPrecision float64,
+	maxIter int, targetFunc func(xs []float64) float64, gradient []func(xs []float64) float64,
+	lineSearch line_search.LineSearch) {
+	bfgs.startPoint = startPoint
+	bfgs.delta = delta
+	bfgs.eps1 = eps1
+	bfgs.eps2 = eps2
+	bfgs.targetFunc = bfgs.countFunc(targetFunc)
+	bfgs.dimension = dimension
+	bfgs.lineSearch = lineSearch
+	bfgs.alphaPrecision = alphaPrecision
+	bfgs.maxIter = maxIter
+	bfgs.gradient = bfgs.countGradient(numericalGradient(targetFunc, gradient, dimension))
+}
+
+func (bfgs *BFGSSearch) SolveProblem(ctx context.Context, problem Problem, settings Settings) (Result, error) {
+	err := problem.check()
+	if err != nil {
+		return Result{}, fmt.Errorf("error checking problem: %w", err)
+	}
+	timeStart := time.Now()
+	bfgs.Init(problem.StartPoint, settings.Delta, problem.Dimension, settings.Eps1, settings.Eps2,
+		settings.AlphaPrecision, settings.MaxIter, problem.TargetFunc, problem.Gradient,
+		settings.LineSearch)
+	bfgs.SetObserver(settings.Observer)
+	bfgs.SetLimits(Limits{MaxIterations: settings.MaxIter, MaxFuncEvaluations: settings.MaxFuncEvals, MaxTime: settings.MaxTime})
+	xMin, yMin, err := bfgs.SolveContext(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	return bfgs.result(xMin, yMin, timeStart), nil
+}
+
+func (bfgs *BFGSSearch) Solve() ([]float64, float64, error) {
+	return bfgs.SolveContext(context.Background())
+}
+
+func (bfgs *BFGSSearch) SolveContext(ctx context.Context) ([]float64, float64, error) {
+	var err error
+	var H la_methods.Matrix
+	var x, xOld, xSub la_methods.Vector
+	var grad, gradOld, gradSub, d la_methods.Vector
+	var alpha, f, fOld float64
+	var k int
+	var lastIter, scaled bool
+	err = checkDerivatives(bfgs.targetFunc, bfgs.gradient, nil, bfgs.startPoint)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error checking derivatives: %w", err)
+	}
+	bfgs.reset(ctx, bfgs.startPoint)
+	if bfgs.lineSearch == nil {
+		return []float64{}, 0, fmt.Errorf("one dimensional method is not set")
+	}
+	alpha = bfgs.alphaPrecision
+	err = x.InitWithPoints(bfgs.dimension, bfgs.startPoint)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error during vector initializing: %w", err)
+	}
+	H.Init(bfgs.dimension, bfgs.dimension)
+	H.E()
+	grad, err = calculateGradient(bfgs.gradient, x)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error calculcating gradient: %w", err)
+	}
+	f = bfgs.targetFunc(x.Points)
+	for {
+		if bfgs.interrupted(k) {
+			return bfgs.bestPoint()
+		}
+		if grad.Len() < bfgs.eps1 {
+			bfgs.finish(k, GradientConverged)
+			return x.Points, f, nil
+		}
+		if k >= bfgs.maxIter {
+			bfgs.finish(k, MaxIterationsReached)
+			return x.Points, f, nil
+		}
+		d, err = H.MulV(grad.MulOnValue(-1))
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during vector and matrix multiplying: %w", err)
+		}
+		alpha, err = bfgs.search(bfgs.lineSearch, getOneDimensionFunc(bfgs.targetFunc, d, x),
+			getOneDimensionDerivative(bfgs.gradient, d, x), alpha)
+		if err != nil {
+			if bfgs.interrupted(k) {
+				return bfgs.bestPoint()
+			}
+			return nil, 0, fmt.Errorf("error during one dimension search: %w", err)
+		}
+		xOld = x
+		x, err = x.Add(d.MulOnValue(alpha))
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during vector adding: %w", err)
+		}
+		gradOld = grad
+		grad, err = calculateGradient(bfgs.gradient, x)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error calculcating gradient: %w", err)
+		}
+		xSub, err = x.Sub(xOld)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during vector substracting: %w", err)
+		}
+		gradSub, err = grad.Sub(gradOld)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during vector substracting: %w", err)
+		}
+		scaled, err = bfgs.updateH(&H, xSub, gradSub, scaled)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error calculating matrix: %w", err)
+		}
+		fOld, f = f, bfgs.targetFunc(x.Points)
+		if xSub.Len() < bfgs.delta && math.Abs(f-fOld) < bfgs.eps2 {
+			if lastIter {
+				bfgs.finish(k, StepConverged)
+				return x.Points, f, nil
+			} else {
+				lastIter = true
+			}
+		}
+		bfgs.notify(k, x.Points, f, grad.Len(), xSub.Len())
+		k++
+	}
+}
+
+func (bfgs *BFGSSearch) updateH(H *la_methods.Matrix, s la_methods.Vector, y la_methods.Vector, scaled bool) (bool, error) {
+	sy, err := s.Mul(y)
+	if err != nil {
+		return scaled, fmt.Errorf("error during vector multiplying: %w", err)
+	}
+	if sy <= 0 {
+		return scaled, nil // curvature condition failed, update would lose positive definiteness
+	}
+	if !scaled {
+		yy, _ := y.Mul(y)
+		H.E()
+		*H = H.MulVal(sy / yy)
+	}
+	Hy, err := H.MulV(y)
+	if err != nil {
+		return scaled, fmt.Errorf("error during matrix and vector multiplying: %w", err)
+	}
+	yHy, _ := y.Mul(Hy)
+	rho := 1 / sy
+	ss := rho*rho*yHy + rho
+	for i := 0; i < bfgs.dimension; i++ {
+		for j := 0; j < bfgs.dimension; j++ {
+			H.Points[i][j] += ss*s.Points[i]*s.Points[j] - rho*(s.Points[i]*Hy.Points[j]+Hy.Points[i]*s.Points[j])
+		}
+	}
+	return true, nil
+}
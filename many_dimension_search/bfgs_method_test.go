@@ -0,0 +1,42 @@
+package many_dimension_search
+
+import (
+	"github.com/saskamegaprogrammist/optimization_methods/line_search"
+	"github.com/saskamegaprogrammist/optimization_methods/test_functions"
+	"testing"
+)
+
+func TestBFGSRosenbrock(t *testing.T) {
+	var mt line_search.MoreThuenteSearch
+	mt.Init(1, 0.0001, 0.9, 1e-10)
+	for _, gradient := range [][]func(xs []float64) float64{test_functions.RosenbrockGradient(2), nil} {
+		var bfgs BFGSSearch
+		bfgs.Init([]float64{-1.2, 1}, 1e-10, 2, 1e-8, 1e-12, 1, 1000, test_functions.Rosenbrock, gradient, &mt)
+		x, f, err := bfgs.Solve()
+		if err != nil {
+			t.Fatalf("error during bfgs search: %v", err)
+		}
+		if distanceTo(x, []float64{1, 1}) > 1e-4 || f > 1e-8 {
+			t.Errorf("expected minimum at [1 1], got %v, %g", x, f)
+		}
+		if bfgs.Reason() != GradientConverged && bfgs.Reason() != StepConverged {
+			t.Errorf("unexpected termination reason: %s", bfgs.Reason())
+		}
+	}
+}
+
+func TestLBFGSRosenbrock(t *testing.T) {
+	var mt line_search.MoreThuenteSearch
+	mt.Init(1, 0.0001, 0.9, 1e-10)
+	for _, history := range []int{1, 5, 10} {
+		var lbfgs LBFGSSearch
+		lbfgs.Init([]float64{-1.2, 1}, 1e-10, 2, 1e-8, 1e-12, 1, 1000, test_functions.Rosenbrock, test_functions.RosenbrockGradient(2), &mt, history)
+		x, f, err := lbfgs.Solve()
+		if err != nil {
+			t.Fatalf("error during l-bfgs search with history %d: %v", history, err)
+		}
+		if distanceTo(x, []float64{1, 1}) > 1e-4 || f > 1e-8 {
+			t.Errorf("history %d: expected minimum at [1 1], got %v, %g", history, x, f)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package many_dimension_search
+
+import (
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
+	"math"
+)
+
+const activeTolerance = 1e-10
+
+type BoundsHandling int
+
+const (
+	Projection BoundsHandling = iota
+	Reflection
+)
+
+type Bounds struct {
+	Lower    []float64 // nil means no bound, infinite values are allowed
+	Upper    []float64
+	Handling BoundsHandling
+}
+
+func (b Bounds) IsSet() bool {
+	return b.Lower != nil || b.Upper != nil
+}
+
+func (b Bounds) check(dimension int) error {
+	if b.Lower != nil && len(b.Lower) != dimension {
+		return fmt.Errorf("lower bounds length: %w", &optimization_errors.DimensionError{Expected: dimension, Actual: len(b.Lower)})
+	}
+	if b.Upper != nil && len(b.Upper) != dimension {
+		return fmt.Errorf("upper bounds length: %w", &optimization_errors.DimensionError{Expected: dimension, Actual: len(b.Upper)})
+	}
+	for i := 0; i < dimension; i++ {
+		if b.lower(i) > b.upper(i) {
+			return fmt.Errorf("%w: lower bound %f is greater than upper bound %f", optimization_errors.ErrInfeasible, b.lower(i), b.upper(i))
+		}
+	}
+	return nil
+}
+
+func (b Bounds) lower(i int) float64 {
+	if b.Lower == nil {
+		return math.Inf(-1)
+	}
+	return b.Lower[i]
+}
+
+func (b Bounds) upper(i int) float64 {
+	if b.Upper == nil {
+		return math.Inf(1)
+	}
+	return b.Upper[i]
+}
+
+func (b Bounds) contains(x la_methods.Vector) bool {
+	for i, p := range x.Points {
+		if p < b.lower(i) || p > b.upper(i) {
+			return false
+		}
+	}
+	return true
+}
+
+func (b Bounds) apply(x la_methods.Vector) la_methods.Vector {
+	if !b.IsSet() || b.contains(x) {
+		return x
+	}
+	y := x.Copy()
+	for i, p := range y.Points {
+		lower, upper := b.lower(i), b.upper(i)
+		if b.Handling == Reflection {
+			if p < lower {
+				p = 2*lower - p
+			} else if p > upper {
+				p = 2*upper - p
+			}
+		}
+		y.Points[i] = math.Min(math.Max(p, lower), upper) // reflected point can cross the opposite bound
+	}
+	return y
+}
+
+func (b Bounds) project(x la_methods.Vector) la_methods.Vector {
+	b.Handling = Projection
+	return b.apply(x)
+}
+
+func (b Bounds) projectedGradient(x la_methods.Vector, grad la_methods.Vector) (la_methods.Vector, error) {
+	xStep, err := x.Sub(grad)
+	if err != nil {
+		return la_methods.Vector{}, fmt.Errorf("error during vector substracting: %w", err)
+	}
+	xProjected := b.project(xStep)
+	return xProjected.Sub(x)
+}
+
+func (b Bounds) freeVariables(x la_methods.Vector, grad la_methods.Vector) []bool {
+	free := make([]bool, len(x.Points))
+	for i, p := range x.Points {
+		atLower := p <= b.lower(i)+activeTolerance*math.Max(math.Abs(p), 1) && grad.Points[i] > 0
+		atUpper := p >= b.upper(i)-activeTolerance*math.Max(math.Abs(p), 1) && grad.Points[i] < 0
+		free[i] = !atLower && !atUpper
+	}
+	return free
+}
+
+func (b Bounds) applyFunc(targetFunc func(xs []float64) float64) func(xs []float64) float64 {
+	if !b.IsSet() {
+		return targetFunc
+	}
+	return func(xs []float64) float64 {
+		var x la_methods.Vector
+		_ = x.InitWithPoints(len(xs), xs)
+		return targetFunc(b.apply(x).Points)
+	}
+}
@@ -0,0 +1,75 @@
+package many_dimension_search
+
+import (
+	"context"
+	"errors"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
+	"testing"
+)
+
+func boundedSolvers() map[string]Solver {
+	return map[string]Solver{
+		"nelder mead":  &NelderMeadSearch{},
+		"hooke jeeves": &HookeJeevesSearch{},
+	}
+}
+
+func inBounds(x []float64, bounds Bounds) bool {
+	for i := range x {
+		if x[i] < bounds.lower(i) || x[i] > bounds.upper(i) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBoundedSearchesActiveBound(t *testing.T) {
+	for _, handling := range []BoundsHandling{Projection, Reflection} {
+		bounds := Bounds{Lower: []float64{-1, -1}, Upper: []float64{2, 1}, Handling: handling}
+		for name, solver := range boundedSolvers() {
+			var recorder Recorder
+			settings := DefaultSettings()
+			settings.Eps1 = 1e-6
+			settings.MaxIter = 10000
+			settings.Observer = recorder.Observe
+			problem := Problem{TargetFunc: quadratic, Dimension: 2, StartPoint: []float64{0, 0}, Bounds: bounds}
+			result, err := solver.SolveProblem(context.Background(), problem, settings)
+			if err != nil {
+				t.Fatalf("%s: error solving bounded problem: %v", name, err)
+			}
+			if distanceTo(result.X, []float64{1, -1}) > 1e-3 {
+				t.Errorf("%s, handling %d: expected minimum at [1 -1], got %v", name, handling, result.X)
+			}
+			for _, info := range recorder.Trajectory {
+				if !inBounds(info.X, bounds) {
+					t.Fatalf("%s, handling %d: point %v is out of bounds", name, handling, info.X)
+				}
+			}
+		}
+	}
+}
+
+func TestBoundedSearchesStartOutsideBounds(t *testing.T) {
+	bounds := Bounds{Lower: []float64{-1, -1}, Upper: []float64{2, 1}}
+	for name, solver := range boundedSolvers() {
+		problem := Problem{TargetFunc: quadratic, Dimension: 2, StartPoint: []float64{5, 5}, Bounds: bounds}
+		result, err := solver.SolveProblem(context.Background(), problem, DefaultSettings())
+		if err != nil {
+			t.Fatalf("%s: error solving bounded problem: %v", name, err)
+		}
+		if !inBounds(result.X, bounds) {
+			t.Errorf("%s: result %v is out of bounds", name, result.X)
+		}
+	}
+}
+
+func TestBoundsInfeasible(t *testing.T) {
+	bounds := Bounds{Lower: []float64{1, -1}, Upper: []float64{0, 1}}
+	for name, solver := range boundedSolvers() {
+		problem := Problem{TargetFunc: quadratic, Dimension: 2, StartPoint: []float64{0, 0}, Bounds: bounds}
+		_, err := solver.SolveProblem(context.Background(), problem, DefaultSettings())
+		if !errors.Is(err, optimization_errors.ErrInfeasible) {
+			t.Errorf("%s: expected infeasible error, got %v", name, err)
+		}
+	}
+}
@@ -1,11 +1,12 @@
 package many_dimension_search
 
 import (
+	"context"
 	"fmt"
-	"github.com/saskamegaprogrammist/optimization_methods/interpolation_search"
 	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
-	"github.com/saskamegaprogrammist/optimization_methods/one_dimension_search"
+	"github.com/saskamegaprogrammist/optimization_methods/line_search"
 	"math"
+	"time"
 )
 
 type DavidonFletcherPowellSearch struct {
@@ -17,77 +18,106 @@ type DavidonFletcherPowellSearch struct {
 	dimension      int
 	targetFunc     func(xs []float64) float64
 	gradient       []func(xs []float64) float64
-	method         string
-	svennAlgorithm one_dimension_search.Svenn
-	oneDStep       float64
+	lineSearch     line_search.LineSearch
 	maxIter        int
+	solverStats
 }
 
 func (dfps *DavidonFletcherPowellSearch) Init(startPoint []float64, delta float64, dimension int,
 	eps1 float64, eps2 float64, alphaPrecision float64,
-	oneDStep float64, maxIter int, targetFunc func(xs []float64) float64, gradient []func(xs []float64) float64,
-	method string) {
+	maxIter int, targetFunc func(xs []float64) float64, gradient []func(xs []float64) float64,
+	lineSearch line_search.LineSearch) {
 	dfps.startPoint = startPoint
 	dfps.delta = delta
 	dfps.eps1 = eps1
 	dfps.eps2 = eps2
-	dfps.targetFunc = targetFunc
+	dfps.targetFunc = dfps.countFunc(targetFunc)
 	dfps.dimension = dimension
-	dfps.method = method
+	dfps.lineSearch = lineSearch
 	dfps.alphaPrecision = alphaPrecision
-	dfps.oneDStep = oneDStep
 	dfps.maxIter = maxIter
-	dfps.gradient = gradient
+	dfps.gradient = dfps.countGradient(numericalGradient(targetFunc, gradient, dimension))
+}
+
+func (dfps *DavidonFletcherPowellSearch) SolveProblem(ctx context.Context, problem Problem, settings Settings) (Result, error) {
+	err := problem.check()
+	if err != nil {
+		return Result{}, fmt.Errorf("error checking problem: %w", err)
+	}
+	timeStart := time.Now()
+	dfps.Init(problem.StartPoint, settings.Delta, problem.Dimension, settings.Eps1, settings.Eps2,
+		settings.AlphaPrecision, settings.MaxIter, problem.TargetFunc, problem.Gradient,
+		settings.LineSearch)
+	dfps.SetObserver(settings.Observer)
+	dfps.SetLimits(Limits{MaxIterations: settings.MaxIter, MaxFuncEvaluations: settings.MaxFuncEvals, MaxTime: settings.MaxTime})
+	xMin, yMin, err := dfps.SolveContext(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	return dfps.result(xMin, yMin, timeStart), nil
 }
 
 func (dfps *DavidonFletcherPowellSearch) Solve() ([]float64, float64, error) {
+	return dfps.SolveContext(context.Background())
+}
+
+func (dfps *DavidonFletcherPowellSearch) SolveContext(ctx context.Context) ([]float64, float64, error) {
 	var err error
 	var G, GNew la_methods.Matrix
 	var x, xOld, xSub la_methods.Vector
 	var k int
 	var grad, gradOld, gradMinus, d, dInter la_methods.Vector
-	var alpha float64
-	var fib, sqrInterp, lastIter bool
+	var alpha, f, fOld float64
+	var lastIter bool
+	err = checkDerivatives(dfps.targetFunc, dfps.gradient, nil, dfps.startPoint)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error checking derivatives: %w", err)
+	}
+	dfps.reset(ctx, dfps.startPoint)
 	alpha = dfps.alphaPrecision
-	var search one_dimension_search.OneDimensionSearchI
-	if dfps.method == "break in two" {
-		search = &one_dimension_search.BreakInTwoSearch{}
-	} else if dfps.method == "golden ratio" {
-		search = &one_dimension_search.GoldenRatioSearch{}
-	} else if dfps.method == "fibonacci" {
-		fib = true
-	} else if dfps.method == "square interpolation" {
-		sqrInterp = true
-	} else {
-		return []float64{}, 0, fmt.Errorf("wrong one dimensional method: %s", dfps.method)
+	if dfps.lineSearch == nil {
+		return []float64{}, 0, fmt.Errorf("one dimensional method is not set")
 	}
 	alpha = dfps.alphaPrecision
 	err = x.InitWithPoints(dfps.dimension, dfps.startPoint)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error during vector initializing: %v", err)
+		return nil, 0, fmt.Errorf("error during vector initializing: %w", err)
 	}
 	xOld = x.Copy()
+	f = dfps.targetFunc(x.Points)
 	G.Init(dfps.dimension, dfps.dimension)
 	G.E()
 	for {
+		if dfps.interrupted(k) {
+			return dfps.bestPoint()
+		}
 		grad, err = dfps.calculateGradient(x)
 		if err != nil {
-			return nil, 0, fmt.Errorf("error calculcating gradient: %v", err)
+			return nil, 0, fmt.Errorf("error calculcating gradient: %w", err)
 		}
-		if grad.Len() < dfps.eps1 || k >= dfps.maxIter {
+		if grad.Len() < dfps.eps1 {
 			//fmt.Printf("k value: %d\n", k)
-			return x.Points, dfps.targetFunc(x.Points), nil
+			dfps.finish(k, GradientConverged)
+			return x.Points, f, nil
+		}
+		if k >= dfps.maxIter {
+			//fmt.Printf("k value: %d\n", k)
+			dfps.finish(k, MaxIterationsReached)
+			return x.Points, f, nil
 		}
 		if k > 0 {
 			gradOld, err = dfps.calculateGradient(xOld)
 			if err != nil {
-				return nil, 0, fmt.Errorf("error calculcating gradient: %v", err)
+				return nil, 0, fmt.Errorf("error calculcating gradient: %w", err)
 			}
 			xSub, err = x.Sub(xOld)
 			if err != nil {
-				return nil, 0, fmt.Errorf("error during vector substracting: %v", err)
+				return nil, 0, fmt.Errorf("error during vector substracting: %w", err)
 			}
 			GNew, err = dfps.calculateG(grad, gradOld, xSub, G)
+			if err != nil {
+				return nil, 0, fmt.Errorf("error calculating matrix: %w", err)
+			}
 		} else {
 			GNew = G
 		}
@@ -95,34 +125,37 @@ func (dfps *DavidonFletcherPowellSearch) Solve() ([]float64, float64, error) {
 		gradMinus = grad.MulOnValue(-1)
 		d, err = GNew.MulV(gradMinus)
 		if err != nil {
-			return nil, 0, fmt.Errorf("error during vector and matrix multiplying: %v", err)
+			return nil, 0, fmt.Errorf("error during vector and matrix multiplying: %w", err)
 		}
-		if fib {
-			alpha, err = dfps.oneDimensionFibonacciSearch(x, d, alpha)
-		} else if sqrInterp {
-			alpha, err = dfps.oneDimensionSquareInterpolation(x, d, alpha)
-		} else {
-			alpha, err = dfps.oneDimensionSearch(x, d, alpha, search)
+		alpha, err = dfps.search(dfps.lineSearch, getOneDimensionFunc(dfps.targetFunc, d, x),
+			getOneDimensionDerivative(dfps.gradient, d, x), alpha)
+		if err != nil {
+			if dfps.interrupted(k) {
+				return dfps.bestPoint()
+			}
+			return nil, 0, fmt.Errorf("error during one dimension search: %w", err)
 		}
 		dInter = d.MulOnValue(alpha)
 		xOld = x
 		x, err = x.Add(dInter)
 		if err != nil {
-			return nil, 0, fmt.Errorf("error during vector adding: %v", err)
+			return nil, 0, fmt.Errorf("error during vector adding: %w", err)
 		}
 		xSub, err = x.Sub(xOld)
 		if err != nil {
-			return nil, 0, fmt.Errorf("error during vector substracting: %v", err)
+			return nil, 0, fmt.Errorf("error during vector substracting: %w", err)
 		}
-		if xSub.Len() < dfps.delta && math.Abs(dfps.targetFunc(x.Points)-dfps.targetFunc(xOld.Points)) < dfps.eps2 {
+		fOld, f = f, dfps.targetFunc(x.Points)
+		if xSub.Len() < dfps.delta && math.Abs(f-fOld) < dfps.eps2 {
 			if lastIter {
 				//fmt.Printf("k value: %d\n", k)
-				return x.Points, dfps.targetFunc(x.Points), nil
+				dfps.finish(k, StepConverged)
+				return x.Points, f, nil
 			} else {
 				lastIter = true
 			}
 		}
-		//fmt.Println(dfps.targetFunc(x.Points))
+		dfps.notify(k, x.Points, f, grad.Len(), xSub.Len())
 		k++
 	}
 }
@@ -135,54 +168,57 @@ func (dfps *DavidonFletcherPowellSearch) calculateG(gradNew la_methods.Vector, g
 	var gradDelta, gg la_methods.Vector
 	gradDelta, err = gradNew.Sub(gradOld)
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during vector substracting: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during vector substracting: %w", err)
 	}
 	err = deltaXMCol.InitWithVectorColumn(dfps.dimension, dfps.dimension, deltaX)
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during vector initalizing: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during vector initalizing: %w", err)
 	}
 	err = deltaXMRow.InitWithVectorRow(dfps.dimension, dfps.dimension, deltaX)
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during vector initalizing: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during vector initalizing: %w", err)
 	}
 	deltaXdeltaGrad, err = deltaX.Mul(gradDelta)
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during vector multiplying: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during vector multiplying: %w", err)
+	}
+	if deltaXdeltaGrad <= 0 {
+		return G, nil // curvature condition failed, update would lose positive definiteness
 	}
 	deltaX2, err = deltaXMCol.MulM(deltaXMRow)
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during matrix multiplying: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during matrix multiplying: %w", err)
 	}
 	A = deltaX2.MulVal(float64(1) / deltaXdeltaGrad)
 
 	gg, err = G.MulV(gradDelta)
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during matrix and vector multiplying: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during matrix and vector multiplying: %w", err)
 	}
 	err = ggCol.InitWithVectorColumn(dfps.dimension, dfps.dimension, gg)
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during vector initalizing: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during vector initalizing: %w", err)
 	}
 	ggRow, err = ggCol.Transponate()
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during vector transonation: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during vector transonation: %w", err)
 	}
 	ggM, err = ggCol.MulM(ggRow)
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during matrix multiplying: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during matrix multiplying: %w", err)
 	}
 	ggF, err = gradDelta.Mul(gg)
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during vector multiplying: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during vector multiplying: %w", err)
 	}
 	B = ggM.MulVal(float64(-1) / ggF)
 	deltaG, err = A.AddM(B)
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during matrix adding: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during matrix adding: %w", err)
 	}
 	GNew, err = G.AddM(deltaG)
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during matrix adding: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during matrix adding: %w", err)
 	}
 	return GNew, nil
 }
@@ -195,54 +231,7 @@ func (dfps *DavidonFletcherPowellSearch) calculateGradient(x la_methods.Vector)
 	}
 	err := grad.InitWithPoints(dfps.dimension, gradPoints)
 	if err != nil {
-		return la_methods.Vector{}, fmt.Errorf("error during vector initializing: %v", err)
+		return la_methods.Vector{}, fmt.Errorf("error during vector initializing: %w", err)
 	}
 	return grad, nil
 }
-
-func (dfps *DavidonFletcherPowellSearch) findBounds(alpha float64, targetFunc func(x float64) float64) (float64, float64, error) {
-	dfps.svennAlgorithm.Init(dfps.eps1, alpha, targetFunc)
-	return dfps.svennAlgorithm.Solve()
-}
-
-func (dfps *DavidonFletcherPowellSearch) oneDimensionFibonacciSearch(y la_methods.Vector, d la_methods.Vector, alpha float64) (float64, error) {
-	var fib one_dimension_search.FibonacciSearch
-	fOneD := dfps.getOneDimensionFunc(d, y)
-	a, b, err := dfps.findBounds(alpha, fOneD)
-	if err != nil {
-		return 0, fmt.Errorf("error during svenn algorithm: %v", err)
-	}
-	fib.Init(a, b, dfps.oneDStep, dfps.oneDStep, fOneD)
-	min, _, err := fib.Solve()
-	if err != nil {
-		return 0, fmt.Errorf("error during fibonacci search: %v", err)
-	}
-	return min, nil
-}
-
-func (dfps *DavidonFletcherPowellSearch) oneDimensionSquareInterpolation(y la_methods.Vector, d la_methods.Vector, alpha float64) (float64, error) {
-	var sqrInter interpolation_search.SquareInterpolation
-	fOneD := dfps.getOneDimensionFunc(d, y)
-	sqrInter.Init(dfps.alphaPrecision, dfps.oneDStep, dfps.eps1, dfps.eps1, fOneD)
-	min, _ := sqrInter.Solve()
-	return min, nil
-}
-
-func (dfps *DavidonFletcherPowellSearch) oneDimensionSearch(y la_methods.Vector, d la_methods.Vector, alpha float64, search one_dimension_search.OneDimensionSearchI) (float64, error) {
-	fOneD := dfps.getOneDimensionFunc(d, y)
-	a, b, err := dfps.findBounds(alpha, fOneD)
-	if err != nil {
-		return 0, fmt.Errorf("error during svenn algorithm: %v", err)
-	}
-	search.Init(a, b, dfps.oneDStep, fOneD)
-	min, _ := search.Solve()
-	return min, nil
-}
-
-func (dfps *DavidonFletcherPowellSearch) getOneDimensionFunc(d la_methods.Vector, y la_methods.Vector) func(x float64) float64 {
-	return func(x float64) float64 {
-		aplhaD := d.MulOnValue(x)
-		sumYAlphD, _ := y.Add(aplhaD)
-		return dfps.targetFunc(sumYAlphD.Points)
-	}
-}
@@ -0,0 +1,31 @@
+package many_dimension_search
+
+import (
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"github.com/saskamegaprogrammist/optimization_methods/test_functions"
+	"testing"
+)
+
+func TestDebugDetectsWrongGradient(t *testing.T) {
+	wrongGradient := []func(xs []float64) float64{test_functions.RosenbrockGradient(2)[1], test_functions.RosenbrockGradient(2)[0]}
+	Debug = true
+	defer func() { Debug = false }()
+	var bfgs BFGSSearch
+	bfgs.Init([]float64{-1.2, 1}, 1e-10, 2, 1e-8, 1e-12, 1, 1000, test_functions.Rosenbrock, wrongGradient, DefaultSettings().LineSearch)
+	if _, _, err := bfgs.Solve(); err == nil {
+		t.Errorf("wrong gradient isn't detected")
+	}
+	bfgs.Init([]float64{-1.2, 1}, 1e-10, 2, 1e-8, 1e-12, 1, 1000, test_functions.Rosenbrock, test_functions.RosenbrockGradient(2), DefaultSettings().LineSearch)
+	if _, _, err := bfgs.Solve(); err != nil {
+		t.Errorf("correct gradient is rejected: %v", err)
+	}
+	var trs TrustRegionSearch
+	trs.Init([]float64{-1.2, 1}, 2, test_functions.Rosenbrock, test_functions.RosenbrockGradient(2), func(xs []float64) la_methods.Matrix {
+		hessian := test_functions.RosenbrockHessian(xs)
+		hessian.Points[1][1] = 100
+		return hessian
+	}, 1, 100, 1e-8, 1e-12, 1000, Dogleg)
+	if _, _, err := trs.Solve(); err == nil {
+		t.Errorf("wrong hessian isn't detected")
+	}
+}
@@ -1,11 +1,12 @@
 package many_dimension_search
 
 import (
+	"context"
 	"fmt"
-	"github.com/saskamegaprogrammist/optimization_methods/interpolation_search"
 	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
-	"github.com/saskamegaprogrammist/optimization_methods/one_dimension_search"
+	"github.com/saskamegaprogrammist/optimization_methods/line_search"
 	"math"
+	"time"
 )
 
 type FletcherReevesSearch struct {
@@ -17,72 +18,98 @@ type FletcherReevesSearch struct {
 	dimension      int
 	targetFunc     func(xs []float64) float64
 	gradient       []func(xs []float64) float64
-	method         string
-	svennAlgorithm one_dimension_search.Svenn
-	oneDStep       float64
+	lineSearch     line_search.LineSearch
 	maxIter        int
 	pollak         bool
+	solverStats
 }
 
 func (frs *FletcherReevesSearch) Init(startPoint []float64, delta float64, dimension int,
 	eps1 float64, eps2 float64, alphaPrecision float64,
-	oneDStep float64, maxIter int, targetFunc func(xs []float64) float64, gradient []func(xs []float64) float64,
-	method string, pollak bool) {
+	maxIter int, targetFunc func(xs []float64) float64, gradient []func(xs []float64) float64,
+	lineSearch line_search.LineSearch, pollak bool) {
 	frs.startPoint = startPoint
 	frs.delta = delta
 	frs.eps1 = eps1
 	frs.eps2 = eps2
-	frs.targetFunc = targetFunc
+	frs.targetFunc = frs.countFunc(targetFunc)
 	frs.dimension = dimension
-	frs.method = method
+	frs.lineSearch = lineSearch
 	frs.alphaPrecision = alphaPrecision
-	frs.oneDStep = oneDStep
 	frs.maxIter = maxIter
-	frs.gradient = gradient
+	frs.gradient = frs.countGradient(numericalGradient(targetFunc, gradient, dimension))
 	frs.pollak = pollak
 }
 
+func (frs *FletcherReevesSearch) SolveProblem(ctx context.Context, problem Problem, settings Settings) (Result, error) {
+	err := problem.check()
+	if err != nil {
+		return Result{}, fmt.Errorf("error checking problem: %w", err)
+	}
+	timeStart := time.Now()
+	frs.Init(problem.StartPoint, settings.Delta, problem.Dimension, settings.Eps1, settings.Eps2,
+		settings.AlphaPrecision, settings.MaxIter, problem.TargetFunc, problem.Gradient,
+		settings.LineSearch, settings.Pollak)
+	frs.SetObserver(settings.Observer)
+	frs.SetLimits(Limits{MaxIterations: settings.MaxIter, MaxFuncEvaluations: settings.MaxFuncEvals, MaxTime: settings.MaxTime})
+	xMin, yMin, err := frs.SolveContext(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	return frs.result(xMin, yMin, timeStart), nil
+}
+
 func (frs *FletcherReevesSearch) Solve() ([]float64, float64, error) {
+	return frs.SolveContext(context.Background())
+}
+
+func (frs *FletcherReevesSearch) SolveContext(ctx context.Context) ([]float64, float64, error) {
 	var err error
 	var x, xOld, xSub la_methods.Vector
 	var k int
 	var grad, gradOld, gradMinus, d, dNew, dInter la_methods.Vector
-	var alpha, w float64
-	var fib, sqrInterp, lastIter bool
+	var alpha, w, f, fOld float64
+	var lastIter bool
+	err = checkDerivatives(frs.targetFunc, frs.gradient, nil, frs.startPoint)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error checking derivatives: %w", err)
+	}
+	frs.reset(ctx, frs.startPoint)
 	alpha = frs.alphaPrecision
-	var search one_dimension_search.OneDimensionSearchI
-	if frs.method == "break in two" {
-		search = &one_dimension_search.BreakInTwoSearch{}
-	} else if frs.method == "golden ratio" {
-		search = &one_dimension_search.GoldenRatioSearch{}
-	} else if frs.method == "fibonacci" {
-		fib = true
-	} else if frs.method == "square interpolation" {
-		sqrInterp = true
-	} else {
-		return []float64{}, 0, fmt.Errorf("wrong one dimensional method: %s", frs.method)
+	if frs.lineSearch == nil {
+		return []float64{}, 0, fmt.Errorf("one dimensional method is not set")
 	}
 	alpha = frs.alphaPrecision
 	err = x.InitWithPoints(frs.dimension, frs.startPoint)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error during vector initializing: %v", err)
+		return nil, 0, fmt.Errorf("error during vector initializing: %w", err)
 	}
 	xOld = x.Copy()
+	f = frs.targetFunc(x.Points)
 	for {
+		if frs.interrupted(k) {
+			return frs.bestPoint()
+		}
 		grad, err = frs.calculateGradient(x)
 		if err != nil {
-			return nil, 0, fmt.Errorf("error calculcating gradient: %v", err)
+			return nil, 0, fmt.Errorf("error calculcating gradient: %w", err)
+		}
+		if grad.Len() < frs.eps1 {
+			//fmt.Printf("k value: %d\n", k)
+			frs.finish(k, GradientConverged)
+			return x.Points, f, nil
 		}
-		if grad.Len() < frs.eps1 || k >= frs.maxIter {
+		if k >= frs.maxIter {
 			//fmt.Printf("k value: %d\n", k)
-			return x.Points, frs.targetFunc(x.Points), nil
+			frs.finish(k, MaxIterationsReached)
+			return x.Points, f, nil
 		}
 		if k == 0 {
 			d = grad.MulOnValue(-1)
 		}
 		gradOld, err = frs.calculateGradient(xOld)
 		if err != nil {
-			return nil, 0, fmt.Errorf("error calculcating gradient: %v", err)
+			return nil, 0, fmt.Errorf("error calculcating gradient: %w", err)
 		}
 		if frs.pollak {
 			if k%frs.dimension != 0 {
@@ -99,35 +126,41 @@ func (frs *FletcherReevesSearch) Solve() ([]float64, float64, error) {
 		gradMinus = grad.MulOnValue(-1)
 		dNew, err = gradMinus.Add(dInter)
 		if err != nil {
-			return nil, 0, fmt.Errorf("error during vector adding: %v", err)
+			return nil, 0, fmt.Errorf("error during vector adding: %w", err)
 		}
-		if fib {
-			alpha, err = frs.oneDimensionFibonacciSearch(x, dNew, alpha)
-		} else if sqrInterp {
-			alpha, err = frs.oneDimensionSquareInterpolation(x, dNew, alpha)
-		} else {
-			alpha, err = frs.oneDimensionSearch(x, dNew, alpha, search)
+		if v, _ := dNew.Mul(grad); v >= 0 {
+			dNew = gradMinus // not a descent direction, restart with antigradient
+		}
+		alpha, err = frs.search(frs.lineSearch, getOneDimensionFunc(frs.targetFunc, dNew, x),
+			getOneDimensionDerivative(frs.gradient, dNew, x), alpha)
+		if err != nil {
+			if frs.interrupted(k) {
+				return frs.bestPoint()
+			}
+			return nil, 0, fmt.Errorf("error during one dimension search: %w", err)
 		}
 		dInter = dNew.MulOnValue(alpha)
 		xOld = x
 		x, err = x.Add(dInter)
 		if err != nil {
-			return nil, 0, fmt.Errorf("error during vector adding: %v", err)
+			return nil, 0, fmt.Errorf("error during vector adding: %w", err)
 		}
 		xSub, err = x.Sub(xOld)
 		if err != nil {
-			return nil, 0, fmt.Errorf("error during vector substracting: %v", err)
+			return nil, 0, fmt.Errorf("error during vector substracting: %w", err)
 		}
-		if xSub.Len() < frs.delta && math.Abs(frs.targetFunc(x.Points)-frs.targetFunc(xOld.Points)) < frs.eps2 {
+		fOld, f = f, frs.targetFunc(x.Points)
+		if xSub.Len() < frs.delta && math.Abs(f-fOld) < frs.eps2 {
 			if lastIter {
 				//fmt.Printf("k value: %d\n", k)
-				return x.Points, frs.targetFunc(x.Points), nil
+				frs.finish(k, StepConverged)
+				return x.Points, f, nil
 			} else {
 				lastIter = true
 			}
 		}
-		//fmt.Println(frs.targetFunc(x.Points))
 		d = dNew
+		frs.notify(k, x.Points, f, grad.Len(), xSub.Len())
 		k++
 	}
 }
@@ -142,54 +175,7 @@ func (frs *FletcherReevesSearch) calculateGradient(x la_methods.Vector) (la_meth
 	}
 	err := grad.InitWithPoints(frs.dimension, gradPoints)
 	if err != nil {
-		return la_methods.Vector{}, fmt.Errorf("error during vector initializing: %v", err)
+		return la_methods.Vector{}, fmt.Errorf("error during vector initializing: %w", err)
 	}
 	return grad, nil
 }
-
-func (frs *FletcherReevesSearch) findBounds(alpha float64, targetFunc func(x float64) float64) (float64, float64, error) {
-	frs.svennAlgorithm.Init(frs.eps1, alpha, targetFunc)
-	return frs.svennAlgorithm.Solve()
-}
-
-func (frs *FletcherReevesSearch) oneDimensionFibonacciSearch(y la_methods.Vector, d la_methods.Vector, alpha float64) (float64, error) {
-	var fib one_dimension_search.FibonacciSearch
-	fOneD := frs.getOneDimensionFunc(d, y)
-	a, b, err := frs.findBounds(alpha, fOneD)
-	if err != nil {
-		return 0, fmt.Errorf("error during svenn algorithm: %v", err)
-	}
-	fib.Init(a, b, frs.oneDStep, frs.oneDStep, fOneD)
-	min, _, err := fib.Solve()
-	if err != nil {
-		return 0, fmt.Errorf("error during fibonacci search: %v", err)
-	}
-	return min, nil
-}
-
-func (frs *FletcherReevesSearch) oneDimensionSquareInterpolation(y la_methods.Vector, d la_methods.Vector, alpha float64) (float64, error) {
-	var sqrInter interpolation_search.SquareInterpolation
-	fOneD := frs.getOneDimensionFunc(d, y)
-	sqrInter.Init(frs.alphaPrecision, frs.oneDStep, frs.eps1, frs.eps1, fOneD)
-	min, _ := sqrInter.Solve()
-	return min, nil
-}
-
-func (frs *FletcherReevesSearch) oneDimensionSearch(y la_methods.Vector, d la_methods.Vector, alpha float64, search one_dimension_search.OneDimensionSearchI) (float64, error) {
-	fOneD := frs.getOneDimensionFunc(d, y)
-	a, b, err := frs.findBounds(alpha, fOneD)
-	if err != nil {
-		return 0, fmt.Errorf("error during svenn algorithm: %v", err)
-	}
-	search.Init(a, b, frs.oneDStep, fOneD)
-	min, _ := search.Solve()
-	return min, nil
-}
-
-func (frs *FletcherReevesSearch) getOneDimensionFunc(d la_methods.Vector, y la_methods.Vector) func(x float64) float64 {
-	return func(x float64) float64 {
-		aplhaD := d.MulOnValue(x)
-		sumYAlphD, _ := y.Add(aplhaD)
-		return frs.targetFunc(sumYAlphD.Points)
-	}
-}
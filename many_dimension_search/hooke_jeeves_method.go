@@ -1,9 +1,11 @@
 package many_dimension_search
 
 import (
+	"context"
 	"fmt"
 	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
-	"github.com/saskamegaprogrammist/optimization_methods/one_dimension_search"
+	"github.com/saskamegaprogrammist/optimization_methods/line_search"
+	"time"
 )
 
 type HookeJeevesSearch struct {
@@ -14,141 +16,127 @@ type HookeJeevesSearch struct {
 	lambda         float64
 	dimension      int
 	targetFunc     func(xs []float64) float64
-	method         string
-	oneDStep       float64
-	svennAlgorithm one_dimension_search.Svenn
+	lineSearch     line_search.LineSearch
+	bounds         Bounds
+	solverStats
 }
 
 func (hjs *HookeJeevesSearch) Init(startPoint []float64, delta float64, dimension int,
 	lambda float64, precision float64, alphaPrecision float64,
-	oneDStep float64, targetFunc func(xs []float64) float64, method string) {
+	targetFunc func(xs []float64) float64, lineSearch line_search.LineSearch) {
 	hjs.startPoint = startPoint
 	hjs.delta = delta
 	hjs.lambda = lambda
 	hjs.precision = precision
-	hjs.targetFunc = targetFunc
+	hjs.targetFunc = hjs.countFunc(targetFunc)
 	hjs.dimension = dimension
-	hjs.method = method
+	hjs.lineSearch = lineSearch
 	hjs.alphaPrecision = alphaPrecision
-	hjs.oneDStep = oneDStep
+}
+
+func (hjs *HookeJeevesSearch) SetBounds(bounds Bounds) {
+	hjs.bounds = bounds
+}
+
+func (hjs *HookeJeevesSearch) SolveProblem(ctx context.Context, problem Problem, settings Settings) (Result, error) {
+	err := problem.checkBounded()
+	if err != nil {
+		return Result{}, fmt.Errorf("error checking problem: %w", err)
+	}
+	timeStart := time.Now()
+	hjs.Init(problem.StartPoint, settings.ExploreStep, problem.Dimension, settings.Lambda, settings.Eps1,
+		settings.AlphaPrecision, problem.TargetFunc, settings.LineSearch)
+	hjs.SetBounds(problem.Bounds)
+	hjs.SetObserver(settings.Observer)
+	hjs.SetLimits(Limits{MaxIterations: settings.MaxIter, MaxFuncEvaluations: settings.MaxFuncEvals, MaxTime: settings.MaxTime})
+	xMin, yMin, err := hjs.SolveContext(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	return hjs.result(xMin, yMin, timeStart), nil
 }
 
 func (hjs *HookeJeevesSearch) Solve() ([]float64, float64, error) {
+	return hjs.SolveContext(context.Background())
+}
+
+func (hjs *HookeJeevesSearch) SolveContext(ctx context.Context) ([]float64, float64, error) {
 	var err error
 	var y, yPrev la_methods.Vector
 	var x la_methods.Vector
 	var i, k int
 	var delta la_methods.Vector
-	var alpha float64
-	var stop, fib bool
+	var alpha, fX, fY float64
+	var stop bool
 	alpha = hjs.alphaPrecision
-	var search one_dimension_search.OneDimensionSearchI
-	if hjs.method == "break in two" {
-		search = &one_dimension_search.BreakInTwoSearch{}
-	} else if hjs.method == "golden ratio" {
-		search = &one_dimension_search.GoldenRatioSearch{}
-	} else if hjs.method == "fibonacci" {
-		fib = true
-	} else {
-		return []float64{}, 0, fmt.Errorf("wrong one dimensional method: %s", hjs.method)
+	if hjs.lineSearch == nil {
+		return []float64{}, 0, fmt.Errorf("one dimensional method is not set")
 	}
 	err = x.InitWithPoints(hjs.dimension, hjs.startPoint)
 	if err != nil {
-		return []float64{}, 0, fmt.Errorf("error initializing vector: %v", err)
+		return []float64{}, 0, fmt.Errorf("error initializing vector: %w", err)
 	}
+	x = hjs.bounds.apply(x)
+	hjs.reset(ctx, x.Points)
 	y = x.Copy()
 	yPrev = y.Copy()
+	fY = hjs.targetFunc(y.Points)
 	k = 1
 	delta.InitWithValue(hjs.dimension, hjs.delta)
 	for {
+		if hjs.interrupted(k) {
+			return hjs.bestPoint()
+		}
 		x, err := hjs.research(i, y, delta)
 		if err != nil {
-			return []float64{}, 0, fmt.Errorf("error researching: %v", err)
+			return []float64{}, 0, fmt.Errorf("error researching: %w", err)
 		}
 
-		fY := hjs.targetFunc(y.Points)
-		fX := hjs.targetFunc(x.Points)
+		fX = hjs.targetFunc(x.Points)
 		if fX < fY {
 			yPrev = y
-			y = x
+			y, fY = x, fX
 			yInterm, err := y.Sub(yPrev)
 			if err != nil {
-				return []float64{}, 0, fmt.Errorf("error substracting: %v", err)
+				return []float64{}, 0, fmt.Errorf("error substracting: %w", err)
 			}
 			x, err = y.Add(yInterm.MulOnValue(hjs.lambda))
 			if err != nil {
-				return []float64{}, 0, fmt.Errorf("error adding: %v", err)
+				return []float64{}, 0, fmt.Errorf("error adding: %w", err)
 			}
 			k++
 		}
 
 		d, err := y.Sub(yPrev)
 		if err != nil {
-			return []float64{}, 0, fmt.Errorf("error substracting: %v", err)
-		}
-		if fib {
-			alpha, err = hjs.oneDimensionFibonacciSearch(y, d, alpha)
-		} else {
-			alpha, err = hjs.oneDimensionSearch(y, d, alpha, search)
+			return []float64{}, 0, fmt.Errorf("error substracting: %w", err)
 		}
+		alpha, err = hjs.search(hjs.lineSearch, getOneDimensionFunc(hjs.bounds.applyFunc(hjs.targetFunc), d, y), nil, alpha)
 		if err != nil {
-			return []float64{}, 0, fmt.Errorf("error during one dimension search: %v", err)
+			if hjs.interrupted(k) {
+				return hjs.bestPoint()
+			}
+			return []float64{}, 0, fmt.Errorf("error during one dimension search: %w", err)
 		}
 		delta, stop = hjs.checkStop(alpha, delta, hjs.precision)
-		fmt.Println(hjs.targetFunc(y.Points))
 		if stop {
 			//fmt.Printf("k value: %d\n", k)
-			return y.Points, hjs.targetFunc(y.Points), nil
+			hjs.finish(k, StepConverged)
+			return y.Points, fY, nil
 		}
 		aplhaD := d.MulOnValue(alpha)
 		y, err = y.Add(aplhaD)
-		yPrev = y
 		if err != nil {
-			return []float64{}, 0, fmt.Errorf("error adding: %v", err)
+			return []float64{}, 0, fmt.Errorf("error adding: %w", err)
 		}
+		y = hjs.bounds.apply(y)
+		yPrev = y
+		fY = hjs.targetFunc(y.Points)
+		hjs.notify(k, y.Points, fY, 0, aplhaD.Len())
 		k++
 	}
 }
 
-func (hjs *HookeJeevesSearch) findBounds(alpha float64, targetFunc func(x float64) float64) (float64, float64, error) {
-	hjs.svennAlgorithm.Init(hjs.precision, alpha, targetFunc)
-	return hjs.svennAlgorithm.Solve()
-}
-
-func (hjs *HookeJeevesSearch) oneDimensionFibonacciSearch(y la_methods.Vector, d la_methods.Vector, alpha float64) (float64, error) {
-	var fib one_dimension_search.FibonacciSearch
-	fOneD := hjs.getOneDimensionFunc(d, y)
-	a, b, err := hjs.findBounds(alpha, fOneD)
-	if err != nil {
-		return 0, fmt.Errorf("error during svenn algorithm: %v", err)
-	}
-	fib.Init(a, b, hjs.oneDStep, hjs.oneDStep, fOneD)
-	min, _, err := fib.Solve()
-	if err != nil {
-		return 0, fmt.Errorf("error during fibonacci search: %v", err)
-	}
-	return min, nil
-}
-
-func (hjs *HookeJeevesSearch) oneDimensionSearch(y la_methods.Vector, d la_methods.Vector, alpha float64, search one_dimension_search.OneDimensionSearchI) (float64, error) {
-	fOneD := hjs.getOneDimensionFunc(d, y)
-	a, b, err := hjs.findBounds(alpha, fOneD)
-	if err != nil {
-		return 0, fmt.Errorf("error during svenn algorithm: %v", err)
-	}
-	search.Init(a, b, hjs.oneDStep, fOneD)
-	min, _ := search.Solve()
-	return min, nil
-}
-
-func (hjs *HookeJeevesSearch) getOneDimensionFunc(d la_methods.Vector, y la_methods.Vector) func(x float64) float64 {
-	return func(x float64) float64 {
-		aplhaD := d.MulOnValue(x)
-		sumYAlphD, _ := y.Add(aplhaD)
-		return hjs.targetFunc(sumYAlphD.Points)
-	}
-}
-
 func (hjs *HookeJeevesSearch) checkStop(alpha float64, delta la_methods.Vector, eps float64) (la_methods.Vector, bool) {
 	//fmt.Println(alpha)
 	stop := alpha < eps
@@ -167,8 +155,9 @@ func (hjs *HookeJeevesSearch) research(iInit int, x la_methods.Vector, delta la_
 		f := hjs.targetFunc(x.Points)
 		xAdd, err := x.AddKOnIndex(delta.Points[i], i)
 		if err != nil {
-			return x, fmt.Errorf("error adding delta value: %v", err)
+			return x, fmt.Errorf("error adding delta value: %w", err)
 		}
+		xAdd = hjs.bounds.apply(xAdd)
 		f1 := hjs.targetFunc(xAdd.Points)
 
 		if f1 < f {
@@ -176,8 +165,9 @@ func (hjs *HookeJeevesSearch) research(iInit int, x la_methods.Vector, delta la_
 		} else {
 			xSub, err := x.SubKOnIndex(delta.Points[i], i)
 			if err != nil {
-				return x, fmt.Errorf("error substracting delta value: %v", err)
+				return x, fmt.Errorf("error substracting delta value: %w", err)
 			}
+			xSub = hjs.bounds.apply(xSub)
 			f2 := hjs.targetFunc(xSub.Points)
 			if f2 < f {
 				x = xSub
@@ -0,0 +1,180 @@
+package many_dimension_search
+
+import (
+	"context"
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"github.com/saskamegaprogrammist/optimization_methods/line_search"
+	"math"
+	"time"
+)
+
+type LBFGSSearch struct {
+	startPoint     []float64
+	eps1           float64
+	eps2           float64
+	delta          float64
+	alphaPrecision float64
+	dimension      int
+	targetFunc     func(xs []float64) float64
+	gradient       []func(xs []float64) float64
+	lineSearch     line_search.LineSearch
+	maxIter        int
+	history        int // stored corrections
+	solverStats
+}
+
+func (lbfgs *LBFGSSearch) Init(startPoint []float64, delta float64, dimension int,
+	eps1 float64, eps2 float64, alphaPrecision float64,
+	maxIter int, targetFunc func(xs []float64) float64, gradient []func(xs []float64) float64,
+	lineSearch line_search.LineSearch, history int) {
+	lbfgs.startPoint = startPoint
+	lbfgs.delta = delta
+	lbfgs.eps1 = eps1
+	lbfgs.eps2 = eps2
+	lbfgs.targetFunc = lbfgs.countFunc(targetFunc)
+	lbfgs.dimension = dimension
+	lbfgs.lineSearch = lineSearch
+	lbfgs.alphaPrecision = alphaPrecision
+	lbfgs.maxIter = maxIter
+	lbfgs.gradient = lbfgs.countGradient(numericalGradient(targetFunc, gradient, dimension))
+	lbfgs.history = history
+}
+
+func (lbfgs *LBFGSSearch) SolveProblem(ctx context.Context, problem Problem, settings Settings) (Result, error) {
+	err := problem.check()
+	if err != nil {
+		return Result{}, fmt.Errorf("error checking problem: %w", err)
+	}
+	timeStart := time.Now()
+	lbfgs.Init(problem.StartPoint, settings.Delta, problem.Dimension, settings.Eps1, settings.Eps2,
+		settings.AlphaPrecision, settings.MaxIter, problem.TargetFunc, problem.Gradient,
+		settings.LineSearch, settings.History)
+	lbfgs.SetObserver(settings.Observer)
+	lbfgs.SetLimits(Limits{MaxIterations: settings.MaxIter, MaxFuncEvaluations: settings.MaxFuncEvals, MaxTime: settings.MaxTime})
+	xMin, yMin, err := lbfgs.SolveContext(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	return lbfgs.result(xMin, yMin, timeStart), nil
+}
+
+func (lbfgs *LBFGSSearch) Solve() ([]float64, float64, error) {
+	return lbfgs.SolveContext(context.Background())
+}
+
+func (lbfgs *LBFGSSearch) SolveContext(ctx context.Context) ([]float64, float64, error) {
+	var err error
+	var x, xOld, xSub la_methods.Vector
+	var grad, gradOld, gradSub, d la_methods.Vector
+	var sHistory, yHistory []la_methods.Vector
+	var rhoHistory []float64
+	var alpha, sy, f, fOld float64
+	var k int
+	var lastIter bool
+	err = checkDerivatives(lbfgs.targetFunc, lbfgs.gradient, nil, lbfgs.startPoint)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error checking derivatives: %w", err)
+	}
+	lbfgs.reset(ctx, lbfgs.startPoint)
+	if lbfgs.lineSearch == nil {
+		return []float64{}, 0, fmt.Errorf("one dimensional method is not set")
+	}
+	if lbfgs.history < 1 {
+		return []float64{}, 0, fmt.Errorf("wrong history size: %d", lbfgs.history)
+	}
+	alpha = lbfgs.alphaPrecision
+	err = x.InitWithPoints(lbfgs.dimension, lbfgs.startPoint)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error during vector initializing: %w", err)
+	}
+	grad, err = calculateGradient(lbfgs.gradient, x)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error calculcating gradient: %w", err)
+	}
+	f = lbfgs.targetFunc(x.Points)
+	for {
+		if lbfgs.interrupted(k) {
+			return lbfgs.bestPoint()
+		}
+		if grad.Len() < lbfgs.eps1 {
+			lbfgs.finish(k, GradientConverged)
+			return x.Points, f, nil
+		}
+		if k >= lbfgs.maxIter {
+			lbfgs.finish(k, MaxIterationsReached)
+			return x.Points, f, nil
+		}
+		d = calculateLBFGSDirection(grad, sHistory, yHistory, rhoHistory)
+		alpha, err = lbfgs.search(lbfgs.lineSearch, getOneDimensionFunc(lbfgs.targetFunc, d, x),
+			getOneDimensionDerivative(lbfgs.gradient, d, x), alpha)
+		if err != nil {
+			if lbfgs.interrupted(k) {
+				return lbfgs.bestPoint()
+			}
+			return nil, 0, fmt.Errorf("error during one dimension search: %w", err)
+		}
+		xOld = x
+		x, err = x.Add(d.MulOnValue(alpha))
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during vector adding: %w", err)
+		}
+		gradOld = grad
+		grad, err = calculateGradient(lbfgs.gradient, x)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error calculcating gradient: %w", err)
+		}
+		xSub, err = x.Sub(xOld)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during vector substracting: %w", err)
+		}
+		gradSub, err = grad.Sub(gradOld)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during vector substracting: %w", err)
+		}
+		sy, err = xSub.Mul(gradSub)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during vector multiplying: %w", err)
+		}
+		if sy > 0 {
+			if len(sHistory) == lbfgs.history {
+				sHistory, yHistory, rhoHistory = sHistory[1:], yHistory[1:], rhoHistory[1:]
+			}
+			sHistory = append(sHistory, xSub)
+			yHistory = append(yHistory, gradSub)
+			rhoHistory = append(rhoHistory, 1/sy)
+		}
+		fOld, f = f, lbfgs.targetFunc(x.Points)
+		if xSub.Len() < lbfgs.delta && math.Abs(f-fOld) < lbfgs.eps2 {
+			if lastIter {
+				lbfgs.finish(k, StepConverged)
+				return x.Points, f, nil
+			} else {
+				lastIter = true
+			}
+		}
+		lbfgs.notify(k, x.Points, f, grad.Len(), xSub.Len())
+		k++
+	}
+}
+
+func calculateLBFGSDirection(grad la_methods.Vector, sHistory []la_methods.Vector,
+	yHistory []la_methods.Vector, rhoHistory []float64) la_methods.Vector {
+	q := grad.MulOnValue(-1)
+	a := make([]float64, len(sHistory))
+	for i := len(sHistory) - 1; i >= 0; i-- {
+		sq, _ := sHistory[i].Mul(q)
+		a[i] = rhoHistory[i] * sq
+		q, _ = q.Sub(yHistory[i].MulOnValue(a[i]))
+	}
+	if last := len(sHistory) - 1; last >= 0 {
+		yy, _ := yHistory[last].Mul(yHistory[last])
+		q = q.MulOnValue(1 / (rhoHistory[last] * yy))
+	}
+	for i := range sHistory {
+		yr, _ := yHistory[i].Mul(q)
+		b := rhoHistory[i] * yr
+		q, _ = q.Add(sHistory[i].MulOnValue(a[i] - b))
+	}
+	return q
+}
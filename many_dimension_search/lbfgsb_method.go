@@ -0,0 +1,179 @@
+package many_dimension_search
+
+import (
+	"context"
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"math"
+	"time"
+)
+
+type LBFGSBSearch struct {
+	startPoint []float64
+	eps1       float64
+	eps2       float64
+	dimension  int
+	targetFunc func(xs []float64) float64
+	gradient   []func(xs []float64) float64
+	bounds     Bounds
+	maxIter    int
+	history    int // stored corrections
+	solverStats
+}
+
+func (lbfgsb *LBFGSBSearch) Init(startPoint []float64, dimension int,
+	eps1 float64, eps2 float64, maxIter int, targetFunc func(xs []float64) float64,
+	gradient []func(xs []float64) float64, bounds Bounds, history int) {
+	lbfgsb.startPoint = startPoint
+	lbfgsb.eps1 = eps1
+	lbfgsb.eps2 = eps2
+	lbfgsb.targetFunc = lbfgsb.countFunc(targetFunc)
+	lbfgsb.dimension = dimension
+	lbfgsb.gradient = lbfgsb.countGradient(numericalGradient(targetFunc, gradient, dimension))
+	lbfgsb.bounds = bounds
+	lbfgsb.maxIter = maxIter
+	lbfgsb.history = history
+}
+
+func (lbfgsb *LBFGSBSearch) SolveProblem(ctx context.Context, problem Problem, settings Settings) (Result, error) {
+	err := problem.checkBounded()
+	if err != nil {
+		return Result{}, fmt.Errorf("error checking problem: %w", err)
+	}
+	timeStart := time.Now()
+	lbfgsb.Init(problem.StartPoint, problem.Dimension, settings.Eps1, settings.Eps2, settings.MaxIter,
+		problem.TargetFunc, problem.Gradient, problem.Bounds, settings.History)
+	lbfgsb.SetObserver(settings.Observer)
+	lbfgsb.SetLimits(Limits{MaxIterations: settings.MaxIter, MaxFuncEvaluations: settings.MaxFuncEvals, MaxTime: settings.MaxTime})
+	xMin, yMin, err := lbfgsb.SolveContext(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	return lbfgsb.result(xMin, yMin, timeStart), nil
+}
+
+func (lbfgsb *LBFGSBSearch) Solve() ([]float64, float64, error) {
+	return lbfgsb.SolveContext(context.Background())
+}
+
+func (lbfgsb *LBFGSBSearch) SolveContext(ctx context.Context) ([]float64, float64, error) {
+	var err error
+	var x, xOld, xSub la_methods.Vector
+	var grad, gradOld, gradSub, projGrad, d la_methods.Vector
+	var sHistory, yHistory []la_methods.Vector
+	var rhoHistory []float64
+	var f, fOld, alpha, gd, sy, yy float64
+	var k int
+	var ok bool
+	err = lbfgsb.bounds.check(lbfgsb.dimension)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error checking bounds: %w", err)
+	}
+	if lbfgsb.history < 1 {
+		return []float64{}, 0, fmt.Errorf("wrong history size: %d", lbfgsb.history)
+	}
+	err = x.InitWithPoints(lbfgsb.dimension, lbfgsb.startPoint)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error during vector initializing: %w", err)
+	}
+	x = lbfgsb.bounds.project(x)
+	err = checkDerivatives(lbfgsb.targetFunc, lbfgsb.gradient, nil, x.Points)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error checking derivatives: %w", err)
+	}
+	lbfgsb.reset(ctx, x.Points)
+	f = lbfgsb.targetFunc(x.Points)
+	grad, err = calculateGradient(lbfgsb.gradient, x)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error calculcating gradient: %w", err)
+	}
+	for {
+		if lbfgsb.interrupted(k) {
+			return lbfgsb.bestPoint()
+		}
+		projGrad, err = lbfgsb.bounds.projectedGradient(x, grad)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error calculating projected gradient: %w", err)
+		}
+		if projGrad.Len() < lbfgsb.eps1 {
+			lbfgsb.finish(k, GradientConverged)
+			return x.Points, f, nil
+		}
+		if k >= lbfgsb.maxIter {
+			lbfgsb.finish(k, MaxIterationsReached)
+			return x.Points, f, nil
+		}
+		free := lbfgsb.bounds.freeVariables(x, grad)
+		d = calculateLBFGSDirection(maskVector(grad, free), sHistory, yHistory, rhoHistory)
+		d = maskVector(d, free) // quasi newton step in the subspace of free variables
+		gd, err = grad.Mul(d)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during vector multiplying: %w", err)
+		}
+		alpha = 1
+		if gd >= 0 {
+			d = maskVector(grad.MulOnValue(-1), free)
+			sHistory, yHistory, rhoHistory = nil, nil, nil
+		}
+		if len(sHistory) == 0 {
+			alpha = 1 / math.Max(d.Len(), 1)
+		}
+		xOld, fOld, gradOld = x, f, grad
+		x, f, ok, err = projectedBacktracking(lbfgsb.targetFunc, lbfgsb.bounds, x, f, grad, d, alpha)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during projected search: %w", err)
+		}
+		if !ok {
+			if len(sHistory) == 0 {
+				lbfgsb.finish(k, StepConverged)
+				return xOld.Points, fOld, nil
+			}
+			sHistory, yHistory, rhoHistory = nil, nil, nil // restart from projected gradient step
+			continue
+		}
+		grad, err = calculateGradient(lbfgsb.gradient, x)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error calculcating gradient: %w", err)
+		}
+		xSub, err = x.Sub(xOld)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during vector substracting: %w", err)
+		}
+		gradSub, err = grad.Sub(gradOld)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during vector substracting: %w", err)
+		}
+		sy, err = xSub.Mul(gradSub)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during vector multiplying: %w", err)
+		}
+		yy, err = gradSub.Mul(gradSub)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during vector multiplying: %w", err)
+		}
+		if sy > activeTolerance*yy {
+			if len(sHistory) == lbfgsb.history {
+				sHistory, yHistory, rhoHistory = sHistory[1:], yHistory[1:], rhoHistory[1:]
+			}
+			sHistory = append(sHistory, xSub)
+			yHistory = append(yHistory, gradSub)
+			rhoHistory = append(rhoHistory, 1/sy)
+		}
+		lbfgsb.notify(k, x.Points, f, grad.Len(), xSub.Len())
+		k++
+		if xSub.Len() < lbfgsb.eps2 && math.Abs(f-fOld) < lbfgsb.eps2 {
+			lbfgsb.finish(k, StepConverged)
+			return x.Points, f, nil
+		}
+	}
+}
+
+func maskVector(v la_methods.Vector, mask []bool) la_methods.Vector {
+	masked := v.Copy()
+	for i, m := range mask {
+		if !m {
+			masked.Points[i] = 0
+		}
+	}
+	return masked
+}
@@ -1,8 +1,10 @@
 package many_dimension_search
 
 import (
+	"context"
 	"fmt"
 	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"time"
 )
 
 type LevenbergMarkkvadratSearch struct {
@@ -14,77 +16,107 @@ type LevenbergMarkkvadratSearch struct {
 	m             float64
 	eps           float64
 	maxIterations int
+	solverStats
 }
 
 func (lms *LevenbergMarkkvadratSearch) Init(startPoint []float64, dimension int,
 	targetFunc func(xs []float64) float64, gradient []func(xs []float64) float64,
 	hessian func(xs []float64) la_methods.Matrix, m float64, maxIterations int, eps float64) {
 	lms.startPoint = startPoint
-	lms.targetFunc = targetFunc
+	lms.targetFunc = lms.countFunc(targetFunc)
 	lms.dimension = dimension
-	lms.gradient = gradient
+	lms.gradient = lms.countGradient(numericalGradient(targetFunc, gradient, dimension))
 	lms.m = m
-	lms.hessian = hessian
+	lms.hessian = numericalHessian(targetFunc, hessian, dimension)
 	lms.maxIterations = maxIterations
 	lms.eps = eps
 }
 
+func (lms *LevenbergMarkkvadratSearch) SolveProblem(ctx context.Context, problem Problem, settings Settings) (Result, error) {
+	err := problem.check()
+	if err != nil {
+		return Result{}, fmt.Errorf("error checking problem: %w", err)
+	}
+	timeStart := time.Now()
+	lms.Init(problem.StartPoint, problem.Dimension, problem.TargetFunc, problem.Gradient, problem.Hessian,
+		settings.Damping, settings.MaxIter, settings.Eps1)
+	lms.SetObserver(settings.Observer)
+	lms.SetLimits(Limits{MaxIterations: settings.MaxIter, MaxFuncEvaluations: settings.MaxFuncEvals, MaxTime: settings.MaxTime})
+	xMin, yMin, err := lms.SolveContext(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	return lms.result(xMin, yMin, timeStart), nil
+}
+
 func (lms *LevenbergMarkkvadratSearch) Solve() ([]float64, float64, error) {
+	return lms.SolveContext(context.Background())
+}
+
+func (lms *LevenbergMarkkvadratSearch) SolveContext(ctx context.Context) ([]float64, float64, error) {
 	var err error
 	var Hess, mM, HessInter, HessInterInv la_methods.Matrix
-	var x, xOld la_methods.Vector
+	var x la_methods.Vector
 	var k int
-	var m float64
+	var m, f, fOld float64
 	var grad, d la_methods.Vector
+	err = checkDerivatives(lms.targetFunc, lms.gradient, lms.hessian, lms.startPoint)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error checking derivatives: %w", err)
+	}
+	lms.reset(ctx, lms.startPoint)
 	m = lms.m
 	err = x.InitWithPoints(lms.dimension, lms.startPoint)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error during vector initializing: %v", err)
+		return nil, 0, fmt.Errorf("error during vector initializing: %w", err)
 	}
-	xOld = x.Copy()
+	f = lms.targetFunc(x.Points)
 OUTER:
 	for {
 		grad, err = lms.calculateGradient(x)
 		if err != nil {
-			return nil, 0, fmt.Errorf("error calculcating gradient: %v", err)
+			return nil, 0, fmt.Errorf("error calculcating gradient: %w", err)
 		}
 		if grad.Len() < lms.eps {
 			//fmt.Printf("k value: %d\n", k)
-			return x.Points, lms.targetFunc(x.Points), nil
+			lms.finish(k, GradientConverged)
+			return x.Points, f, nil
 		}
 		Hess = lms.hessian(x.Points)
 		for {
+			if lms.interrupted(k) {
+				return lms.bestPoint()
+			}
 			if k >= lms.maxIterations {
 				//fmt.Printf("k value: %d\n", k)
-				return x.Points, lms.targetFunc(x.Points), nil
+				lms.finish(k, MaxIterationsReached)
+				return x.Points, f, nil
 			}
 			mM.Init(lms.dimension, lms.dimension)
 			mM.E()
 			mM = mM.MulVal(m)
 			HessInter, err = Hess.AddM(mM)
 			if err != nil {
-				return nil, 0, fmt.Errorf("error adding matrices: %v", err)
+				return nil, 0, fmt.Errorf("error adding matrices: %w", err)
 			}
 			HessInterInv, err = HessInter.Inverted()
 			if err != nil {
-				return nil, 0, fmt.Errorf("error inverting matrix: %v", err)
+				return nil, 0, fmt.Errorf("error inverting matrix: %w", err)
 			}
 
-			HessInterInv.Print()
-
 			//gradMinus = grad.MulOnValue(-1)
 			d, err = HessInterInv.MulV(grad)
 			if err != nil {
-				return nil, 0, fmt.Errorf("error during vector and matrix multiplying: %v", err)
+				return nil, 0, fmt.Errorf("error during vector and matrix multiplying: %w", err)
 			}
-			xOld = x
 			x, err = x.Sub(d)
 			if err != nil {
-				return nil, 0, fmt.Errorf("error during vector adding: %v", err)
+				return nil, 0, fmt.Errorf("error during vector adding: %w", err)
 			}
-			//fmt.Println(lms.targetFunc(x.Points))
-			if lms.targetFunc(x.Points) < lms.targetFunc(xOld.Points) {
+			fOld, f = f, lms.targetFunc(x.Points)
+			if f < fOld {
 				m /= 2
+				lms.notify(k, x.Points, f, grad.Len(), d.Len())
 				k++
 				continue OUTER
 			} else {
@@ -103,54 +135,54 @@ func (lms *LevenbergMarkkvadratSearch) calculateG(gradNew la_methods.Vector, gra
 	var gradDelta, gg la_methods.Vector
 	gradDelta, err = gradNew.Sub(gradOld)
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during vector substracting: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during vector substracting: %w", err)
 	}
 	err = deltaXMCol.InitWithVectorColumn(lms.dimension, lms.dimension, deltaX)
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during vector initalizing: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during vector initalizing: %w", err)
 	}
 	err = deltaXMRow.InitWithVectorRow(lms.dimension, lms.dimension, deltaX)
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during vector initalizing: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during vector initalizing: %w", err)
 	}
 	deltaXdeltaGrad, err = deltaX.Mul(gradDelta)
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during vector multiplying: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during vector multiplying: %w", err)
 	}
 	deltaX2, err = deltaXMCol.MulM(deltaXMRow)
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during matrix multiplying: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during matrix multiplying: %w", err)
 	}
 	A = deltaX2.MulVal(float64(1) / deltaXdeltaGrad)
 
 	gg, err = G.MulV(gradDelta)
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during matrix and vector multiplying: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during matrix and vector multiplying: %w", err)
 	}
 	err = ggCol.InitWithVectorColumn(lms.dimension, lms.dimension, gg)
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during vector initalizing: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during vector initalizing: %w", err)
 	}
 	ggRow, err = ggCol.Transponate()
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during vector transonation: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during vector transonation: %w", err)
 	}
 	ggM, err = ggCol.MulM(ggRow)
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during matrix multiplying: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during matrix multiplying: %w", err)
 	}
 	ggF, err = gradDelta.Mul(gg)
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during vector multiplying: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during vector multiplying: %w", err)
 	}
 	B = ggM.MulVal(float64(-1) / ggF)
 	deltaG, err = A.AddM(B)
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during matrix adding: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during matrix adding: %w", err)
 	}
 	GNew, err = G.AddM(deltaG)
 	if err != nil {
-		return la_methods.Matrix{}, fmt.Errorf("error during matrix adding: %v", err)
+		return la_methods.Matrix{}, fmt.Errorf("error during matrix adding: %w", err)
 	}
 	return GNew, nil
 }
@@ -163,7 +195,7 @@ func (lms *LevenbergMarkkvadratSearch) calculateGradient(x la_methods.Vector) (l
 	}
 	err := grad.InitWithPoints(lms.dimension, gradPoints)
 	if err != nil {
-		return la_methods.Vector{}, fmt.Errorf("error during vector initializing: %v", err)
+		return la_methods.Vector{}, fmt.Errorf("error during vector initializing: %w", err)
 	}
 	return grad, nil
 }
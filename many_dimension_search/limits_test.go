@@ -0,0 +1,91 @@
+package many_dimension_search
+
+import (
+	"context"
+	"github.com/saskamegaprogrammist/optimization_methods/test_functions"
+	"testing"
+	"time"
+)
+
+func TestSolveProblemMaxFuncEvaluations(t *testing.T) {
+	problem := Problem{TargetFunc: test_functions.Rosenbrock, Dimension: 2, StartPoint: []float64{-1.2, 1}}
+	for name, solver := range solvers() {
+		settings := DefaultSettings()
+		settings.Eps1 = 1e-12
+		settings.Eps2 = 1e-14
+		settings.Delta = 1e-14
+		settings.MaxIter = 100000
+		settings.MaxFuncEvals = 10
+		result, err := solver.SolveProblem(context.Background(), problem, settings)
+		if err != nil {
+			t.Fatalf("%s: error solving problem: %v", name, err)
+		}
+		if result.Reason != MaxFuncEvaluationsReached {
+			t.Errorf("%s: expected evaluations limit, got %s", name, result.Reason)
+		}
+		if result.F != test_functions.Rosenbrock(result.X) {
+			t.Errorf("%s: interrupted result value %g isn't function value %g", name, result.F, test_functions.Rosenbrock(result.X))
+		}
+	}
+}
+
+func TestSolveProblemCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	problem := Problem{TargetFunc: test_functions.Rosenbrock, Dimension: 2, StartPoint: []float64{-1.2, 1}}
+	for name, solver := range solvers() {
+		result, err := solver.SolveProblem(ctx, problem, DefaultSettings())
+		if err != nil {
+			t.Fatalf("%s: error solving problem: %v", name, err)
+		}
+		if result.Reason != Cancelled {
+			t.Errorf("%s: expected cancelled, got %s", name, result.Reason)
+		}
+	}
+}
+
+func TestSolveProblemTimeLimit(t *testing.T) {
+	slow := func(xs []float64) float64 {
+		time.Sleep(100 * time.Microsecond)
+		return test_functions.Rosenbrock(xs)
+	}
+	problem := Problem{TargetFunc: slow, Dimension: 2, StartPoint: []float64{-1.2, 1}}
+	for name, solver := range solvers() {
+		settings := DefaultSettings()
+		settings.Eps1 = 1e-12
+		settings.Eps2 = 1e-14
+		settings.Delta = 1e-14
+		settings.MaxIter = 100000
+		settings.MaxTime = 20 * time.Millisecond
+		result, err := solver.SolveProblem(context.Background(), problem, settings)
+		if err != nil {
+			t.Fatalf("%s: error solving problem: %v", name, err)
+		}
+		if result.Reason != TimeLimitReached && result.Reason != GradientConverged && result.Reason != StepConverged &&
+			result.Reason != SimplexConverged {
+			t.Errorf("%s: expected time limit, got %s", name, result.Reason)
+		}
+		if result.Time > time.Second {
+			t.Errorf("%s: time limit is ignored: %v", name, result.Time)
+		}
+	}
+}
+
+func TestLimitsExceeded(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	if reason := (Limits{}).Exceeded(ctx, 0, 0, time.Now()); reason != TimeLimitReached {
+		t.Errorf("expected time limit on context deadline, got %s", reason)
+	}
+	limits := Limits{MaxIterations: 10, MaxFuncEvaluations: 100}
+	if reason := limits.Exceeded(context.Background(), 10, 0, time.Now()); reason != MaxIterationsReached {
+		t.Errorf("expected iterations limit, got %s", reason)
+	}
+	if reason := limits.Exceeded(context.Background(), 9, 100, time.Now()); reason != MaxFuncEvaluationsReached {
+		t.Errorf("expected evaluations limit, got %s", reason)
+	}
+	if reason := limits.Exceeded(context.Background(), 9, 99, time.Now()); reason != NotTerminated {
+		t.Errorf("expected no limit, got %s", reason)
+	}
+}
@@ -1,11 +1,35 @@
 package many_dimension_search
 
 import (
+	"context"
 	"fmt"
 	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"gonum.org/v1/gonum/mat"
 	"math"
+	"time"
 )
 
+type NelderMeadCoefficients struct {
+	Alpha float64 // reflection
+	Beta  float64 // contraction
+	Gamma float64 // expansion
+	M     float64 // reduction
+	Teta  float64 // degenerate simplex minimal angle between edges, radians
+	Ratio float64 // degenerate simplex smallest to largest edge singular values ratio
+}
+
+func DefaultNelderMeadCoefficients() NelderMeadCoefficients {
+	return NelderMeadCoefficients{Alpha: 1, Beta: 0.5, Gamma: 2, M: 0.5, Teta: 0.01, Ratio: 0.01}
+}
+
+func AdaptiveNelderMeadCoefficients(dimension int) NelderMeadCoefficients {
+	if dimension < 2 {
+		return DefaultNelderMeadCoefficients() // gao han coefficients are defined for n >= 2
+	}
+	n := float64(dimension)
+	return NelderMeadCoefficients{Alpha: 1, Beta: 0.75 - 1/(2*n), Gamma: 1 + 2/n, M: 1 - 1/n, Teta: 0.01, Ratio: 0.01}
+}
+
 type NelderMeadSearch struct {
 	startPoint []float64
 	s          float64
@@ -15,23 +39,36 @@ type NelderMeadSearch struct {
 	gamma      float64
 	m          float64
 	teta       float64
+	ratio      float64
 	dimension  int
 	targetFunc func(xs []float64) float64
+	bounds     Bounds
+	restarts   int // restarts from best vertex after convergence
+	solverStats
 }
 
 func (nms *NelderMeadSearch) Init(startPoint []float64, s float64, dimension int,
 	precision float64, targetFunc func(xs []float64) float64) {
 	nms.startPoint = startPoint
 	nms.precision = precision
-	nms.targetFunc = targetFunc
+	nms.targetFunc = nms.countFunc(targetFunc)
 	nms.dimension = dimension
 	nms.s = s
+	nms.restarts = 0
+	nms.SetCoefficients(DefaultNelderMeadCoefficients())
+}
+
+func (nms *NelderMeadSearch) SetCoefficients(coefficients NelderMeadCoefficients) {
+	nms.alpha = coefficients.Alpha
+	nms.beta = coefficients.Beta
+	nms.gamma = coefficients.Gamma
+	nms.m = coefficients.M
+	nms.teta = coefficients.Teta
+	nms.ratio = coefficients.Ratio
+}
 
-	nms.alpha = 1
-	nms.beta = 0.5
-	nms.gamma = 2
-	nms.m = 0.5
-	nms.teta = 0.01
+func (nms *NelderMeadSearch) SetRestarts(restarts int) {
+	nms.restarts = restarts
 }
 
 func getL1(s float64, n float64) float64 {
@@ -42,7 +79,11 @@ func getL2(s float64, n float64) float64 {
 	return s * (math.Sqrt(n+1) - 1) / (n * math.Sqrt(2))
 }
 
-func (nms *NelderMeadSearch) getLVector(i int, s float64) la_methods.Vector {
+func (nms *NelderMeadSearch) SetBounds(bounds Bounds) {
+	nms.bounds = bounds
+}
+
+func (nms *NelderMeadSearch) getLVector(i int, s float64, xStart la_methods.Vector) la_methods.Vector {
 	var lVector la_methods.Vector
 	lPoints := make([]float64, nms.dimension)
 	l1 := getL1(s, float64(nms.dimension))
@@ -54,44 +95,77 @@ func (nms *NelderMeadSearch) getLVector(i int, s float64) la_methods.Vector {
 			lPoints[j] = l1
 			lPoints[j] += s
 		}
+		if xStart.Points[j]+lPoints[j] > nms.bounds.upper(j) && xStart.Points[j]-lPoints[j] >= nms.bounds.lower(j) {
+			lPoints[j] = -lPoints[j] // build simplex inside the bounds
+		}
 	}
 	_ = lVector.InitWithPoints(nms.dimension, lPoints)
 
 	return lVector
 }
 
+func (nms *NelderMeadSearch) SolveProblem(ctx context.Context, problem Problem, settings Settings) (Result, error) {
+	err := problem.checkBounded()
+	if err != nil {
+		return Result{}, fmt.Errorf("error checking problem: %w", err)
+	}
+	timeStart := time.Now()
+	nms.Init(problem.StartPoint, settings.SimplexSize, problem.Dimension, settings.Eps1, problem.TargetFunc)
+	nms.SetBounds(problem.Bounds)
+	if settings.AdaptiveNelderMead {
+		nms.SetCoefficients(AdaptiveNelderMeadCoefficients(problem.Dimension))
+	} else if settings.NelderMead != (NelderMeadCoefficients{}) {
+		nms.SetCoefficients(settings.NelderMead)
+	}
+	nms.SetRestarts(settings.Restarts)
+	nms.SetObserver(settings.Observer)
+	nms.SetLimits(Limits{MaxIterations: settings.MaxIter, MaxFuncEvaluations: settings.MaxFuncEvals, MaxTime: settings.MaxTime})
+	xMin, yMin, err := nms.SolveContext(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	return nms.result(xMin, yMin, timeStart), nil
+}
+
 func (nms *NelderMeadSearch) Solve() ([]float64, float64, error) {
+	return nms.SolveContext(context.Background())
+}
+
+func (nms *NelderMeadSearch) SolveContext(ctx context.Context) ([]float64, float64, error) {
 	var err error
-	var k int
-	var s float64
+	var k, restarts int
+	var fRestart float64
 	var xStart la_methods.Vector
 	var minVOld la_methods.Vector
+	err = nms.checkCoefficients()
+	if err != nil {
+		return []float64{}, 0, fmt.Errorf("error checking coefficients: %w", err)
+	}
 	err = xStart.InitWithPoints(nms.dimension, nms.startPoint)
 	if err != nil {
-		return []float64{}, 0, fmt.Errorf("error initializing vector: %v", err)
+		return []float64{}, 0, fmt.Errorf("error initializing vector: %w", err)
 	}
+	xStart = nms.bounds.apply(xStart)
+	nms.reset(ctx, xStart.Points)
 	minVOld = xStart.Copy()
-	s = nms.s
-	vectors := make([]la_methods.Vector, nms.dimension+1)
-	for i := 0; i < nms.dimension; i++ {
-		lVec := nms.getLVector(i, s)
-		vectors[i], err = xStart.Add(lVec)
-		if err != nil {
-			return []float64{}, 0, fmt.Errorf("error adding vector: %v", err)
-		}
+	fRestart = math.Inf(1)
+	vectors, values, err := nms.buildSimplex(xStart, nms.s)
+	if err != nil {
+		return []float64{}, 0, fmt.Errorf("error building simplex: %w", err)
 	}
-	vectors[nms.dimension] = xStart
 	for {
-
-		maxV, max, maxI, minV, min, minI := nms.findMaxAndMin(vectors)
-		xC, _, xCMax, _, err := nms.findMassCenter(vectors, maxI)
+		if nms.interrupted(k) {
+			return nms.bestPoint()
+		}
+		maxV, max, maxI, minV, min, minI := nms.findMaxAndMin(vectors, values)
+		xC, _, xCMax, _, err := nms.findMassCenter(vectors, values, maxI)
 
 		if err != nil {
-			return []float64{}, 0, fmt.Errorf("error finding mass center: %v", err)
+			return []float64{}, 0, fmt.Errorf("error finding mass center: %w", err)
 		}
 		xF, err := nms.getTestPoint(xC, maxV)
 		if err != nil {
-			return []float64{}, 0, fmt.Errorf("error getting test point: %v", err)
+			return []float64{}, 0, fmt.Errorf("error getting test point: %w", err)
 		}
 		fF := nms.targetFunc(xF.Points)
 
@@ -100,250 +174,264 @@ func (nms *NelderMeadSearch) Solve() ([]float64, float64, error) {
 		if fF < min {
 			xInter, err := xC.Sub(maxV)
 			if err != nil {
-				return []float64{}, 0, fmt.Errorf("error substracting vectors: %v", err)
+				return []float64{}, 0, fmt.Errorf("error substracting vectors: %w", err)
 			}
 			xInter = xInter.MulOnValue(nms.gamma)
 			xN, err = xC.Add(xInter)
 			if err != nil {
-				return []float64{}, 0, fmt.Errorf("error adding vectors: %v", err)
+				return []float64{}, 0, fmt.Errorf("error adding vectors: %w", err)
 			}
+			xN = nms.bounds.apply(xN)
 			fN = nms.targetFunc(xN.Points)
 
 			if fN < fF {
 				maxV = xN
 				vectors[maxI] = xN
-				max = nms.targetFunc(xN.Points)
+				values[maxI] = fN
+				max = fN
 			} else {
 				maxV = xF
 				vectors[maxI] = xF
-				max = nms.targetFunc(xF.Points)
+				values[maxI] = fF
+				max = fF
 			}
 		} else if min <= fF && fF <= xCMax {
 			maxV = xF
 			vectors[maxI] = xF
-			max = nms.targetFunc(xF.Points)
+			values[maxI] = fF
+			max = fF
 		} else if xCMax < fF && fF <= max {
 			xInter, err := xF.Sub(xC)
 			if err != nil {
-				return []float64{}, 0, fmt.Errorf("error substracting vectors: %v", err)
+				return []float64{}, 0, fmt.Errorf("error substracting vectors: %w", err)
 			}
 			xInter = xInter.MulOnValue(nms.beta)
 			xN, err = xC.Add(xInter)
 			if err != nil {
-				return []float64{}, 0, fmt.Errorf("error adding vectors: %v", err)
+				return []float64{}, 0, fmt.Errorf("error adding vectors: %w", err)
 			}
 			fN = nms.targetFunc(xN.Points)
 
 			if fN < fF {
 				maxV = xN
 				vectors[maxI] = xN
-				max = nms.targetFunc(xN.Points)
+				values[maxI] = fN
+				max = fN
 			} else {
-				vectors, err = nms.reduction(vectors, minV, min, minI)
+				vectors, values, err = nms.reduction(vectors, values, minV, minI)
 				if err != nil {
-					return []float64{}, 0, fmt.Errorf("error during reduction: %v", err)
+					return []float64{}, 0, fmt.Errorf("error during reduction: %w", err)
 				}
 			}
 		} else if fF > max {
 			xInter, err := maxV.Sub(xC)
 			if err != nil {
-				return []float64{}, 0, fmt.Errorf("error substracting vectors: %v", err)
+				return []float64{}, 0, fmt.Errorf("error substracting vectors: %w", err)
 			}
 			xInter = xInter.MulOnValue(nms.beta)
 			xN, err = xC.Add(xInter)
 			if err != nil {
-				return []float64{}, 0, fmt.Errorf("error adding vectors: %v", err)
+				return []float64{}, 0, fmt.Errorf("error adding vectors: %w", err)
 			}
 			fN = nms.targetFunc(xN.Points)
 
 			if fN < max {
 				maxV = xN
 				vectors[maxI] = xN
-				max = nms.targetFunc(xN.Points)
+				values[maxI] = fN
+				max = fN
 			} else {
-				vectors, err = nms.reduction(vectors, minV, min, minI)
+				vectors, values, err = nms.reduction(vectors, values, minV, minI)
 				if err != nil {
-					return []float64{}, 0, fmt.Errorf("error during reduction: %v", err)
+					return []float64{}, 0, fmt.Errorf("error during reduction: %w", err)
 				}
 			}
 		}
 		stopFirst, err := nms.checkStopFirst(minV, minVOld)
 		if err != nil {
-			return []float64{}, 0, fmt.Errorf("error during checking first condition: %v", err)
+			return []float64{}, 0, fmt.Errorf("error during checking first condition: %w", err)
 		}
 
-		//fmt.Println(minV.Points, nms.targetFunc(minV.Points))
-		if stopFirst && nms.checkStopSecond(vectors, min) {
+		if stopFirst && nms.checkStopSecond(values, min) {
 			//fmt.Printf("k value: %d\n", k)
-			return minV.Points, nms.targetFunc(minV.Points), nil
-		}
-		if k%10 == 0 {
-			tetaCur, err := nms.findTetaCurrent(vectors)
+			if restarts >= nms.restarts || fRestart-min <= nms.precision {
+				nms.finish(k, SimplexConverged)
+				return minV.Points, min, nil
+			}
+			restarts++
+			fRestart = min
+			vectors, values, err = nms.buildSimplex(minV, nms.s) // check convergence with new simplex
 			if err != nil {
-				return []float64{}, 0, fmt.Errorf("error during finding teta current: %v", err)
+				return []float64{}, 0, fmt.Errorf("error building simplex: %w", err)
 			}
-			if tetaCur <= nms.teta {
-				minIR := nms.findMin(vectors, minI)
-				xInterI, err := minV.Sub(minIR)
+		} else if k%10 == 0 {
+			degenerate, size := nms.checkDegenerate(vectors, minI)
+			if degenerate && size > 0 {
+				vectors, values, err = nms.buildSimplex(minV, size)
 				if err != nil {
-					return []float64{}, 0, fmt.Errorf("error substracting vectors: %v", err)
-				}
-				xStart = minV
-				s = xInterI.Len()
-
-				for i := 0; i < nms.dimension; i++ {
-					lVec := nms.getLVector(i, s)
-					vectors[i], err = xStart.Add(lVec)
-					if err != nil {
-						return []float64{}, 0, fmt.Errorf("error adding vector: %v", err)
-					}
+					return []float64{}, 0, fmt.Errorf("error building simplex: %w", err)
 				}
 			}
 		}
+		step, err := minV.EqDist(minVOld)
+		if err != nil {
+			return []float64{}, 0, fmt.Errorf("error calculating step: %w", err)
+		}
+		nms.notify(k, minV.Points, min, 0, step)
 		k++
 		minVOld = minV
 	}
 }
 
-func (nms *NelderMeadSearch) findMin(vectors []la_methods.Vector, minI int) la_methods.Vector {
-	var minINew int
-	var minNew float64
-	var testI int
-	if minI == 0 {
-		testI = 1
+func (nms *NelderMeadSearch) checkCoefficients() error {
+	if nms.alpha <= 0 || nms.gamma <= 1 || nms.beta <= 0 || nms.beta >= 1 || nms.m <= 0 || nms.m >= 1 ||
+		nms.teta < 0 || nms.ratio < 0 || nms.ratio >= 1 {
+		return fmt.Errorf("wrong nelder mead coefficients: %f, %f, %f, %f, %f, %f", nms.alpha, nms.beta, nms.gamma, nms.m, nms.teta, nms.ratio)
 	}
-	minNew = nms.targetFunc(vectors[testI].Points)
-	minINew = testI
-	for i, vec := range vectors[1:] {
-		if (i + 1) != minI {
-			v := nms.targetFunc(vec.Points)
-			if v < minNew {
-				minNew = v
-			}
-			minINew = i + 1
+	return nil
+}
+
+func (nms *NelderMeadSearch) buildSimplex(xStart la_methods.Vector, s float64) ([]la_methods.Vector, []float64, error) {
+	var err error
+	vectors := make([]la_methods.Vector, nms.dimension+1)
+	values := make([]float64, nms.dimension+1)
+	for i := 0; i < nms.dimension; i++ {
+		lVec := nms.getLVector(i, s, xStart)
+		vectors[i], err = xStart.Add(lVec)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error adding vector: %w", err)
 		}
+		vectors[i] = nms.bounds.apply(vectors[i])
+		values[i] = nms.targetFunc(vectors[i].Points)
 	}
-	return vectors[minINew]
+	vectors[nms.dimension] = xStart
+	values[nms.dimension] = nms.targetFunc(xStart.Points)
+	return vectors, values, nil
 }
 
-func (nms *NelderMeadSearch) findTetaCurrent(vectors []la_methods.Vector) (float64, error) {
-	min := math.Pi * float64(2)
+func (nms *NelderMeadSearch) checkDegenerate(vectors []la_methods.Vector, minI int) (bool, float64) {
+	var svd mat.SVD
+	size := math.Inf(1)
+	edges := mat.NewDense(nms.dimension, nms.dimension, nil)
+	column := 0
+	for i, vec := range vectors {
+		if i == minI {
+			continue
+		}
+		var length float64
+		for j, p := range vec.Points {
+			edge := p - vectors[minI].Points[j]
+			edges.Set(j, column, edge)
+			length += edge * edge
+		}
+		size = math.Min(size, math.Sqrt(length)) // shortest edge, rebuilt simplex keeps shrinking
+		column++
+	}
+	if !svd.Factorize(edges, mat.SVDNone) {
+		return true, size
+	}
+	values := svd.Values(nil)
+	return values[len(values)-1] <= nms.ratio*values[0] || nms.minAngle(edges) <= nms.teta, size
+}
+
+func (nms *NelderMeadSearch) minAngle(edges *mat.Dense) float64 {
+	min := math.Pi / 2
 	for i := 0; i < nms.dimension; i++ {
-		for j := 0; j < nms.dimension; j++ {
-			for k := 0; k < nms.dimension; k++ {
-				var v float64
-				if i != k && j != k && j != i {
-					xInterI, err := vectors[i].Sub(vectors[k])
-					if err != nil {
-						return 0, fmt.Errorf("error substracting vectors: %v", err)
-					}
-					xInterJ, err := vectors[j].Sub(vectors[k])
-					if err != nil {
-						return 0, fmt.Errorf("error substracting vectors: %v", err)
-					}
-					mulF, err := xInterI.Mul(xInterJ)
-					if err != nil {
-						return 0, fmt.Errorf("error multiplicating vectors: %v", err)
-					}
-					mulS := xInterJ.Len() * xInterI.Len()
-					v = math.Acos(math.Abs(mulF / mulS))
-					if v < min {
-						min = v
-					}
-				}
+		for j := i + 1; j < nms.dimension; j++ {
+			a, b := edges.ColView(i), edges.ColView(j)
+			angle := math.Acos(math.Min(math.Abs(mat.Dot(a, b))/(mat.Norm(a, 2)*mat.Norm(b, 2)), 1))
+			if angle < min {
+				min = angle
 			}
 		}
 	}
-	return min, nil
+	return min
 }
 
 func (nms *NelderMeadSearch) checkStopFirst(minV la_methods.Vector, minVOld la_methods.Vector) (bool, error) {
 	xInter, err := minV.Sub(minVOld)
 	if err != nil {
-		return false, fmt.Errorf("error substracting vectors: %v", err)
+		return false, fmt.Errorf("error substracting vectors: %w", err)
 	}
 	return xInter.Len() <= nms.precision, nil
 }
 
-func (nms *NelderMeadSearch) checkStopSecond(vectors []la_methods.Vector, min float64) bool {
+func (nms *NelderMeadSearch) checkStopSecond(values []float64, min float64) bool {
 	var sum float64
-	for _, vec := range vectors {
-		sum += math.Pow(nms.targetFunc(vec.Points)-min, 2)
+	for _, f := range values {
+		sum += math.Pow(f-min, 2)
 	}
 	sum = math.Sqrt(sum)
 	sum /= float64(nms.dimension + 1)
 	return sum <= nms.precision
 }
 
-func (nms *NelderMeadSearch) reduction(vectors []la_methods.Vector, minV la_methods.Vector, min float64, minI int) ([]la_methods.Vector, error) {
+func (nms *NelderMeadSearch) reduction(vectors []la_methods.Vector, values []float64, minV la_methods.Vector, minI int) ([]la_methods.Vector, []float64, error) {
 	for i, vec := range vectors {
 		if i != minI {
 			xInter, err := vec.Sub(minV)
 			if err != nil {
-				return vectors, fmt.Errorf("error substracting vectors: %v", err)
+				return vectors, values, fmt.Errorf("error substracting vectors: %w", err)
 			}
 			xInter = xInter.MulOnValue(nms.m)
 			xInter, err = minV.Add(xInter)
 			if err != nil {
-				return vectors, fmt.Errorf("error adding vectors: %v", err)
+				return vectors, values, fmt.Errorf("error adding vectors: %w", err)
 			}
 			vectors[i] = xInter
+			values[i] = nms.targetFunc(xInter.Points)
 		}
 	}
-	return vectors, nil
+	return vectors, values, nil
 }
 
 func (nms *NelderMeadSearch) getTestPoint(xC la_methods.Vector, xH la_methods.Vector) (la_methods.Vector, error) {
 	var err error
 	xInter, err := xC.Sub(xH)
 	if err != nil {
-		return la_methods.Vector{}, fmt.Errorf("error substracting vectors: %v", err)
+		return la_methods.Vector{}, fmt.Errorf("error substracting vectors: %w", err)
 	}
 	xInter = xInter.MulOnValue(nms.alpha)
 	xInter, err = xInter.Add(xC)
 	if err != nil {
-		return la_methods.Vector{}, fmt.Errorf("error adding vectors: %v", err)
+		return la_methods.Vector{}, fmt.Errorf("error adding vectors: %w", err)
 	}
-	return xInter, nil
+	return nms.bounds.apply(xInter), nil
 }
 
-func (nms *NelderMeadSearch) findMassCenter(vectors []la_methods.Vector, maxI int) (la_methods.Vector, la_methods.Vector, float64, int, error) {
+func (nms *NelderMeadSearch) findMassCenter(vectors []la_methods.Vector, values []float64, maxI int) (la_methods.Vector, la_methods.Vector, float64, int, error) {
 	var sum la_methods.Vector
 	var err error
 	var maxCI int
 	var max float64
 	if maxI == 0 && nms.dimension > 1 {
 		maxCI = 1
-		max = nms.targetFunc(vectors[1].Points)
-	} else {
-		max = nms.targetFunc(vectors[0].Points)
 	}
+	max = values[maxCI]
 	sum.Init(nms.dimension)
 
 	for i, vec := range vectors {
 		if i != maxI {
 			sum, err = sum.Add(vec)
 			if err != nil {
-				return la_methods.Vector{}, vectors[maxCI], nms.targetFunc(vectors[maxCI].Points), maxCI, fmt.Errorf("error adding vectors: %v", err)
+				return la_methods.Vector{}, vectors[maxCI], max, maxCI, fmt.Errorf("error adding vectors: %w", err)
 			}
-			fInter := nms.targetFunc(vec.Points)
-			if fInter > max {
-				max = fInter
+			if values[i] > max {
+				max = values[i]
 				maxCI = i
 			}
 		}
 	}
 	sum = sum.MulOnValue(float64(1) / float64(nms.dimension))
-	return sum, vectors[maxCI], nms.targetFunc(vectors[maxCI].Points), maxCI, err
+	return sum, vectors[maxCI], max, maxCI, err
 }
 
-func (nms *NelderMeadSearch) findMaxAndMin(vectors []la_methods.Vector) (la_methods.Vector, float64, int, la_methods.Vector, float64, int) {
+func (nms *NelderMeadSearch) findMaxAndMin(vectors []la_methods.Vector, values []float64) (la_methods.Vector, float64, int, la_methods.Vector, float64, int) {
 	var maxI, minI int
 	var max, min float64
-	max = nms.targetFunc(vectors[0].Points)
+	max = values[0]
 	min = max
-	for i, vec := range vectors[1:] {
-		f := nms.targetFunc(vec.Points)
+	for i, f := range values[1:] {
 		if f > max {
 			max = f
 			maxI = i + 1
@@ -0,0 +1,127 @@
+package many_dimension_search
+
+import (
+	"context"
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"github.com/saskamegaprogrammist/optimization_methods/test_functions"
+	"math"
+	"testing"
+)
+
+func shiftedEllipsoid(xs []float64) float64 {
+	var sum float64
+	for i, x := range xs {
+		sum += float64(i+1) * math.Pow(x-1, 2)
+	}
+	return sum
+}
+
+func TestNelderMeadAdaptiveCoefficients(t *testing.T) {
+	dimension := 8
+	start := make([]float64, dimension)
+	problem := Problem{TargetFunc: shiftedEllipsoid, Dimension: dimension, StartPoint: start}
+	settings := DefaultSettings()
+	settings.SimplexSize = 1
+	settings.Eps1 = 1e-8
+	settings.MaxIter = 100000
+	settings.AdaptiveNelderMead = true
+	var nms NelderMeadSearch
+	result, err := nms.SolveProblem(context.Background(), problem, settings)
+	if err != nil {
+		t.Fatalf("error during nelder mead search: %v", err)
+	}
+	if result.F > 1e-4 {
+		t.Errorf("adaptive nelder mead didn't converge: %v, %g", result.X, result.F)
+	}
+}
+
+func TestNelderMeadAdaptiveCoefficientsOneDimension(t *testing.T) {
+	problem := Problem{TargetFunc: shiftedEllipsoid, Dimension: 1, StartPoint: []float64{5}}
+	settings := DefaultSettings()
+	settings.Eps1 = 1e-8
+	settings.AdaptiveNelderMead = true
+	var nms NelderMeadSearch
+	result, err := nms.SolveProblem(context.Background(), problem, settings)
+	if err != nil {
+		t.Fatalf("error during nelder mead search: %v", err)
+	}
+	if math.Abs(result.X[0]-1) > 1e-3 {
+		t.Errorf("adaptive nelder mead didn't converge: %v, %g", result.X, result.F)
+	}
+}
+
+func TestNelderMeadRestarts(t *testing.T) {
+	problem := Problem{TargetFunc: test_functions.Rosenbrock, Dimension: 2, StartPoint: []float64{-1.2, 1}}
+	settings := DefaultSettings()
+	settings.Eps1 = 1e-3
+	settings.MaxIter = 100000
+	var nms NelderMeadSearch
+	single, err := nms.SolveProblem(context.Background(), problem, settings)
+	if err != nil {
+		t.Fatalf("error during nelder mead search: %v", err)
+	}
+	settings.Restarts = 5
+	restarted, err := nms.SolveProblem(context.Background(), problem, settings)
+	if err != nil {
+		t.Fatalf("error during nelder mead search with restarts: %v", err)
+	}
+	if restarted.F > single.F {
+		t.Errorf("restarts worsened minimum: %g > %g", restarted.F, single.F)
+	}
+	if restarted.FuncEvaluations <= single.FuncEvaluations {
+		t.Errorf("restarts didn't evaluate function: %d <= %d", restarted.FuncEvaluations, single.FuncEvaluations)
+	}
+}
+
+func TestNelderMeadWrongCoefficients(t *testing.T) {
+	wrong := []NelderMeadCoefficients{
+		{Alpha: 1, Beta: 1.5, Gamma: 2, M: 0.5},
+		{Alpha: 1, Beta: 0.5, Gamma: 0.5, M: 0.5},
+		{Alpha: 1, Beta: 0.5, Gamma: 2, M: 0.5, Ratio: 1},
+	}
+	for _, coefficients := range wrong {
+		var nms NelderMeadSearch
+		nms.Init([]float64{0, 0}, 0.1, 2, 1e-6, quadratic)
+		nms.SetCoefficients(coefficients)
+		if _, _, err := nms.Solve(); err == nil {
+			t.Errorf("wrong coefficients are accepted: %+v", coefficients)
+		}
+	}
+}
+
+func TestNelderMeadDegenerateSimplex(t *testing.T) {
+	simplexes := map[string][][]float64{
+		"flat":    {{0, 0}, {1, 0.001}, {2, 0}},
+		"regular": {{0, 0}, {1, 0}, {0, 1}},
+	}
+	var nms NelderMeadSearch
+	nms.Init([]float64{0, 0}, 0.1, 2, 1e-6, quadratic)
+	for name, points := range simplexes {
+		vectors := make([]la_methods.Vector, len(points))
+		for i := range points {
+			_ = vectors[i].InitWithPoints(2, points[i])
+		}
+		degenerate, size := nms.checkDegenerate(vectors, 0)
+		if degenerate != (name == "flat") {
+			t.Errorf("%s simplex: degenerate is %t", name, degenerate)
+		}
+		if math.Abs(size-1) > 0.01 {
+			t.Errorf("%s simplex: expected shortest edge 1, got %f", name, size)
+		}
+	}
+}
+
+func TestNelderMeadEvaluationsPerIteration(t *testing.T) {
+	problem := Problem{TargetFunc: test_functions.Rosenbrock, Dimension: 2, StartPoint: []float64{-1.2, 1}}
+	settings := DefaultSettings()
+	settings.Eps1 = 1e-6
+	settings.MaxIter = 100000
+	var nms NelderMeadSearch
+	result, err := nms.SolveProblem(context.Background(), problem, settings)
+	if err != nil {
+		t.Fatalf("error during nelder mead search: %v", err)
+	}
+	if result.FuncEvaluations > 3*(result.Iterations+1) {
+		t.Errorf("simplex vertices are reevaluated: %d evaluations in %d iterations", result.FuncEvaluations, result.Iterations)
+	}
+}
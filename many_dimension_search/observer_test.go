@@ -0,0 +1,73 @@
+package many_dimension_search
+
+import (
+	"context"
+	"testing"
+)
+
+func TestObserverTrajectory(t *testing.T) {
+	problem := Problem{TargetFunc: quadratic, Dimension: 2, StartPoint: []float64{0, 0}}
+	for name, solver := range solvers() {
+		var recorder Recorder
+		settings := DefaultSettings()
+		settings.Observer = recorder.Observe
+		result, err := solver.SolveProblem(context.Background(), problem, settings)
+		if err != nil {
+			t.Fatalf("%s: error solving problem: %v", name, err)
+		}
+		if len(recorder.Trajectory) == 0 {
+			t.Fatalf("%s: observer isn't called", name)
+		}
+		iteration, evaluations := -1, 0
+		for i, info := range recorder.Trajectory {
+			if info.Iteration <= iteration {
+				t.Errorf("%s: iteration %d is reported after %d", name, info.Iteration, iteration)
+			}
+			iteration = info.Iteration
+			if info.F != quadratic(info.X) {
+				t.Errorf("%s: iteration %d value %g isn't function value %g", name, i, info.F, quadratic(info.X))
+			}
+			if info.FuncEvaluations < evaluations {
+				t.Errorf("%s: iteration %d evaluations decreased", name, i)
+			}
+			evaluations = info.FuncEvaluations
+		}
+		if evaluations > result.FuncEvaluations {
+			t.Errorf("%s: observed evaluations %d exceed result evaluations %d", name, evaluations, result.FuncEvaluations)
+		}
+	}
+}
+
+func TestObserverDoesNotEvaluate(t *testing.T) {
+	for name, solver := range solvers() {
+		if _, ok := solver.(*SimulatedAnnealingSearch); ok {
+			continue // unseeded proposals differ between runs
+		}
+		var calls [2]int
+		for i, observer := range []Observer{nil, func(info IterationInfo) {}} {
+			problem := Problem{Dimension: 2, StartPoint: []float64{0, 0}}
+			problem.TargetFunc = func(xs []float64) float64 {
+				calls[i]++
+				return quadratic(xs)
+			}
+			settings := DefaultSettings()
+			settings.Observer = observer
+			_, err := solver.SolveProblem(context.Background(), problem, settings)
+			if err != nil {
+				t.Fatalf("%s: error solving problem: %v", name, err)
+			}
+		}
+		if calls[0] != calls[1] {
+			t.Errorf("%s: observer changed target function calls from %d to %d", name, calls[0], calls[1])
+		}
+	}
+}
+
+func TestRecorderReset(t *testing.T) {
+	var recorder Recorder
+	recorder.Observe(IterationInfo{Iteration: 0, X: []float64{1}})
+	recorder.Reset()
+	if len(recorder.Trajectory) != 0 {
+		t.Errorf("recorder isn't reset: %v", recorder.Trajectory)
+	}
+}
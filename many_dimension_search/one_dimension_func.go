@@ -0,0 +1,28 @@
+package many_dimension_search
+
+import (
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+)
+
+func getOneDimensionFunc(targetFunc func(xs []float64) float64, d la_methods.Vector, y la_methods.Vector) func(x float64) float64 {
+	return func(x float64) float64 {
+		aplhaD := d.MulOnValue(x)
+		sumYAlphD, _ := y.Add(aplhaD)
+		return targetFunc(sumYAlphD.Points)
+	}
+}
+
+func getOneDimensionDerivative(gradient []func(xs []float64) float64, d la_methods.Vector, y la_methods.Vector) func(x float64) float64 {
+	if len(gradient) == 0 {
+		return nil
+	}
+	return func(x float64) float64 {
+		var sum float64
+		aplhaD := d.MulOnValue(x)
+		sumYAlphD, _ := y.Add(aplhaD)
+		for i, g := range gradient {
+			sum += g(sumYAlphD.Points) * d.Points[i]
+		}
+		return sum
+	}
+}
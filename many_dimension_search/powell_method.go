@@ -0,0 +1,154 @@
+package many_dimension_search
+
+import (
+	"context"
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"github.com/saskamegaprogrammist/optimization_methods/line_search"
+	"math"
+	"time"
+)
+
+type PowellSearch struct {
+	startPoint []float64
+	eps1       float64 // step precision
+	eps2       float64 // function precision
+	dimension  int
+	targetFunc func(xs []float64) float64
+	lineSearch line_search.LineSearch
+	maxIter    int
+	solverStats
+}
+
+func (ps *PowellSearch) Init(startPoint []float64, dimension int, eps1 float64, eps2 float64, maxIter int,
+	targetFunc func(xs []float64) float64, lineSearch line_search.LineSearch) {
+	ps.startPoint = startPoint
+	ps.dimension = dimension
+	ps.eps1 = eps1
+	ps.eps2 = eps2
+	ps.maxIter = maxIter
+	ps.targetFunc = ps.countFunc(targetFunc)
+	ps.lineSearch = lineSearch
+}
+
+func (ps *PowellSearch) SolveProblem(ctx context.Context, problem Problem, settings Settings) (Result, error) {
+	err := problem.check()
+	if err != nil {
+		return Result{}, fmt.Errorf("error checking problem: %w", err)
+	}
+	timeStart := time.Now()
+	ps.Init(problem.StartPoint, problem.Dimension, settings.Eps1, settings.Eps2, settings.MaxIter,
+		problem.TargetFunc, settings.LineSearch)
+	ps.SetObserver(settings.Observer)
+	ps.SetLimits(Limits{MaxIterations: settings.MaxIter, MaxFuncEvaluations: settings.MaxFuncEvals, MaxTime: settings.MaxTime})
+	xMin, yMin, err := ps.SolveContext(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	return ps.result(xMin, yMin, timeStart), nil
+}
+
+func (ps *PowellSearch) Solve() ([]float64, float64, error) {
+	return ps.SolveContext(context.Background())
+}
+
+func (ps *PowellSearch) SolveContext(ctx context.Context) ([]float64, float64, error) {
+	var err error
+	var x, xOld, xSub, xExtra la_methods.Vector
+	var f, fNew, fOld, fExtra, decrease, maxDecrease float64
+	var maxI, k int
+	ps.reset(ctx, ps.startPoint)
+	if ps.lineSearch == nil {
+		return []float64{}, 0, fmt.Errorf("one dimensional method is not set")
+	}
+	err = x.InitWithPoints(ps.dimension, ps.startPoint)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error during vector initializing: %w", err)
+	}
+	f = ps.targetFunc(x.Points)
+	directions := unitDirections(ps.dimension)
+	for {
+		if ps.interrupted(k) {
+			return ps.bestPoint()
+		}
+		if k >= ps.maxIter {
+			ps.finish(k, MaxIterationsReached)
+			return x.Points, f, nil
+		}
+		if k%(ps.dimension+1) == 0 {
+			directions = unitDirections(ps.dimension) // directions become linearly dependent
+		}
+		xOld, fOld = x, f
+		maxDecrease, maxI = 0, 0
+		for i, d := range directions {
+			x, fNew, err = ps.searchAlong(ps.targetFunc, ps.lineSearch, x, f, d)
+			if err != nil {
+				if ps.interrupted(k) {
+					return ps.bestPoint()
+				}
+				return nil, 0, fmt.Errorf("error searching along direction %d: %w", i, err)
+			}
+			decrease = f - fNew
+			f = fNew
+			if decrease > maxDecrease {
+				maxDecrease, maxI = decrease, i
+			}
+		}
+		xSub, err = x.Sub(xOld)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during vector substracting: %w", err)
+		}
+		xExtra, err = x.Add(xSub)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during vector adding: %w", err)
+		}
+		fExtra = ps.targetFunc(xExtra.Points)
+		if fExtra < fOld && 2*(fOld-2*f+fExtra)*math.Pow(fOld-f-maxDecrease, 2) < maxDecrease*math.Pow(fOld-fExtra, 2) {
+			x, f, err = ps.searchAlong(ps.targetFunc, ps.lineSearch, x, f, xSub)
+			if err != nil {
+				if ps.interrupted(k) {
+					return ps.bestPoint()
+				}
+				return nil, 0, fmt.Errorf("error searching along new direction: %w", err)
+			}
+			directions = append(directions[:maxI], directions[maxI+1:]...)
+			directions = append(directions, xSub.MulOnValue(1/xSub.Len()))
+		}
+		step, err := x.EqDist(xOld)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error calculating step: %w", err)
+		}
+		ps.notify(k, x.Points, f, 0, step)
+		k++
+		if step < ps.eps1 && math.Abs(fOld-f) < ps.eps2 {
+			ps.finish(k, StepConverged)
+			return x.Points, f, nil
+		}
+	}
+}
+
+func (ss *solverStats) searchAlong(targetFunc func(xs []float64) float64, lineSearch line_search.LineSearch,
+	x la_methods.Vector, f float64, d la_methods.Vector) (la_methods.Vector, float64, error) {
+	alpha, err := ss.search(lineSearch, getOneDimensionFunc(targetFunc, d, x), nil, 0)
+	if err != nil {
+		return la_methods.Vector{}, 0, fmt.Errorf("error during one dimension search: %w", err)
+	}
+	xNew, err := x.Add(d.MulOnValue(alpha))
+	if err != nil {
+		return la_methods.Vector{}, 0, fmt.Errorf("error during vector adding: %w", err)
+	}
+	fNew := targetFunc(xNew.Points)
+	if fNew >= f {
+		return x, f, nil // line search didn't improve the point
+	}
+	return xNew, fNew, nil
+}
+
+func unitDirections(dimension int) []la_methods.Vector {
+	directions := make([]la_methods.Vector, dimension)
+	for i := range directions {
+		directions[i].Init(dimension)
+		directions[i].Points[i] = 1
+	}
+	return directions
+}
@@ -0,0 +1,64 @@
+package many_dimension_search
+
+import (
+	"github.com/saskamegaprogrammist/optimization_methods/line_search"
+	"github.com/saskamegaprogrammist/optimization_methods/test_functions"
+	"math"
+	"testing"
+)
+
+func rotatedQuadratic(xs []float64) float64 {
+	return math.Pow(xs[0]+xs[1]-3, 2) + 5*math.Pow(xs[0]-xs[1]+1, 2) + xs[2]*xs[2]
+}
+
+type directionSearch interface {
+	Init(startPoint []float64, dimension int, eps1 float64, eps2 float64, maxIter int,
+		targetFunc func(xs []float64) float64, lineSearch line_search.LineSearch)
+	Solve() ([]float64, float64, error)
+}
+
+func directionSearches() map[string]directionSearch {
+	return map[string]directionSearch{
+		"powell":     &PowellSearch{},
+		"rosenbrock": &RosenbrockSearch{},
+	}
+}
+
+func TestDirectionSearchesRotatedQuadratic(t *testing.T) {
+	var gr line_search.GoldenRatioSearch
+	gr.Init(0.01, 1e-8)
+	for name, search := range directionSearches() {
+		search.Init([]float64{5, -4, 2}, 3, 1e-8, 1e-12, 1000, rotatedQuadratic, &gr)
+		x, f, err := search.Solve()
+		if err != nil {
+			t.Fatalf("error during %s search: %v", name, err)
+		}
+		if distanceTo(x, []float64{1, 2, 0}) > 1e-4 || f > 1e-8 {
+			t.Errorf("%s: expected minimum at [1 2 0], got %v, %g", name, x, f)
+		}
+	}
+}
+
+func TestDirectionSearchesRosenbrock(t *testing.T) {
+	var gr line_search.GoldenRatioSearch
+	gr.Init(0.01, 1e-10)
+	for name, search := range directionSearches() {
+		search.Init([]float64{-1.2, 1}, 2, 1e-10, 1e-14, 10000, test_functions.Rosenbrock, &gr)
+		x, _, err := search.Solve()
+		if err != nil {
+			t.Fatalf("error during %s search: %v", name, err)
+		}
+		if distanceTo(x, []float64{1, 1}) > 1e-3 {
+			t.Errorf("%s: expected minimum at [1 1], got %v", name, x)
+		}
+	}
+}
+
+func TestDirectionSearchesRequireLineSearch(t *testing.T) {
+	for name, search := range directionSearches() {
+		search.Init([]float64{-1.2, 1}, 2, 1e-6, 1e-6, 100, test_functions.Rosenbrock, nil)
+		if _, _, err := search.Solve(); err == nil {
+			t.Errorf("%s: missing line search is accepted", name)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package many_dimension_search
+
+import (
+	"context"
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"math"
+	"time"
+)
+
+const (
+	armijoDecrease   = 0.0001
+	maxBacktrackings = 50
+)
+
+type ProjectedGradientSearch struct {
+	startPoint []float64
+	eps1       float64
+	eps2       float64
+	dimension  int
+	targetFunc func(xs []float64) float64
+	gradient   []func(xs []float64) float64
+	bounds     Bounds
+	maxIter    int
+	solverStats
+}
+
+func (pgs *ProjectedGradientSearch) Init(startPoint []float64, dimension int,
+	eps1 float64, eps2 float64, maxIter int, targetFunc func(xs []float64) float64,
+	gradient []func(xs []float64) float64, bounds Bounds) {
+	pgs.startPoint = startPoint
+	pgs.eps1 = eps1
+	pgs.eps2 = eps2
+	pgs.targetFunc = pgs.countFunc(targetFunc)
+	pgs.dimension = dimension
+	pgs.gradient = pgs.countGradient(numericalGradient(targetFunc, gradient, dimension))
+	pgs.bounds = bounds
+	pgs.maxIter = maxIter
+}
+
+func (pgs *ProjectedGradientSearch) SolveProblem(ctx context.Context, problem Problem, settings Settings) (Result, error) {
+	err := problem.checkBounded()
+	if err != nil {
+		return Result{}, fmt.Errorf("error checking problem: %w", err)
+	}
+	timeStart := time.Now()
+	pgs.Init(problem.StartPoint, problem.Dimension, settings.Eps1, settings.Eps2, settings.MaxIter,
+		problem.TargetFunc, problem.Gradient, problem.Bounds)
+	pgs.SetObserver(settings.Observer)
+	pgs.SetLimits(Limits{MaxIterations: settings.MaxIter, MaxFuncEvaluations: settings.MaxFuncEvals, MaxTime: settings.MaxTime})
+	xMin, yMin, err := pgs.SolveContext(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	return pgs.result(xMin, yMin, timeStart), nil
+}
+
+func (pgs *ProjectedGradientSearch) Solve() ([]float64, float64, error) {
+	return pgs.SolveContext(context.Background())
+}
+
+func (pgs *ProjectedGradientSearch) SolveContext(ctx context.Context) ([]float64, float64, error) {
+	var err error
+	var x, xOld, xSub la_methods.Vector
+	var grad, gradOld, gradSub, projGrad la_methods.Vector
+	var f, fOld, alpha, ss, sy float64
+	var k int
+	var ok bool
+	err = pgs.bounds.check(pgs.dimension)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error checking bounds: %w", err)
+	}
+	err = x.InitWithPoints(pgs.dimension, pgs.startPoint)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error during vector initializing: %w", err)
+	}
+	x = pgs.bounds.project(x)
+	err = checkDerivatives(pgs.targetFunc, pgs.gradient, nil, x.Points)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error checking derivatives: %w", err)
+	}
+	pgs.reset(ctx, x.Points)
+	f = pgs.targetFunc(x.Points)
+	grad, err = calculateGradient(pgs.gradient, x)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error calculcating gradient: %w", err)
+	}
+	alpha = 1 / math.Max(grad.Len(), 1)
+	for {
+		if pgs.interrupted(k) {
+			return pgs.bestPoint()
+		}
+		projGrad, err = pgs.bounds.projectedGradient(x, grad)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error calculating projected gradient: %w", err)
+		}
+		if projGrad.Len() < pgs.eps1 {
+			pgs.finish(k, GradientConverged)
+			return x.Points, f, nil
+		}
+		if k >= pgs.maxIter {
+			pgs.finish(k, MaxIterationsReached)
+			return x.Points, f, nil
+		}
+		xOld, fOld, gradOld = x, f, grad
+		x, f, ok, err = projectedBacktracking(pgs.targetFunc, pgs.bounds, x, f, grad, grad.MulOnValue(-1), alpha)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during projected search: %w", err)
+		}
+		if !ok {
+			pgs.finish(k, StepConverged)
+			return xOld.Points, fOld, nil
+		}
+		grad, err = calculateGradient(pgs.gradient, x)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error calculcating gradient: %w", err)
+		}
+		xSub, err = x.Sub(xOld)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during vector substracting: %w", err)
+		}
+		gradSub, err = grad.Sub(gradOld)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during vector substracting: %w", err)
+		}
+		ss, err = xSub.Mul(xSub)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during vector multiplying: %w", err)
+		}
+		sy, err = xSub.Mul(gradSub)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during vector multiplying: %w", err)
+		}
+		alpha = 1 / math.Max(grad.Len(), 1)
+		if sy > 0 {
+			alpha = ss / sy // barzilai borwein step
+		}
+		pgs.notify(k, x.Points, f, grad.Len(), xSub.Len())
+		k++
+		if xSub.Len() < pgs.eps2 && math.Abs(f-fOld) < pgs.eps2 {
+			pgs.finish(k, StepConverged)
+			return x.Points, f, nil
+		}
+	}
+}
+
+func projectedBacktracking(targetFunc func(xs []float64) float64, bounds Bounds, x la_methods.Vector, f float64,
+	grad la_methods.Vector, d la_methods.Vector, alpha float64) (la_methods.Vector, float64, bool, error) {
+	for i := 0; i < maxBacktrackings; i++ {
+		xNew, err := x.Add(d.MulOnValue(alpha))
+		if err != nil {
+			return la_methods.Vector{}, 0, false, fmt.Errorf("error during vector adding: %w", err)
+		}
+		xNew = bounds.project(xNew)
+		xSub, err := xNew.Sub(x)
+		if err != nil {
+			return la_methods.Vector{}, 0, false, fmt.Errorf("error during vector substracting: %w", err)
+		}
+		gs, err := grad.Mul(xSub)
+		if err != nil {
+			return la_methods.Vector{}, 0, false, fmt.Errorf("error during vector multiplying: %w", err)
+		}
+		fNew := targetFunc(xNew.Points)
+		if gs < 0 && fNew <= f+armijoDecrease*gs {
+			return xNew, fNew, true, nil
+		}
+		alpha /= 2
+	}
+	return x, f, false, nil
+}
@@ -0,0 +1,59 @@
+package many_dimension_search
+
+import (
+	"github.com/saskamegaprogrammist/optimization_methods/test_functions"
+	"math"
+	"testing"
+)
+
+type boundedGradientSearch interface {
+	Solve() ([]float64, float64, error)
+}
+
+func boundedGradientSearches(startPoint []float64, targetFunc func(xs []float64) float64,
+	gradient []func(xs []float64) float64, bounds Bounds) map[string]boundedGradientSearch {
+	var pgs ProjectedGradientSearch
+	pgs.Init(startPoint, len(startPoint), 1e-8, 1e-14, 10000, targetFunc, gradient, bounds)
+	var lbfgsb LBFGSBSearch
+	lbfgsb.Init(startPoint, len(startPoint), 1e-8, 1e-14, 10000, targetFunc, gradient, bounds, 5)
+	return map[string]boundedGradientSearch{"projected gradient": &pgs, "l-bfgs-b": &lbfgsb}
+}
+
+func TestBoundedGradientSearchesActiveBound(t *testing.T) {
+	bounds := Bounds{Lower: []float64{math.Inf(-1), -1}, Upper: []float64{0.5, math.Inf(1)}}
+	for name, search := range boundedGradientSearches([]float64{-1.2, 1}, test_functions.Rosenbrock, test_functions.RosenbrockGradient(2), bounds) {
+		x, _, err := search.Solve()
+		if err != nil {
+			t.Fatalf("error during %s search: %v", name, err)
+		}
+		if distanceTo(x, []float64{0.5, 0.25}) > 1e-4 {
+			t.Errorf("%s: expected minimum at [0.5 0.25], got %v", name, x)
+		}
+	}
+}
+
+func TestBoundedGradientSearchesInnerMinimum(t *testing.T) {
+	bounds := Bounds{Lower: []float64{-5, -5}, Upper: []float64{5, 5}}
+	for name, search := range boundedGradientSearches([]float64{4, 4}, quadratic, nil, bounds) {
+		x, _, err := search.Solve()
+		if err != nil {
+			t.Fatalf("error during %s search: %v", name, err)
+		}
+		if distanceTo(x, []float64{1, -2}) > 1e-4 {
+			t.Errorf("%s: expected minimum at [1 -2], got %v", name, x)
+		}
+	}
+}
+
+func TestBoundedGradientSearchesStayInBounds(t *testing.T) {
+	bounds := Bounds{Lower: []float64{2, 0}, Upper: []float64{3, 1}}
+	for name, search := range boundedGradientSearches([]float64{10, -10}, quadratic, nil, bounds) {
+		x, _, err := search.Solve()
+		if err != nil {
+			t.Fatalf("error during %s search: %v", name, err)
+		}
+		if !inBounds(x, bounds) || distanceTo(x, []float64{2, 0}) > 1e-6 {
+			t.Errorf("%s: expected minimum at corner [2 0], got %v", name, x)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package many_dimension_search
+
+import (
+	"context"
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"github.com/saskamegaprogrammist/optimization_methods/line_search"
+	"math"
+	"time"
+)
+
+type RosenbrockSearch struct {
+	startPoint []float64
+	eps1       float64 // step precision
+	eps2       float64 // function precision
+	dimension  int
+	targetFunc func(xs []float64) float64
+	lineSearch line_search.LineSearch
+	maxIter    int
+	solverStats
+}
+
+func (rs *RosenbrockSearch) Init(startPoint []float64, dimension int, eps1 float64, eps2 float64, maxIter int,
+	targetFunc func(xs []float64) float64, lineSearch line_search.LineSearch) {
+	rs.startPoint = startPoint
+	rs.dimension = dimension
+	rs.eps1 = eps1
+	rs.eps2 = eps2
+	rs.maxIter = maxIter
+	rs.targetFunc = rs.countFunc(targetFunc)
+	rs.lineSearch = lineSearch
+}
+
+func (rs *RosenbrockSearch) SolveProblem(ctx context.Context, problem Problem, settings Settings) (Result, error) {
+	err := problem.check()
+	if err != nil {
+		return Result{}, fmt.Errorf("error checking problem: %w", err)
+	}
+	timeStart := time.Now()
+	rs.Init(problem.StartPoint, problem.Dimension, settings.Eps1, settings.Eps2, settings.MaxIter,
+		problem.TargetFunc, settings.LineSearch)
+	rs.SetObserver(settings.Observer)
+	rs.SetLimits(Limits{MaxIterations: settings.MaxIter, MaxFuncEvaluations: settings.MaxFuncEvals, MaxTime: settings.MaxTime})
+	xMin, yMin, err := rs.SolveContext(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	return rs.result(xMin, yMin, timeStart), nil
+}
+
+func (rs *RosenbrockSearch) Solve() ([]float64, float64, error) {
+	return rs.SolveContext(context.Background())
+}
+
+func (rs *RosenbrockSearch) SolveContext(ctx context.Context) ([]float64, float64, error) {
+	var err error
+	var x, xOld, xNew, xSub la_methods.Vector
+	var f, fNew, fOld float64
+	var k int
+	rs.reset(ctx, rs.startPoint)
+	if rs.lineSearch == nil {
+		return []float64{}, 0, fmt.Errorf("one dimensional method is not set")
+	}
+	err = x.InitWithPoints(rs.dimension, rs.startPoint)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error during vector initializing: %w", err)
+	}
+	f = rs.targetFunc(x.Points)
+	directions := unitDirections(rs.dimension)
+	steps := make([]float64, rs.dimension)
+	for {
+		if rs.interrupted(k) {
+			return rs.bestPoint()
+		}
+		if k >= rs.maxIter {
+			rs.finish(k, MaxIterationsReached)
+			return x.Points, f, nil
+		}
+		xOld, fOld = x, f
+		for i, d := range directions {
+			xNew, fNew, err = rs.searchAlong(rs.targetFunc, rs.lineSearch, x, f, d)
+			if err != nil {
+				if rs.interrupted(k) {
+					return rs.bestPoint()
+				}
+				return nil, 0, fmt.Errorf("error searching along direction %d: %w", i, err)
+			}
+			xSub, err = xNew.Sub(x)
+			if err != nil {
+				return nil, 0, fmt.Errorf("error during vector substracting: %w", err)
+			}
+			steps[i], err = xSub.Mul(d)
+			if err != nil {
+				return nil, 0, fmt.Errorf("error during vector multiplying: %w", err)
+			}
+			x, f = xNew, fNew
+		}
+		step, err := x.EqDist(xOld)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error calculating step: %w", err)
+		}
+		rs.notify(k, x.Points, f, 0, step)
+		k++
+		if step < rs.eps1 && math.Abs(fOld-f) < rs.eps2 {
+			rs.finish(k, StepConverged)
+			return x.Points, f, nil
+		}
+		directions, err = rs.rotate(directions, steps)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error rotating directions: %w", err)
+		}
+	}
+}
+
+func (rs *RosenbrockSearch) rotate(directions []la_methods.Vector, steps []float64) ([]la_methods.Vector, error) {
+	var err error
+	rotated := make([]la_methods.Vector, rs.dimension)
+	for j := range directions {
+		a := directions[j].Copy()
+		if steps[j] != 0 {
+			a.Init(rs.dimension)
+			for i := j; i < rs.dimension; i++ {
+				a, err = a.Add(directions[i].MulOnValue(steps[i]))
+				if err != nil {
+					return nil, fmt.Errorf("error during vector adding: %w", err)
+				}
+			}
+		}
+		length := a.Len()
+		for i := 0; i < j; i++ { // gram schmidt orthogonalization
+			ae, err := a.Mul(rotated[i])
+			if err != nil {
+				return nil, fmt.Errorf("error during vector multiplying: %w", err)
+			}
+			a, err = a.Sub(rotated[i].MulOnValue(ae))
+			if err != nil {
+				return nil, fmt.Errorf("error during vector substracting: %w", err)
+			}
+		}
+		if a.Len() <= activeTolerance*length {
+			return unitDirections(rs.dimension), nil
+		}
+		rotated[j] = a.MulOnValue(1 / a.Len())
+	}
+	return rotated, nil
+}
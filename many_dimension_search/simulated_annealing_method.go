@@ -0,0 +1,220 @@
+package many_dimension_search
+
+import (
+	"context"
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"github.com/saskamegaprogrammist/optimization_methods/random_points_gen"
+	"math"
+	"math/rand"
+	"time"
+)
+
+type CoolingSchedule string
+
+const (
+	BoltzmannCooling   CoolingSchedule = "boltzmann"   // t0 / ln(k + e)
+	CauchyCooling      CoolingSchedule = "cauchy"      // t0 / (k + 1)
+	ExponentialCooling CoolingSchedule = "exponential" // t0 * rate^k
+)
+
+const (
+	adaptInterval    = 20 // proposals between neighbourhood adaptations
+	acceptanceHigh   = 0.6
+	acceptanceLow    = 0.4
+	stepAdaptation   = 2
+	reheatStagnation = 500 // proposals without best point improvement
+)
+
+type SimulatedAnnealingSearch struct {
+	startPoint     []float64
+	dimension      int
+	targetFunc     func(xs []float64) float64
+	temperature    float64 // initial temperature
+	minTemperature float64
+	schedule       CoolingSchedule
+	coolingRate    float64 // exponential schedule rate
+	step           float64 // initial neighbourhood size
+	maxIter        int
+	reheatAfter    int // zero disables reheat
+	maxReheats     int
+	reheats        int
+	bounds         Bounds
+	generator      random_points_gen.NeighbourhoodGen
+	random         *rand.Rand
+	solverStats
+}
+
+func (sa *SimulatedAnnealingSearch) Init(startPoint []float64, dimension int, targetFunc func(xs []float64) float64,
+	temperature float64, minTemperature float64, schedule CoolingSchedule, coolingRate float64,
+	step float64, maxIter int) {
+	sa.startPoint = startPoint
+	sa.dimension = dimension
+	sa.targetFunc = sa.countFunc(targetFunc)
+	sa.temperature = temperature
+	sa.minTemperature = minTemperature
+	sa.schedule = schedule
+	sa.coolingRate = coolingRate
+	sa.step = step
+	sa.maxIter = maxIter
+	sa.reheatAfter = 0
+	sa.maxReheats = 0
+	sa.random = rand.New(rand.NewSource(time.Now().UnixNano()))
+	switch schedule {
+	case BoltzmannCooling:
+		sa.SetProposal(random_points_gen.Normal)
+	case CauchyCooling:
+		sa.SetProposal(random_points_gen.Cauchy)
+	default:
+		sa.SetProposal(random_points_gen.Uniform)
+	}
+}
+
+func (sa *SimulatedAnnealingSearch) SetSeed(seed int64) {
+	sa.random = rand.New(rand.NewSource(seed))
+	sa.generator.SetRandom(sa.random)
+}
+
+func (sa *SimulatedAnnealingSearch) SetBounds(bounds Bounds) {
+	sa.bounds = bounds
+}
+
+func (sa *SimulatedAnnealingSearch) SetProposal(distribution random_points_gen.Distribution) {
+	sa.generator.Init(distribution)
+	sa.generator.SetRandom(sa.random)
+}
+
+func (sa *SimulatedAnnealingSearch) SetReheat(after int, maxReheats int) {
+	sa.reheatAfter = after
+	sa.maxReheats = maxReheats
+}
+
+func (sa *SimulatedAnnealingSearch) Reheats() int {
+	return sa.reheats
+}
+
+func (sa *SimulatedAnnealingSearch) SolveProblem(ctx context.Context, problem Problem, settings Settings) (Result, error) {
+	err := problem.checkBounded()
+	if err != nil {
+		return Result{}, fmt.Errorf("error checking problem: %w", err)
+	}
+	timeStart := time.Now()
+	sa.Init(problem.StartPoint, problem.Dimension, problem.TargetFunc, settings.Temperature, settings.MinTemperature,
+		settings.Cooling, settings.CoolingRate, settings.ExploreStep, settings.MaxIter)
+	if settings.Seed != 0 {
+		sa.SetSeed(settings.Seed)
+	}
+	sa.SetBounds(problem.Bounds)
+	sa.SetReheat(reheatStagnation, settings.Restarts)
+	sa.SetObserver(settings.Observer)
+	sa.SetLimits(Limits{MaxIterations: settings.MaxIter, MaxFuncEvaluations: settings.MaxFuncEvals, MaxTime: settings.MaxTime})
+	xMin, yMin, err := sa.SolveContext(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	return sa.result(xMin, yMin, timeStart), nil
+}
+
+func (sa *SimulatedAnnealingSearch) Solve() ([]float64, float64, error) {
+	return sa.SolveContext(context.Background())
+}
+
+func (sa *SimulatedAnnealingSearch) SolveContext(ctx context.Context) ([]float64, float64, error) {
+	var x la_methods.Vector
+	var k, tk, accepted, stagnation int
+	if sa.temperature <= 0 || sa.step <= 0 {
+		return nil, 0, fmt.Errorf("wrong annealing parameters: %f, %f", sa.temperature, sa.step)
+	}
+	if sa.schedule == ExponentialCooling && (sa.coolingRate <= 0 || sa.coolingRate >= 1) {
+		return nil, 0, fmt.Errorf("wrong cooling rate: %f", sa.coolingRate)
+	}
+	err := x.InitWithPoints(sa.dimension, sa.startPoint)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error during vector initializing: %w", err)
+	}
+	x = sa.bounds.apply(x)
+	sa.reset(ctx, x.Points)
+	sa.reheats = 0
+	steps := make([]float64, sa.dimension)
+	for i := range steps {
+		steps[i] = sa.step
+	}
+	f := sa.targetFunc(x.Points)
+	xBest, fBest := x.Copy(), f
+	for {
+		if sa.interrupted(k) {
+			return xBest.Points, fBest, nil
+		}
+		if k >= sa.maxIter {
+			sa.finish(k, MaxIterationsReached)
+			return xBest.Points, fBest, nil
+		}
+		t, err := sa.cool(tk)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error cooling: %w", err)
+		}
+		if t < sa.minTemperature {
+			sa.finish(k, TemperatureConverged)
+			return xBest.Points, fBest, nil
+		}
+		var y la_methods.Vector
+		err = y.InitWithPoints(sa.dimension, sa.generator.Generate(x.Points, steps))
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during vector initializing: %w", err)
+		}
+		y = sa.bounds.apply(y)
+		fY := sa.targetFunc(y.Points)
+		var move float64
+		if fY <= f || sa.random.Float64() < math.Exp(-(fY-f)/t) {
+			d, err := y.Sub(x)
+			if err != nil {
+				return nil, 0, fmt.Errorf("error during vector substracting: %w", err)
+			}
+			move = d.Len()
+			x, f = y, fY
+			accepted++
+		}
+		stagnation++
+		if f < fBest {
+			xBest, fBest = x.Copy(), f
+			stagnation = 0
+		}
+		if (k+1)%adaptInterval == 0 {
+			adaptSteps(steps, float64(accepted)/adaptInterval)
+			accepted = 0
+		}
+		tk++
+		if sa.reheatAfter > 0 && stagnation >= sa.reheatAfter && sa.reheats < sa.maxReheats {
+			x, f = xBest.Copy(), fBest // reheat from best point
+			for i := range steps {
+				steps[i] = sa.step
+			}
+			tk, stagnation = 0, 0
+			sa.reheats++
+		}
+		sa.notify(k, x.Points, f, 0, move)
+		k++
+	}
+}
+
+func (sa *SimulatedAnnealingSearch) cool(k int) (float64, error) {
+	switch sa.schedule {
+	case BoltzmannCooling:
+		return sa.temperature / math.Log(float64(k)+math.E), nil
+	case CauchyCooling:
+		return sa.temperature / float64(k+1), nil
+	case ExponentialCooling:
+		return sa.temperature * math.Pow(sa.coolingRate, float64(k)), nil
+	}
+	return 0, fmt.Errorf("unknown cooling schedule: %s", sa.schedule)
+}
+
+func adaptSteps(steps []float64, acceptance float64) {
+	for i := range steps {
+		if acceptance > acceptanceHigh {
+			steps[i] *= 1 + stepAdaptation*(acceptance-acceptanceHigh)/acceptanceLow
+		} else if acceptance < acceptanceLow {
+			steps[i] /= 1 + stepAdaptation*(acceptanceLow-acceptance)/acceptanceLow
+		}
+	}
+}
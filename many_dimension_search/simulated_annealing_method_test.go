@@ -0,0 +1,88 @@
+package many_dimension_search
+
+import (
+	"context"
+	"github.com/saskamegaprogrammist/optimization_methods/test_functions"
+	"testing"
+)
+
+func TestSimulatedAnnealingSchedules(t *testing.T) {
+	for _, schedule := range []CoolingSchedule{BoltzmannCooling, CauchyCooling, ExponentialCooling} {
+		var sa SimulatedAnnealingSearch
+		sa.Init([]float64{4, 4}, 2, quadratic, 10, 1e-8, schedule, 0.995, 0.5, 20000)
+		sa.SetSeed(1)
+		x, f, err := sa.Solve()
+		if err != nil {
+			t.Fatalf("error during %s annealing: %v", schedule, err)
+		}
+		if distanceTo(x, []float64{1, -2}) > 0.1 || f != quadratic(x) {
+			t.Errorf("%s: expected minimum at [1 -2], got %v, %g", schedule, x, f)
+		}
+	}
+}
+
+func TestSimulatedAnnealingSeed(t *testing.T) {
+	run := func(seed int64) ([]float64, float64) {
+		var sa SimulatedAnnealingSearch
+		sa.Init([]float64{4, 4}, 2, test_functions.Rosenbrock, 1, 1e-6, ExponentialCooling, 0.99, 0.5, 2000)
+		sa.SetSeed(seed)
+		x, f, err := sa.Solve()
+		if err != nil {
+			t.Fatalf("error during annealing: %v", err)
+		}
+		return x, f
+	}
+	x1, f1 := run(42)
+	x2, f2 := run(42)
+	if f1 != f2 || x1[0] != x2[0] || x1[1] != x2[1] {
+		t.Errorf("seeded runs differ: %v, %g and %v, %g", x1, f1, x2, f2)
+	}
+	x3, _ := run(43)
+	if x1[0] == x3[0] && x1[1] == x3[1] {
+		t.Errorf("different seeds give same point %v", x1)
+	}
+}
+
+func TestSimulatedAnnealingSettingsSeed(t *testing.T) {
+	problem := Problem{TargetFunc: test_functions.Rosenbrock, Dimension: 2, StartPoint: []float64{4, 4}}
+	settings := DefaultSettings()
+	settings.Seed = 42
+	var sa SimulatedAnnealingSearch
+	first, err := sa.SolveProblem(context.Background(), problem, settings)
+	if err != nil {
+		t.Fatalf("error during annealing: %v", err)
+	}
+	second, err := sa.SolveProblem(context.Background(), problem, settings)
+	if err != nil {
+		t.Fatalf("error during annealing: %v", err)
+	}
+	if first.F != second.F || first.X[0] != second.X[0] || first.X[1] != second.X[1] {
+		t.Errorf("seeded runs differ: %v, %g and %v, %g", first.X, first.F, second.X, second.F)
+	}
+}
+
+func TestSimulatedAnnealingReheat(t *testing.T) {
+	var sa SimulatedAnnealingSearch
+	sa.Init([]float64{4, 4}, 2, quadratic, 1, 1e-12, CauchyCooling, 0, 0.5, 5000)
+	sa.SetSeed(1)
+	sa.SetReheat(50, 3)
+	_, _, err := sa.Solve()
+	if err != nil {
+		t.Fatalf("error during annealing: %v", err)
+	}
+	if sa.Reheats() != 3 {
+		t.Errorf("expected 3 reheats, got %d", sa.Reheats())
+	}
+}
+
+func TestSimulatedAnnealingWrongParameters(t *testing.T) {
+	var sa SimulatedAnnealingSearch
+	sa.Init([]float64{4, 4}, 2, quadratic, 1, 1e-6, ExponentialCooling, 1.5, 0.5, 100)
+	if _, _, err := sa.Solve(); err == nil {
+		t.Errorf("wrong cooling rate is accepted")
+	}
+	sa.Init([]float64{4, 4}, 2, quadratic, 1, 1e-6, "linear", 0.5, 0.5, 100)
+	if _, _, err := sa.Solve(); err == nil {
+		t.Errorf("unknown cooling schedule is accepted")
+	}
+}
@@ -0,0 +1,402 @@
+package many_dimension_search
+
+import (
+	"context"
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/finite_differences"
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"github.com/saskamegaprogrammist/optimization_methods/line_search"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
+	"time"
+)
+
+var (
+	Debug          = false // check supplied derivatives at start point
+	DebugTolerance = 0.0001
+)
+
+type TerminationReason int
+
+const (
+	NotTerminated TerminationReason = iota
+	GradientConverged
+	StepConverged
+	SimplexConverged
+	MaxIterationsReached
+	MaxFuncEvaluationsReached
+	TimeLimitReached
+	Cancelled
+	TemperatureConverged
+)
+
+func (tr TerminationReason) String() string {
+	switch tr {
+	case GradientConverged:
+		return "gradient norm is less than precision"
+	case StepConverged:
+		return "step is less than precision"
+	case SimplexConverged:
+		return "simplex is less than precision"
+	case MaxIterationsReached:
+		return "maximum iterations reached"
+	case MaxFuncEvaluationsReached:
+		return "maximum function evaluations reached"
+	case TimeLimitReached:
+		return "time limit reached"
+	case Cancelled:
+		return "context cancelled"
+	case TemperatureConverged:
+		return "temperature is less than precision"
+	}
+	return "not terminated"
+}
+
+type Limits struct {
+	MaxIterations      int // zero means no limit
+	MaxFuncEvaluations int
+	MaxTime            time.Duration
+}
+
+func (l Limits) Exceeded(ctx context.Context, k int, funcEvaluations int, timeStart time.Time) TerminationReason {
+	if ctx.Err() == context.DeadlineExceeded {
+		return TimeLimitReached
+	}
+	if ctx.Err() != nil {
+		return Cancelled
+	}
+	if l.MaxIterations > 0 && k >= l.MaxIterations {
+		return MaxIterationsReached
+	}
+	if l.MaxFuncEvaluations > 0 && funcEvaluations >= l.MaxFuncEvaluations {
+		return MaxFuncEvaluationsReached
+	}
+	if l.MaxTime > 0 && time.Since(timeStart) >= l.MaxTime {
+		return TimeLimitReached
+	}
+	return NotTerminated
+}
+
+type Problem struct {
+	TargetFunc func(xs []float64) float64
+	Gradient   []func(xs []float64) float64
+	Hessian    func(xs []float64) la_methods.Matrix
+	Dimension  int
+	StartPoint []float64
+	Bounds     Bounds // supported by nelder mead, hooke jeeves, projected gradient, l-bfgs-b and simulated annealing
+}
+
+type Settings struct {
+	Eps1               float64
+	Eps2               float64
+	Delta              float64 // step precision
+	ExploreStep        float64 // hooke jeeves research step
+	Lambda             float64
+	AlphaPrecision     float64
+	MaxIter            int
+	MaxFuncEvals       int // zero means no limit
+	MaxTime            time.Duration
+	LineSearch         line_search.LineSearch
+	Pollak             bool
+	SimplexSize        float64
+	NelderMead         NelderMeadCoefficients // default coefficients are used when zero
+	AdaptiveNelderMead bool                   // gao han dimension dependent coefficients
+	Restarts           int
+	Damping            float64
+	History            int     // l-bfgs stored corrections
+	Radius             float64 // trust region initial radius
+	MaxRadius          float64
+	Subproblem         Subproblem
+	Temperature        float64 // simulated annealing initial temperature
+	MinTemperature     float64
+	Cooling            CoolingSchedule
+	CoolingRate        float64 // exponential cooling rate
+	Seed               int64   // simulated annealing random seed, zero seeds from current time
+	Observer           Observer
+}
+
+func DefaultSettings() Settings {
+	var gr line_search.GoldenRatioSearch
+	gr.Init(0.0001, 0.001)
+	return Settings{
+		Eps1:           0.0001,
+		Eps2:           0.0001,
+		Delta:          0.0001,
+		ExploreStep:    0.1,
+		Lambda:         2,
+		AlphaPrecision: 0.1,
+		MaxIter:        1000,
+		LineSearch:     &gr,
+		SimplexSize:    0.1,
+		Damping:        1000,
+		History:        10,
+		Radius:         1,
+		MaxRadius:      100,
+		Subproblem:     AutoSubproblem,
+		Temperature:    1,
+		MinTemperature: 0.000001,
+		Cooling:        ExponentialCooling,
+		CoolingRate:    0.99,
+	}
+}
+
+type Result struct {
+	X               []float64
+	F               float64
+	Iterations      int
+	FuncEvaluations int
+	GradEvaluations int
+	Reason          TerminationReason
+	Time            time.Duration
+}
+
+type IterationInfo struct {
+	Iteration       int
+	X               []float64
+	F               float64
+	GradNorm        float64 // zero for derivative free methods
+	Step            float64
+	FuncEvaluations int
+	GradEvaluations int
+}
+
+type Observer func(info IterationInfo)
+
+type Recorder struct {
+	Trajectory []IterationInfo
+}
+
+func (r *Recorder) Observe(info IterationInfo) {
+	r.Trajectory = append(r.Trajectory, info)
+}
+
+func (r *Recorder) Reset() {
+	r.Trajectory = nil
+}
+
+type Solver interface {
+	SolveProblem(ctx context.Context, problem Problem, settings Settings) (Result, error)
+}
+
+func (p *Problem) check() error {
+	err := p.checkBounded()
+	if err != nil {
+		return err
+	}
+	if p.Bounds.IsSet() {
+		return optimization_errors.ErrBoundsUnsupported
+	}
+	return nil
+}
+
+func (p *Problem) checkBounded() error {
+	if p.TargetFunc == nil {
+		return fmt.Errorf("target function is not set")
+	}
+	if len(p.StartPoint) != p.Dimension {
+		return fmt.Errorf("start point length: %w", &optimization_errors.DimensionError{Expected: p.Dimension, Actual: len(p.StartPoint)})
+	}
+	if p.Gradient != nil && len(p.Gradient) != p.Dimension {
+		return fmt.Errorf("gradient length: %w", &optimization_errors.DimensionError{Expected: p.Dimension, Actual: len(p.Gradient)})
+	}
+	return p.Bounds.check(p.Dimension)
+}
+
+func numericalGradient(targetFunc func(xs []float64) float64, gradient []func(xs []float64) float64,
+	dimension int) []func(xs []float64) float64 {
+	if gradient != nil {
+		return gradient
+	}
+	var fd finite_differences.Differentiator
+	fd.Init(finite_differences.Central, 0)
+	return fd.Gradient(targetFunc, dimension)
+}
+
+func numericalHessian(targetFunc func(xs []float64) float64, hessian func(xs []float64) la_methods.Matrix,
+	dimension int) func(xs []float64) la_methods.Matrix {
+	if hessian != nil {
+		return hessian
+	}
+	var fd finite_differences.Differentiator
+	fd.Init(finite_differences.Central, 0)
+	return fd.Hessian(targetFunc, dimension)
+}
+
+func checkDerivatives(targetFunc func(xs []float64) float64, gradient []func(xs []float64) float64,
+	hessian func(xs []float64) la_methods.Matrix, startPoint []float64) error {
+	if !Debug {
+		return nil
+	}
+	gradientChecks, err := finite_differences.CheckGradient(targetFunc, gradient, [][]float64{startPoint})
+	if err != nil {
+		return fmt.Errorf("error checking gradient: %w", err)
+	}
+	if check := gradientChecks[0]; !(check.MaxError <= DebugTolerance) {
+		return fmt.Errorf("wrong gradient component %d: relative error %g", check.MaxComponent, check.MaxError)
+	}
+	if hessian == nil {
+		return nil
+	}
+	hessianChecks, err := finite_differences.CheckHessian(targetFunc, hessian, [][]float64{startPoint})
+	if err != nil {
+		return fmt.Errorf("error checking hessian: %w", err)
+	}
+	if check := hessianChecks[0]; !(check.MaxError <= DebugTolerance) {
+		return fmt.Errorf("wrong hessian element %d, %d: relative error %g", check.MaxRow, check.MaxColumn, check.MaxError)
+	}
+	return nil
+}
+
+func calculateGradient(gradient []func(xs []float64) float64, x la_methods.Vector) (la_methods.Vector, error) {
+	var grad la_methods.Vector
+	gradPoints := make([]float64, len(gradient))
+	for i, g := range gradient {
+		gradPoints[i] = g(x.Points)
+	}
+	err := grad.InitWithPoints(len(gradient), gradPoints)
+	if err != nil {
+		return la_methods.Vector{}, fmt.Errorf("error during vector initializing: %w", err)
+	}
+	return grad, nil
+}
+
+type solverStats struct {
+	iterations      int
+	funcEvaluations int
+	gradEvaluations int
+	reason          TerminationReason
+	observer        Observer
+	observedFunc    func(xs []float64) float64 // not counted target function
+	limits          Limits
+	ctx             context.Context
+	timeStart       time.Time
+	startPoint      []float64
+	best            []float64 // best evaluated point
+	bestF           float64
+}
+
+func (ss *solverStats) SetLimits(limits Limits) {
+	ss.limits = limits
+}
+
+func (ss *solverStats) Reason() TerminationReason {
+	return ss.reason
+}
+
+func (ss *solverStats) interrupted(k int) bool {
+	reason := ss.limits.Exceeded(ss.ctx, k, ss.funcEvaluations, ss.timeStart)
+	if reason == NotTerminated {
+		return false
+	}
+	ss.finish(k, reason)
+	return true
+}
+
+func (ss *solverStats) search(lineSearch line_search.LineSearch, phi func(alpha float64) float64,
+	dPhi func(alpha float64) float64, alpha float64) (float64, error) {
+	if ss.limits.MaxTime == 0 {
+		return lineSearch.Search(ss.ctx, phi, dPhi, alpha)
+	}
+	ctx, cancel := context.WithDeadline(ss.ctx, ss.timeStart.Add(ss.limits.MaxTime))
+	defer cancel()
+	return lineSearch.Search(ctx, phi, dPhi, alpha)
+}
+
+func (ss *solverStats) bestPoint() ([]float64, float64, error) {
+	if ss.best == nil {
+		return ss.startPoint, ss.observedFunc(ss.startPoint), nil
+	}
+	return ss.best, ss.bestF, nil
+}
+
+func (ss *solverStats) SetObserver(observer Observer) {
+	ss.observer = observer
+}
+
+func (ss *solverStats) notify(k int, x []float64, f float64, gradNorm float64, step float64) {
+	if ss.observer == nil {
+		return
+	}
+	point := make([]float64, len(x))
+	copy(point, x)
+	ss.observer(IterationInfo{
+		Iteration:       k,
+		X:               point,
+		F:               f,
+		GradNorm:        gradNorm,
+		Step:            step,
+		FuncEvaluations: ss.funcEvaluations,
+		GradEvaluations: ss.gradEvaluations,
+	})
+}
+
+func (ss *solverStats) reset(ctx context.Context, startPoint []float64) {
+	ss.ctx = ctx
+	ss.timeStart = time.Now()
+	ss.startPoint = startPoint
+	ss.best = nil
+	ss.iterations = 0
+	ss.funcEvaluations = 0
+	ss.gradEvaluations = 0
+	ss.reason = NotTerminated
+}
+
+func (ss *solverStats) finish(k int, reason TerminationReason) {
+	ss.iterations = k
+	ss.reason = reason
+}
+
+func (ss *solverStats) countFunc(targetFunc func(xs []float64) float64) func(xs []float64) float64 {
+	ss.observedFunc = targetFunc
+	return func(xs []float64) float64 {
+		ss.funcEvaluations++
+		f := targetFunc(xs)
+		if ss.best == nil || f < ss.bestF {
+			ss.best = append(ss.best[:0], xs...)
+			ss.bestF = f
+		}
+		return f
+	}
+}
+
+func (ss *solverStats) countGradient(gradient []func(xs []float64) float64) []func(xs []float64) float64 {
+	if len(gradient) == 0 {
+		return gradient
+	}
+	counted := make([]func(xs []float64) float64, len(gradient))
+	copy(counted, gradient)
+	first := gradient[0]
+	counted[0] = func(xs []float64) float64 {
+		ss.gradEvaluations++
+		return first(xs)
+	}
+	return counted
+}
+
+func (ss *solverStats) result(x []float64, f float64, timeStart time.Time) Result {
+	return Result{
+		X:               x,
+		F:               f,
+		Iterations:      ss.iterations,
+		FuncEvaluations: ss.funcEvaluations,
+		GradEvaluations: ss.gradEvaluations,
+		Reason:          ss.reason,
+		Time:            time.Since(timeStart),
+	}
+}
+
+var (
+	_ Solver = &HookeJeevesSearch{}
+	_ Solver = &NelderMeadSearch{}
+	_ Solver = &FastGradientDescendSearch{}
+	_ Solver = &FletcherReevesSearch{}
+	_ Solver = &DavidonFletcherPowellSearch{}
+	_ Solver = &LevenbergMarkkvadratSearch{}
+	_ Solver = &BFGSSearch{}
+	_ Solver = &LBFGSSearch{}
+	_ Solver = &TrustRegionSearch{}
+	_ Solver = &ProjectedGradientSearch{}
+	_ Solver = &LBFGSBSearch{}
+	_ Solver = &PowellSearch{}
+	_ Solver = &RosenbrockSearch{}
+	_ Solver = &SimulatedAnnealingSearch{}
+)
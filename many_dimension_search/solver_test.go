@@ -0,0 +1,80 @@
+package many_dimension_search
+
+import (
+	"context"
+	"errors"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
+	"math"
+	"testing"
+)
+
+func quadratic(xs []float64) float64 {
+	return math.Pow(xs[0]-1, 2) + 10*math.Pow(xs[1]+2, 2)
+}
+
+func solvers() map[string]Solver {
+	return map[string]Solver{
+		"fletcher reeves":         &FletcherReevesSearch{},
+		"fast gradient descent":   &FastGradientDescendSearch{},
+		"davidon fletcher powell": &DavidonFletcherPowellSearch{},
+		"bfgs":                    &BFGSSearch{},
+		"lbfgs":                   &LBFGSSearch{},
+		"lbfgsb":                  &LBFGSBSearch{},
+		"projected gradient":      &ProjectedGradientSearch{},
+		"levenberg markkvadrat":   &LevenbergMarkkvadratSearch{},
+		"trust region":            &TrustRegionSearch{},
+		"nelder mead":             &NelderMeadSearch{},
+		"hooke jeeves":            &HookeJeevesSearch{},
+		"powell":                  &PowellSearch{},
+		"rosenbrock":              &RosenbrockSearch{},
+		"simulated annealing":     &SimulatedAnnealingSearch{},
+	}
+}
+
+func distanceTo(x []float64, y []float64) float64 {
+	var sum float64
+	for i := range x {
+		sum += math.Pow(x[i]-y[i], 2)
+	}
+	return math.Sqrt(sum)
+}
+
+func TestSolveProblemResult(t *testing.T) {
+	problem := Problem{TargetFunc: quadratic, Dimension: 2, StartPoint: []float64{0, 0}}
+	for name, solver := range solvers() {
+		settings := DefaultSettings()
+		settings.MaxIter = 20000
+		result, err := solver.SolveProblem(context.Background(), problem, settings)
+		if err != nil {
+			t.Fatalf("%s: error solving problem: %v", name, err)
+		}
+		if distanceTo(result.X, []float64{1, -2}) > 0.05 {
+			t.Errorf("%s: expected minimum at [1 -2], got %v", name, result.X)
+		}
+		if result.F != quadratic(result.X) {
+			t.Errorf("%s: result value %g isn't function value %g", name, result.F, quadratic(result.X))
+		}
+		if result.FuncEvaluations == 0 || result.Reason == NotTerminated {
+			t.Errorf("%s: result statistics aren't filled: %+v", name, result)
+		}
+	}
+}
+
+func TestSolveProblemChecksDimension(t *testing.T) {
+	problem := Problem{TargetFunc: quadratic, Dimension: 2, StartPoint: []float64{0}}
+	for name, solver := range solvers() {
+		_, err := solver.SolveProblem(context.Background(), problem, DefaultSettings())
+		if !errors.Is(err, optimization_errors.ErrDimensionMismatch) {
+			t.Errorf("%s: expected dimension mismatch, got %v", name, err)
+		}
+	}
+}
+
+func TestSolveProblemRejectsBounds(t *testing.T) {
+	problem := Problem{TargetFunc: quadratic, Dimension: 2, StartPoint: []float64{0, 0},
+		Bounds: Bounds{Lower: []float64{-1, -1}}}
+	var bfgs BFGSSearch
+	if _, err := bfgs.SolveProblem(context.Background(), problem, DefaultSettings()); err == nil {
+		t.Errorf("unbounded solver accepted bounds")
+	}
+}
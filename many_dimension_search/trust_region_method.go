@@ -0,0 +1,323 @@
+package many_dimension_search
+
+import (
+	"context"
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"time"
+)
+
+type Subproblem string
+
+const (
+	AutoSubproblem Subproblem = "auto"
+	Dogleg         Subproblem = "dogleg"
+	SteihaugCG     Subproblem = "steihaug cg"
+)
+
+const (
+	doglegMaxDimension = 100 // auto subproblem uses steihaug cg above
+	acceptRatio        = 0.1
+	minRadius          = 1e-12
+)
+
+type TrustRegionSearch struct {
+	startPoint    []float64
+	dimension     int
+	targetFunc    func(xs []float64) float64
+	gradient      []func(xs []float64) float64
+	hessian       func(xs []float64) la_methods.Matrix
+	radius        float64
+	maxRadius     float64
+	eps1          float64
+	eps2          float64
+	maxIterations int
+	subproblem    Subproblem
+	solverStats
+}
+
+func (trs *TrustRegionSearch) Init(startPoint []float64, dimension int,
+	targetFunc func(xs []float64) float64, gradient []func(xs []float64) float64,
+	hessian func(xs []float64) la_methods.Matrix, radius float64, maxRadius float64,
+	eps1 float64, eps2 float64, maxIterations int, subproblem Subproblem) {
+	trs.startPoint = startPoint
+	trs.dimension = dimension
+	trs.targetFunc = trs.countFunc(targetFunc)
+	trs.gradient = trs.countGradient(numericalGradient(targetFunc, gradient, dimension))
+	trs.hessian = numericalHessian(targetFunc, hessian, dimension)
+	trs.radius = radius
+	trs.maxRadius = maxRadius
+	trs.eps1 = eps1
+	trs.eps2 = eps2
+	trs.maxIterations = maxIterations
+	trs.subproblem = subproblem
+}
+
+func (trs *TrustRegionSearch) SolveProblem(ctx context.Context, problem Problem, settings Settings) (Result, error) {
+	err := problem.check()
+	if err != nil {
+		return Result{}, fmt.Errorf("error checking problem: %w", err)
+	}
+	timeStart := time.Now()
+	trs.Init(problem.StartPoint, problem.Dimension, problem.TargetFunc, problem.Gradient, problem.Hessian,
+		settings.Radius, settings.MaxRadius, settings.Eps1, settings.Eps2, settings.MaxIter, settings.Subproblem)
+	trs.SetObserver(settings.Observer)
+	trs.SetLimits(Limits{MaxIterations: settings.MaxIter, MaxFuncEvaluations: settings.MaxFuncEvals, MaxTime: settings.MaxTime})
+	xMin, yMin, err := trs.SolveContext(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	return trs.result(xMin, yMin, timeStart), nil
+}
+
+func (trs *TrustRegionSearch) Solve() ([]float64, float64, error) {
+	return trs.SolveContext(context.Background())
+}
+
+func (trs *TrustRegionSearch) SolveContext(ctx context.Context) ([]float64, float64, error) {
+	var err error
+	var x, xNew, grad, p, Bp la_methods.Vector
+	var B la_methods.Matrix
+	var k int
+	var f, fNew, gp, pBp, predicted, ratio float64
+	err = checkDerivatives(trs.targetFunc, trs.gradient, trs.hessian, trs.startPoint)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error checking derivatives: %w", err)
+	}
+	trs.reset(ctx, trs.startPoint)
+	if trs.radius <= 0 || trs.maxRadius < trs.radius {
+		return nil, 0, fmt.Errorf("wrong trust region radius: %f, %f", trs.radius, trs.maxRadius)
+	}
+	radius := trs.radius
+	err = x.InitWithPoints(trs.dimension, trs.startPoint)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error during vector initializing: %w", err)
+	}
+	f = trs.targetFunc(x.Points)
+	grad, err = calculateGradient(trs.gradient, x)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error calculcating gradient: %w", err)
+	}
+	B = trs.hessian(x.Points)
+	for {
+		if trs.interrupted(k) {
+			return trs.bestPoint()
+		}
+		if grad.Len() < trs.eps1 {
+			trs.finish(k, GradientConverged)
+			return x.Points, f, nil
+		}
+		if k >= trs.maxIterations {
+			trs.finish(k, MaxIterationsReached)
+			return x.Points, f, nil
+		}
+		if radius < minRadius {
+			trs.finish(k, StepConverged)
+			return x.Points, f, nil
+		}
+		p, err = trs.solveSubproblem(B, grad, radius)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error solving trust region subproblem: %w", err)
+		}
+		Bp, err = B.MulV(p)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during matrix and vector multiplying: %w", err)
+		}
+		gp, err = grad.Mul(p)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during vector multiplying: %w", err)
+		}
+		pBp, err = p.Mul(Bp)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during vector multiplying: %w", err)
+		}
+		predicted = -(gp + pBp/2)
+		xNew, err = x.Add(p)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during vector adding: %w", err)
+		}
+		fNew = trs.targetFunc(xNew.Points)
+		ratio = -1
+		if predicted > 0 {
+			ratio = (f - fNew) / predicted
+		}
+		if ratio < 0.25 {
+			radius = p.Len() / 4
+		} else if ratio > 0.75 && p.Len() >= 0.99*radius {
+			radius = math.Min(2*radius, trs.maxRadius)
+		}
+		k++
+		if ratio <= acceptRatio {
+			continue
+		}
+		stepConverged := p.Len() < trs.eps2 && math.Abs(f-fNew) < trs.eps2
+		x = xNew
+		f = fNew
+		grad, err = calculateGradient(trs.gradient, x)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error calculcating gradient: %w", err)
+		}
+		B = trs.hessian(x.Points)
+		trs.notify(k, x.Points, f, grad.Len(), p.Len())
+		if stepConverged {
+			trs.finish(k, StepConverged)
+			return x.Points, f, nil
+		}
+	}
+}
+
+func (trs *TrustRegionSearch) solveSubproblem(B la_methods.Matrix, grad la_methods.Vector, radius float64) (la_methods.Vector, error) {
+	switch trs.subproblem {
+	case Dogleg:
+		return trs.dogleg(B, grad, radius)
+	case SteihaugCG:
+		return trs.steihaugCG(B, grad, radius)
+	case AutoSubproblem, "":
+		if trs.dimension <= doglegMaxDimension {
+			return trs.dogleg(B, grad, radius)
+		}
+		return trs.steihaugCG(B, grad, radius)
+	}
+	return la_methods.Vector{}, fmt.Errorf("wrong trust region subproblem: %s", trs.subproblem)
+}
+
+func (trs *TrustRegionSearch) dogleg(B la_methods.Matrix, grad la_methods.Vector, radius float64) (la_methods.Vector, error) {
+	Bg, err := B.MulV(grad)
+	if err != nil {
+		return la_methods.Vector{}, fmt.Errorf("error during matrix and vector multiplying: %w", err)
+	}
+	gBg, err := grad.Mul(Bg)
+	if err != nil {
+		return la_methods.Vector{}, fmt.Errorf("error during vector multiplying: %w", err)
+	}
+	gNorm := grad.Len()
+	if gBg <= 0 {
+		return grad.MulOnValue(-radius / gNorm), nil // negative curvature, go to the boundary
+	}
+	pU := grad.MulOnValue(-gNorm * gNorm / gBg)
+	pB, ok := trs.newtonStep(B, grad)
+	if !ok {
+		if pU.Len() >= radius {
+			return grad.MulOnValue(-radius / gNorm), nil
+		}
+		return pU, nil
+	}
+	if pB.Len() <= radius {
+		return pB, nil
+	}
+	if pU.Len() >= radius {
+		return pU.MulOnValue(radius / pU.Len()), nil
+	}
+	d, err := pB.Sub(pU)
+	if err != nil {
+		return la_methods.Vector{}, fmt.Errorf("error during vector substracting: %w", err)
+	}
+	tau, err := boundaryStep(pU, d, radius)
+	if err != nil {
+		return la_methods.Vector{}, fmt.Errorf("error finding boundary step: %w", err)
+	}
+	return pU.Add(d.MulOnValue(tau))
+}
+
+func (trs *TrustRegionSearch) newtonStep(B la_methods.Matrix, grad la_methods.Vector) (la_methods.Vector, bool) {
+	var chol mat.Cholesky
+	var step mat.VecDense
+	var pB la_methods.Vector
+	sym := mat.NewSymDense(trs.dimension, nil)
+	for i := 0; i < trs.dimension; i++ {
+		for j := i; j < trs.dimension; j++ {
+			sym.SetSym(i, j, (B.Points[i][j]+B.Points[j][i])/2)
+		}
+	}
+	if !chol.Factorize(sym) {
+		return la_methods.Vector{}, false // hessian isn't positive definite
+	}
+	gradMinus := grad.MulOnValue(-1)
+	err := la_methods.InverseError(chol.SolveVecTo(&step, mat.NewVecDense(trs.dimension, gradMinus.Points)))
+	if err != nil {
+		return la_methods.Vector{}, false
+	}
+	err = pB.InitWithPoints(trs.dimension, step.RawVector().Data)
+	if err != nil {
+		return la_methods.Vector{}, false
+	}
+	return pB, true
+}
+
+func (trs *TrustRegionSearch) steihaugCG(B la_methods.Matrix, grad la_methods.Vector, radius float64) (la_methods.Vector, error) {
+	var z, zNew, r, rNew, d, Bd la_methods.Vector
+	var dBd, rr, alpha, beta, tau float64
+	var err error
+	z.Init(trs.dimension)
+	r = grad.Copy()
+	d = grad.MulOnValue(-1)
+	tolerance := math.Min(0.5, math.Sqrt(grad.Len())) * grad.Len()
+	for j := 0; j < 2*trs.dimension; j++ {
+		Bd, err = B.MulV(d)
+		if err != nil {
+			return la_methods.Vector{}, fmt.Errorf("error during matrix and vector multiplying: %w", err)
+		}
+		dBd, err = d.Mul(Bd)
+		if err != nil {
+			return la_methods.Vector{}, fmt.Errorf("error during vector multiplying: %w", err)
+		}
+		if dBd <= 0 {
+			tau, err = boundaryStep(z, d, radius) // negative curvature direction
+			if err != nil {
+				return la_methods.Vector{}, fmt.Errorf("error finding boundary step: %w", err)
+			}
+			return z.Add(d.MulOnValue(tau))
+		}
+		rr, err = r.Mul(r)
+		if err != nil {
+			return la_methods.Vector{}, fmt.Errorf("error during vector multiplying: %w", err)
+		}
+		alpha = rr / dBd
+		zNew, err = z.Add(d.MulOnValue(alpha))
+		if err != nil {
+			return la_methods.Vector{}, fmt.Errorf("error during vector adding: %w", err)
+		}
+		if zNew.Len() >= radius {
+			tau, err = boundaryStep(z, d, radius)
+			if err != nil {
+				return la_methods.Vector{}, fmt.Errorf("error finding boundary step: %w", err)
+			}
+			return z.Add(d.MulOnValue(tau))
+		}
+		rNew, err = r.Add(Bd.MulOnValue(alpha))
+		if err != nil {
+			return la_methods.Vector{}, fmt.Errorf("error during vector adding: %w", err)
+		}
+		if rNew.Len() < tolerance {
+			return zNew, nil
+		}
+		beta = rNew.Len() * rNew.Len() / rr
+		rNewMinus := rNew.MulOnValue(-1)
+		d, err = rNewMinus.Add(d.MulOnValue(beta))
+		if err != nil {
+			return la_methods.Vector{}, fmt.Errorf("error during vector adding: %w", err)
+		}
+		z = zNew
+		r = rNew
+	}
+	return z, nil
+}
+
+func boundaryStep(z la_methods.Vector, d la_methods.Vector, radius float64) (float64, error) {
+	dd, err := d.Mul(d)
+	if err != nil {
+		return 0, fmt.Errorf("error during vector multiplying: %w", err)
+	}
+	zd, err := z.Mul(d)
+	if err != nil {
+		return 0, fmt.Errorf("error during vector multiplying: %w", err)
+	}
+	zz, err := z.Mul(z)
+	if err != nil {
+		return 0, fmt.Errorf("error during vector multiplying: %w", err)
+	}
+	discriminant := zd*zd - dd*(zz-radius*radius)
+	return (-zd + math.Sqrt(math.Max(discriminant, 0))) / dd, nil
+}
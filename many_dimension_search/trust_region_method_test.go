@@ -0,0 +1,64 @@
+package many_dimension_search
+
+import (
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"github.com/saskamegaprogrammist/optimization_methods/test_functions"
+	"math"
+	"testing"
+)
+
+func TestTrustRegionRosenbrock(t *testing.T) {
+	for _, subproblem := range []Subproblem{Dogleg, SteihaugCG, AutoSubproblem} {
+		var trs TrustRegionSearch
+		trs.Init([]float64{-1.2, 1}, 2, test_functions.Rosenbrock, test_functions.RosenbrockGradient(2), test_functions.RosenbrockHessian, 1, 100, 1e-8, 1e-12, 1000, subproblem)
+		x, f, err := trs.Solve()
+		if err != nil {
+			t.Fatalf("error during %s trust region search: %v", subproblem, err)
+		}
+		if distanceTo(x, []float64{1, 1}) > 1e-5 || f > 1e-10 {
+			t.Errorf("%s: expected minimum at [1 1], got %v, %g", subproblem, x, f)
+		}
+	}
+}
+
+func TestTrustRegionNumericalHessian(t *testing.T) {
+	var trs TrustRegionSearch
+	trs.Init([]float64{-1.2, 1}, 2, test_functions.Rosenbrock, nil, nil, 1, 100, 1e-6, 1e-12, 1000, Dogleg)
+	x, _, err := trs.Solve()
+	if err != nil {
+		t.Fatalf("error during trust region search: %v", err)
+	}
+	if distanceTo(x, []float64{1, 1}) > 1e-4 {
+		t.Errorf("expected minimum at [1 1], got %v", x)
+	}
+}
+
+func TestTrustRegionStepInsideRadius(t *testing.T) {
+	var grad la_methods.Vector
+	grad.InitWithPoints(2, []float64{1, 2})
+	indefinite := test_functions.RosenbrockHessian([]float64{0, 1})
+	for _, subproblem := range []Subproblem{Dogleg, SteihaugCG} {
+		var trs TrustRegionSearch
+		trs.Init([]float64{0, 1}, 2, test_functions.Rosenbrock, nil, nil, 1, 100, 1e-6, 1e-12, 1000, subproblem)
+		for _, radius := range []float64{0.001, 0.1, 10} {
+			step, err := trs.solveSubproblem(indefinite, grad, radius)
+			if err != nil {
+				t.Fatalf("error solving %s subproblem: %v", subproblem, err)
+			}
+			if step.Len() > radius*(1+1e-9) {
+				t.Errorf("%s: step length %g exceeds radius %g", subproblem, step.Len(), radius)
+			}
+			if v, _ := step.Mul(grad); !(v < 0) || math.IsNaN(v) {
+				t.Errorf("%s: step %v isn't a descent step", subproblem, step.Points)
+			}
+		}
+	}
+}
+
+func TestTrustRegionWrongSubproblem(t *testing.T) {
+	var trs TrustRegionSearch
+	trs.Init([]float64{-1.2, 1}, 2, test_functions.Rosenbrock, test_functions.RosenbrockGradient(2), test_functions.RosenbrockHessian, 1, 100, 1e-8, 1e-12, 1000, "newton")
+	if _, _, err := trs.Solve(); err == nil {
+		t.Errorf("wrong subproblem is accepted")
+	}
+}
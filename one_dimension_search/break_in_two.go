@@ -1,6 +1,7 @@
 package one_dimension_search
 
 import (
+	"context"
 	"fmt"
 	"math"
 )
@@ -12,16 +13,22 @@ type BreakInTwoSearch struct {
 	targetFunc func(x float64) float64
 	k          int
 	r          float64
+	searchStats
 }
 
 func (bit *BreakInTwoSearch) Init(aStart float64, bStart float64, precision float64, targetFunc func(x float64) float64) {
 	bit.aStart = aStart
 	bit.bStart = bStart
 	bit.precision = precision
-	bit.targetFunc = targetFunc
+	bit.targetFunc = bit.countFunc(targetFunc)
 }
 
 func (bit *BreakInTwoSearch) Solve() (float64, float64) {
+	x, f, _ := bit.SolveContext(context.Background())
+	return x, f
+}
+
+func (bit *BreakInTwoSearch) SolveContext(ctx context.Context) (float64, float64, error) {
 	var k int
 	var y, z float64
 	var fMid, fY, fZ float64
@@ -29,7 +36,12 @@ func (bit *BreakInTwoSearch) Solve() (float64, float64) {
 	b := bit.bStart
 	xMid := (a + b) / 2
 	length := b - a
+	bit.reset(ctx)
 	for {
+		if err := bit.interrupted(k); err != nil {
+			bit.k = k
+			return xMid, bit.targetFunc(xMid), err
+		}
 		fMid = bit.targetFunc(xMid)
 		y = a + length/4
 		z = b - length/4
@@ -47,11 +59,12 @@ func (bit *BreakInTwoSearch) Solve() (float64, float64) {
 				b = z
 			}
 		}
+		lastLength := length
 		length = b - a
-		if length <= bit.precision {
+		if length <= bit.precision || length >= lastLength { // interval stopped shrinking at float resolution
 			//fmt.Printf("k value: %d\n", k)
 			bit.k = k
-			return xMid, bit.targetFunc(xMid)
+			return xMid, bit.targetFunc(xMid), nil
 		} else {
 			k++
 		}
@@ -1,6 +1,7 @@
 package one_dimension_search
 
 import (
+	"context"
 	"fmt"
 	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
 	"math"
@@ -13,16 +14,24 @@ type BreakInTwoSearchVector struct {
 	targetFunc func(x []float64) float64
 	k          int
 	r          float64
+	searchStats
 }
 
 func (bit *BreakInTwoSearchVector) Init(aStart []float64, bStart []float64, precision float64, targetFunc func(xs []float64) float64) {
 	bit.aStart = aStart
 	bit.bStart = bStart
 	bit.precision = precision
-	bit.targetFunc = targetFunc
+	bit.targetFunc = func(xs []float64) float64 {
+		bit.funcEvaluations++
+		return targetFunc(xs)
+	}
 }
 
 func (bit *BreakInTwoSearchVector) Solve() ([]float64, float64, error) {
+	return bit.SolveContext(context.Background())
+}
+
+func (bit *BreakInTwoSearchVector) SolveContext(ctx context.Context) ([]float64, float64, error) {
 	var err error
 	var k int
 	var fMid, fY, fZ float64
@@ -30,24 +39,29 @@ func (bit *BreakInTwoSearchVector) Solve() ([]float64, float64, error) {
 	var yVec, zVec la_methods.Vector
 	err = aVec.InitWithPoints(len(bit.aStart), bit.aStart)
 	if err != nil {
-		return []float64{}, 0, fmt.Errorf("error initializing vector: %v", err)
+		return []float64{}, 0, fmt.Errorf("error initializing vector: %w", err)
 	}
 	err = bVec.InitWithPoints(len(bit.bStart), bit.bStart)
 	if err != nil {
-		return []float64{}, 0, fmt.Errorf("error initializing vector: %v", err)
+		return []float64{}, 0, fmt.Errorf("error initializing vector: %w", err)
 	}
 	sumAB, err := aVec.Add(bVec)
 	if err != nil {
-		return []float64{}, 0, fmt.Errorf("error adding vector: %v", err)
+		return []float64{}, 0, fmt.Errorf("error adding vector: %w", err)
 	}
 	sumAB.MulOnValue(float64(1) / float64(2))
 	xMid := sumAB
 	AB, err := bVec.Sub(aVec)
 	if err != nil {
-		return []float64{}, 0, fmt.Errorf("error substracting vector: %v", err)
+		return []float64{}, 0, fmt.Errorf("error substracting vector: %w", err)
 	}
 	length := AB.Len()
+	bit.reset(ctx)
 	for {
+		if err := bit.interrupted(k); err != nil {
+			bit.k = k
+			return xMid.Points, bit.targetFunc(xMid.Points), err
+		}
 		fMid = bit.targetFunc(xMid.Points)
 		yVec = aVec.AddK(length / 4)
 		zVec = bVec.SubK(length / 4)
@@ -67,7 +81,7 @@ func (bit *BreakInTwoSearchVector) Solve() ([]float64, float64, error) {
 		}
 		AB, err := bVec.Sub(aVec)
 		if err != nil {
-			return []float64{}, 0, fmt.Errorf("error substracting vector: %v", err)
+			return []float64{}, 0, fmt.Errorf("error substracting vector: %w", err)
 		}
 		length := AB.Len()
 		if length <= bit.precision {
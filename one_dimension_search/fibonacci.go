@@ -1,6 +1,7 @@
 package one_dimension_search
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -15,6 +16,7 @@ type FibonacciSearch struct {
 	r          float64
 	fibNumbers []float64
 	fibN       float64
+	searchStats
 }
 
 func (fs *FibonacciSearch) Init(aStart float64, bStart float64, precision float64, diffConst float64, targetFunc func(x float64) float64) {
@@ -23,11 +25,15 @@ func (fs *FibonacciSearch) Init(aStart float64, bStart float64, precision float6
 	fs.abLen = bStart - aStart
 	fs.precision = precision
 	fs.diffConst = diffConst
-	fs.targetFunc = targetFunc
+	fs.targetFunc = fs.countFunc(targetFunc)
 	fs.fibNumbers = make([]float64, 0)
 }
 
 func (fs *FibonacciSearch) Solve() (float64, float64, error) {
+	return fs.SolveContext(context.Background())
+}
+
+func (fs *FibonacciSearch) SolveContext(ctx context.Context) (float64, float64, error) {
 	var err error
 	var k int
 	var y, z float64
@@ -38,15 +44,21 @@ func (fs *FibonacciSearch) Solve() (float64, float64, error) {
 	b = fs.bStart
 	fibN2, err := fs.GetFibonacci(n - 2)
 	if err != nil {
-		return 0, 0, fmt.Errorf("error counting fibonacci number: %v", err)
+		return 0, 0, fmt.Errorf("error counting fibonacci number: %w", err)
 	}
 	fibN1, err := fs.GetFibonacci(n - 1)
 	if err != nil {
-		return 0, 0, fmt.Errorf("error counting fibonacci number: %v", err)
+		return 0, 0, fmt.Errorf("error counting fibonacci number: %w", err)
 	}
 	y = a + (b-a)*fibN2/fibN
 	z = a + (b-a)*fibN1/fibN
+	fs.reset(ctx)
 	for {
+		if err := fs.interrupted(k); err != nil {
+			fs.k = k
+			xMid := (a + b) / 2
+			return xMid, fs.targetFunc(xMid), err
+		}
 		fY = fs.targetFunc(y)
 		fZ = fs.targetFunc(z)
 		if checkDecreasing(fZ, fY) {
@@ -54,11 +66,11 @@ func (fs *FibonacciSearch) Solve() (float64, float64, error) {
 			y = z
 			fibNk1, err := fs.GetFibonacci(n - k - 1)
 			if err != nil {
-				return 0, 0, fmt.Errorf("error counting fibonacci number: %v", err)
+				return 0, 0, fmt.Errorf("error counting fibonacci number: %w", err)
 			}
 			fibNk2, err := fs.GetFibonacci(n - k - 2)
 			if err != nil {
-				return 0, 0, fmt.Errorf("error counting fibonacci number: %v", err)
+				return 0, 0, fmt.Errorf("error counting fibonacci number: %w", err)
 			}
 			z = a + (b-a)*fibNk2/fibNk1
 		} else {
@@ -66,11 +78,11 @@ func (fs *FibonacciSearch) Solve() (float64, float64, error) {
 			z = y
 			fibNk1, err := fs.GetFibonacci(n - k - 1)
 			if err != nil {
-				return 0, 0, fmt.Errorf("error counting fibonacci number: %v", err)
+				return 0, 0, fmt.Errorf("error counting fibonacci number: %w", err)
 			}
 			fibNk3, err := fs.GetFibonacci(n - k - 3)
 			if err != nil {
-				return 0, 0, fmt.Errorf("error counting fibonacci number: %v", err)
+				return 0, 0, fmt.Errorf("error counting fibonacci number: %w", err)
 			}
 			y = a + (b-a)*fibNk3/fibNk1
 		}
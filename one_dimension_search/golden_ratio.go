@@ -1,6 +1,7 @@
 package one_dimension_search
 
 import (
+	"context"
 	"fmt"
 	"math"
 )
@@ -14,16 +15,22 @@ type GoldenRatioSearch struct {
 	targetFunc func(x float64) float64
 	k          int
 	r          float64
+	searchStats
 }
 
 func (gr *GoldenRatioSearch) Init(aStart float64, bStart float64, precision float64, targetFunc func(x float64) float64) {
 	gr.aStart = aStart
 	gr.bStart = bStart
 	gr.precision = precision
-	gr.targetFunc = targetFunc
+	gr.targetFunc = gr.countFunc(targetFunc)
 }
 
 func (gr *GoldenRatioSearch) Solve() (float64, float64) {
+	x, f, _ := gr.SolveContext(context.Background())
+	return x, f
+}
+
+func (gr *GoldenRatioSearch) SolveContext(ctx context.Context) (float64, float64, error) {
 	var k int
 	var delta float64
 	var y, z float64
@@ -32,24 +39,30 @@ func (gr *GoldenRatioSearch) Solve() (float64, float64) {
 	b := gr.bStart
 	y = a + GLDNRT*(b-a)
 	z = a + b - y
+	gr.reset(ctx)
 	for {
+		if err := gr.interrupted(k); err != nil {
+			gr.k = k
+			xMid := (a + b) / 2
+			return xMid, gr.targetFunc(xMid), err
+		}
 		fY = gr.targetFunc(y)
 		fZ = gr.targetFunc(z)
 		if checkDecreasing(fZ, fY) {
 			a = y
-			y = z
-			z = a + b - z
 		} else {
 			b = z
-			z = y
-			y = a + b - y
 		}
+		// recomputing both points keeps them ordered, a + b - y drifts out of the interval
+		y = a + GLDNRT*(b-a)
+		z = b - GLDNRT*(b-a)
+		lastDelta := delta
 		delta = math.Abs(a - b)
-		if delta <= gr.precision {
+		if delta <= gr.precision || (k > 0 && delta >= lastDelta) {
 			//fmt.Printf("k value: %d\n", k)
 			gr.k = k
 			xMid := (a + b) / 2
-			return xMid, gr.targetFunc(xMid)
+			return xMid, gr.targetFunc(xMid), nil
 		} else {
 			k++
 		}
@@ -1,7 +1,74 @@
 package one_dimension_search
 
+import (
+	"context"
+	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
+	"time"
+)
+
+const defaultMaxIterations = 10000 // cap for non unimodal and unbounded functions
+
 type OneDimensionSearchI interface {
 	Init(aStart float64, bStart float64, precision float64, targetFunc func(x float64) float64)
 	Solve() (float64, float64)
 	CountConvergence() (float64, error)
 }
+
+type Limits struct {
+	MaxIterations      int // zero means default cap
+	MaxFuncEvaluations int // zero means no limit
+	MaxTime            time.Duration
+}
+
+func (l Limits) Exceeded(ctx context.Context, k int, funcEvaluations int, timeStart time.Time) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	maxIterations := l.MaxIterations
+	if maxIterations == 0 {
+		maxIterations = defaultMaxIterations
+	}
+	if k >= maxIterations {
+		return fmt.Errorf("%w: %d", optimization_errors.ErrMaxIterations, k)
+	}
+	if l.MaxFuncEvaluations > 0 && funcEvaluations >= l.MaxFuncEvaluations {
+		return fmt.Errorf("%w: %d", optimization_errors.ErrMaxEvaluations, funcEvaluations)
+	}
+	if l.MaxTime > 0 && time.Since(timeStart) >= l.MaxTime {
+		return fmt.Errorf("%w: %v", optimization_errors.ErrTimeLimit, l.MaxTime)
+	}
+	return nil
+}
+
+type searchStats struct {
+	limits          Limits
+	ctx             context.Context
+	timeStart       time.Time
+	funcEvaluations int
+}
+
+func (ss *searchStats) SetLimits(limits Limits) {
+	ss.limits = limits
+}
+
+func (ss *searchStats) FuncEvaluations() int {
+	return ss.funcEvaluations
+}
+
+func (ss *searchStats) reset(ctx context.Context) {
+	ss.ctx = ctx
+	ss.timeStart = time.Now()
+	ss.funcEvaluations = 0
+}
+
+func (ss *searchStats) interrupted(k int) error {
+	return ss.limits.Exceeded(ss.ctx, k, ss.funcEvaluations, ss.timeStart)
+}
+
+func (ss *searchStats) countFunc(targetFunc func(x float64) float64) func(x float64) float64 {
+	return func(x float64) float64 {
+		ss.funcEvaluations++
+		return targetFunc(x)
+	}
+}
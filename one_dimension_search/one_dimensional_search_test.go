@@ -0,0 +1,131 @@
+package one_dimension_search
+
+import (
+	"context"
+	"errors"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
+	"math"
+	"testing"
+	"time"
+)
+
+type contextSearch interface {
+	SolveContext(ctx context.Context) (float64, float64, error)
+}
+
+func parabola(x float64) float64 {
+	return math.Pow(x-2, 2) + 1
+}
+
+func TestSearchesFindMinimum(t *testing.T) {
+	var bit BreakInTwoSearch
+	bit.Init(-5, 10, 1e-6, parabola)
+	var gr GoldenRatioSearch
+	gr.Init(-5, 10, 1e-6, parabola)
+	var fs FibonacciSearch
+	fs.Init(-5, 10, 1e-6, 1e-8, parabola)
+	searches := map[string]contextSearch{"break in two": &bit, "golden ratio": &gr, "fibonacci": &fs}
+	for name, search := range searches {
+		x, _, err := search.SolveContext(context.Background())
+		if err != nil {
+			t.Fatalf("error during %s search: %v", name, err)
+		}
+		if math.Abs(x-2) > 1e-5 {
+			t.Errorf("%s: expected minimum at 2, got %f", name, x)
+		}
+	}
+}
+
+func TestSvennBracketsMinimum(t *testing.T) {
+	var sv Svenn
+	sv.Init(0.1, -7, parabola)
+	a, b, err := sv.Solve()
+	if err != nil {
+		t.Fatalf("error during svenn algorithm: %v", err)
+	}
+	if a > 2 || b < 2 {
+		t.Errorf("minimum isn't bracketed: [%f, %f]", a, b)
+	}
+}
+
+func TestSvennMaxIterations(t *testing.T) {
+	var sv Svenn
+	sv.Init(0.1, 0, func(x float64) float64 { return -x })
+	sv.SetLimits(Limits{MaxIterations: 5})
+	_, _, err := sv.Solve()
+	if !errors.Is(err, optimization_errors.ErrMaxIterations) {
+		t.Errorf("expected maximum iterations error, got %v", err)
+	}
+}
+
+func TestSvennUnbounded(t *testing.T) {
+	var sv Svenn
+	sv.Init(0.1, 0, func(x float64) float64 { return -x })
+	_, _, err := sv.Solve()
+	if !errors.Is(err, optimization_errors.ErrUnbounded) {
+		t.Errorf("expected unbounded error, got %v", err)
+	}
+}
+
+func TestSvennNotUnimodal(t *testing.T) {
+	var sv Svenn
+	sv.Init(0.1, 0, func(x float64) float64 { return -x * x })
+	_, _, err := sv.Solve()
+	if !errors.Is(err, optimization_errors.ErrNotUnimodal) {
+		t.Errorf("expected not unimodal error, got %v", err)
+	}
+}
+
+func TestGoldenRatioLimits(t *testing.T) {
+	var gr GoldenRatioSearch
+	gr.Init(-5, 10, 1e-12, parabola)
+	gr.SetLimits(Limits{MaxFuncEvaluations: 10})
+	_, _, err := gr.SolveContext(context.Background())
+	if !errors.Is(err, optimization_errors.ErrMaxEvaluations) {
+		t.Errorf("expected maximum evaluations error, got %v", err)
+	}
+	if gr.FuncEvaluations() > 12 {
+		t.Errorf("evaluations limit is exceeded: %d", gr.FuncEvaluations())
+	}
+	slow := func(x float64) float64 {
+		time.Sleep(time.Millisecond)
+		return parabola(x)
+	}
+	gr.Init(-5, 10, 1e-12, slow)
+	gr.SetLimits(Limits{MaxTime: 5 * time.Millisecond})
+	_, _, err = gr.SolveContext(context.Background())
+	if !errors.Is(err, optimization_errors.ErrTimeLimit) {
+		t.Errorf("expected time limit error, got %v", err)
+	}
+}
+
+func TestCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var bit BreakInTwoSearch
+	bit.Init(-5, 10, 1e-6, parabola)
+	_, _, err := bit.SolveContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected cancelled context error, got %v", err)
+	}
+}
+
+func TestSearchesStopAtFloatResolution(t *testing.T) {
+	shifted := func(x float64) float64 {
+		return (x - 1000.3) * (x - 1000.3)
+	}
+	var bit BreakInTwoSearch
+	bit.Init(1000, 1001, 1e-15, shifted)
+	var gr GoldenRatioSearch
+	gr.Init(1000, 1001, 1e-15, shifted)
+	searches := map[string]contextSearch{"break in two": &bit, "golden ratio": &gr}
+	for name, search := range searches {
+		x, _, err := search.SolveContext(context.Background())
+		if err != nil {
+			t.Fatalf("error during %s search: %v", name, err)
+		}
+		if math.Abs(x-1000.3) > 1e-9 {
+			t.Errorf("%s: expected minimum at 1000.3, got %f", name, x)
+		}
+	}
+}
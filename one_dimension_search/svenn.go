@@ -1,7 +1,9 @@
 package one_dimension_search
 
 import (
+	"context"
 	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
 	"math"
 )
 
@@ -9,12 +11,13 @@ type Svenn struct {
 	tStep      float64
 	startPoint float64
 	targetFunc func(x float64) float64
+	searchStats
 }
 
 func (sv *Svenn) Init(tStep float64, startPoint float64, targetFunc func(x float64) float64) {
 	sv.startPoint = startPoint
 	sv.tStep = tStep
-	sv.targetFunc = targetFunc
+	sv.targetFunc = sv.countFunc(targetFunc)
 }
 
 func (sv *Svenn) SetStartPoint(startPoint float64) {
@@ -22,7 +25,12 @@ func (sv *Svenn) SetStartPoint(startPoint float64) {
 }
 
 func (sv *Svenn) Solve() (float64, float64, error) {
+	return sv.SolveContext(context.Background())
+}
+
+func (sv *Svenn) SolveContext(ctx context.Context) (float64, float64, error) {
 	var k float64
+	sv.reset(ctx)
 	x := sv.startPoint
 	a := sv.startPoint - sv.tStep
 	b := sv.startPoint + sv.tStep
@@ -33,7 +41,7 @@ func (sv *Svenn) Solve() (float64, float64, error) {
 		return a, b, nil
 	}
 	if checkInvalidCond(f1, f2, f3) {
-		return a, b, fmt.Errorf("function is not unimodal, select another start point")
+		return a, b, fmt.Errorf("%w, select another start point", optimization_errors.ErrNotUnimodal)
 	}
 	var delta float64
 	k = 1
@@ -49,6 +57,12 @@ func (sv *Svenn) Solve() (float64, float64, error) {
 	}
 	for {
 		xNext := x + math.Pow(2, k)*delta
+		if math.IsInf(xNext, 0) {
+			return a, b, fmt.Errorf("%w: minimum isn't bracketed after %v iterations", optimization_errors.ErrUnbounded, k)
+		}
+		if err := sv.interrupted(int(k)); err != nil {
+			return a, b, fmt.Errorf("minimum isn't bracketed: %w", err)
+		}
 		fNext := sv.targetFunc(xNext)
 		f := sv.targetFunc(x)
 		if checkDecreasing(fNext, f) {
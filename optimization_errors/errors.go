@@ -0,0 +1,44 @@
+package optimization_errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNotUnimodal       = errors.New("function is not unimodal")
+	ErrUnknownMethod     = errors.New("unknown method")
+	ErrDimensionMismatch = errors.New("dimensions don't match")
+	ErrSingularMatrix    = errors.New("matrix is singular")
+	ErrInfeasible        = errors.New("problem is infeasible")
+	ErrUnbounded         = errors.New("function is unbounded")
+	ErrMaxIterations     = errors.New("maximum iterations reached")
+	ErrMaxEvaluations    = errors.New("maximum function evaluations reached")
+	ErrTimeLimit         = errors.New("time limit reached")
+	ErrBoundsUnsupported = errors.New("bounds are not supported by solver")
+)
+
+type DimensionError struct {
+	Expected int
+	Actual   int
+}
+
+func (e *DimensionError) Error() string {
+	return fmt.Sprintf("%v: %d != %d", ErrDimensionMismatch, e.Actual, e.Expected)
+}
+
+func (e *DimensionError) Is(target error) bool {
+	return target == ErrDimensionMismatch
+}
+
+type MethodError struct {
+	Method string
+}
+
+func (e *MethodError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrUnknownMethod, e.Method)
+}
+
+func (e *MethodError) Is(target error) bool {
+	return target == ErrUnknownMethod
+}
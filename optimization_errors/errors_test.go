@@ -0,0 +1,47 @@
+package optimization_errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestDimensionErrorIs(t *testing.T) {
+	err := fmt.Errorf("error adding vectors: %w", &DimensionError{Expected: 3, Actual: 2})
+	if !errors.Is(err, ErrDimensionMismatch) {
+		t.Fatalf("wrapped dimension error isn't dimension mismatch: %v", err)
+	}
+	if errors.Is(err, ErrSingularMatrix) {
+		t.Fatalf("dimension error is singular matrix error: %v", err)
+	}
+	var dimensionError *DimensionError
+	if !errors.As(err, &dimensionError) {
+		t.Fatalf("dimension error isn't extracted: %v", err)
+	}
+	if dimensionError.Expected != 3 || dimensionError.Actual != 2 {
+		t.Errorf("wrong dimensions: %d, %d", dimensionError.Expected, dimensionError.Actual)
+	}
+}
+
+func TestMethodErrorIs(t *testing.T) {
+	err := fmt.Errorf("error solving: %w", &MethodError{Method: "newton"})
+	if !errors.Is(err, ErrUnknownMethod) {
+		t.Fatalf("wrapped method error isn't unknown method: %v", err)
+	}
+	var methodError *MethodError
+	if !errors.As(err, &methodError) || methodError.Method != "newton" {
+		t.Errorf("method error isn't extracted: %v", err)
+	}
+}
+
+func TestSentinelsAreDistinct(t *testing.T) {
+	sentinels := []error{ErrNotUnimodal, ErrUnknownMethod, ErrDimensionMismatch, ErrSingularMatrix,
+		ErrInfeasible, ErrUnbounded, ErrMaxIterations, ErrMaxEvaluations, ErrTimeLimit, ErrBoundsUnsupported}
+	for i, err := range sentinels {
+		for j, target := range sentinels {
+			if got := errors.Is(err, target); got != (i == j) {
+				t.Errorf("errors.Is(%v, %v) = %t", err, target, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package random_points_gen
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+type Distribution int
+
+const (
+	Uniform Distribution = iota
+	Normal
+	Cauchy
+	TruncatedNormal // normal limited by bounds
+	LogUniform      // uniform logarithm, positive bounds only
+)
+
+const normalDeviations = 3 // bounds cover three standard deviations around center
+
+type Generator interface {
+	Generate() [][]float64
+}
+
+type RandomGenerator interface {
+	Generator
+	SetRandom(random *rand.Rand)
+}
+
+type BoxGenerator interface {
+	Generator
+	Init(lower []float64, upper []float64, n int) error
+}
+
+func checkBox(lower []float64, upper []float64, n int) error {
+	if len(lower) != len(upper) || len(lower) == 0 {
+		return fmt.Errorf("wrong bounds length: %d, %d", len(lower), len(upper))
+	}
+	for i := range lower {
+		if !(lower[i] < upper[i]) || math.IsInf(lower[i], 0) || math.IsInf(upper[i], 0) {
+			return fmt.Errorf("wrong bounds: %f, %f", lower[i], upper[i])
+		}
+	}
+	if n < 1 {
+		return fmt.Errorf("wrong points number: %d", n)
+	}
+	return nil
+}
+
+func truncatedNormal(random *rand.Rand, lower float64, upper float64) float64 {
+	for {
+		x := random.NormFloat64()
+		if x >= lower && x <= upper {
+			return x
+		}
+	}
+}
+
+func scale(point []float64, lower []float64, upper []float64) []float64 {
+	for i := range point {
+		point[i] = lower[i] + point[i]*(upper[i]-lower[i])
+	}
+	return point
+}
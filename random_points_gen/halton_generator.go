@@ -0,0 +1,62 @@
+package random_points_gen
+
+type HaltonGen struct {
+	lower []float64
+	upper []float64
+	n     int
+	bases []int // first primes, one per dimension
+}
+
+func (hg *HaltonGen) Init(lower []float64, upper []float64, n int) error {
+	err := checkBox(lower, upper, n)
+	if err != nil {
+		return err
+	}
+	hg.lower = lower
+	hg.upper = upper
+	hg.n = n
+	hg.bases = primes(len(lower))
+	return nil
+}
+
+func (hg *HaltonGen) Generate() [][]float64 {
+	points := make([][]float64, hg.n)
+	for i := range points {
+		points[i] = make([]float64, len(hg.bases))
+		for j, base := range hg.bases {
+			points[i][j] = radicalInverse(i+1, base) // zero point is skipped
+		}
+		points[i] = scale(points[i], hg.lower, hg.upper)
+	}
+	return points
+}
+
+func radicalInverse(index int, base int) float64 {
+	var result float64
+	fraction := 1 / float64(base)
+	for ; index > 0; index /= base {
+		result += float64(index%base) * fraction
+		fraction /= float64(base)
+	}
+	return result
+}
+
+func primes(n int) []int {
+	var result []int
+	for candidate := 2; len(result) < n; candidate++ {
+		prime := true
+		for _, p := range result {
+			if p*p > candidate {
+				break
+			}
+			if candidate%p == 0 {
+				prime = false
+				break
+			}
+		}
+		if prime {
+			result = append(result, candidate)
+		}
+	}
+	return result
+}
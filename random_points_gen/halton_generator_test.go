@@ -0,0 +1,50 @@
+package random_points_gen
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHaltonReferencePoints(t *testing.T) {
+	want := [][]float64{
+		{1.0 / 2, 1.0 / 3, 1.0 / 5},
+		{1.0 / 4, 2.0 / 3, 2.0 / 5},
+		{3.0 / 4, 1.0 / 9, 3.0 / 5},
+		{1.0 / 8, 4.0 / 9, 4.0 / 5},
+		{5.0 / 8, 7.0 / 9, 1.0 / 25},
+	}
+	var hg HaltonGen
+	err := hg.Init([]float64{0, 0, 0}, []float64{1, 1, 1}, len(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	points := hg.Generate()
+	for i := range want {
+		for j := range want[i] {
+			if math.Abs(points[i][j]-want[i][j]) > 1e-15 {
+				t.Errorf("point %d: got %v, want %v", i, points[i], want[i])
+				break
+			}
+		}
+	}
+}
+
+func TestPrimes(t *testing.T) {
+	want := []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}
+	got := primes(len(want))
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestHaltonWrongBox(t *testing.T) {
+	var hg HaltonGen
+	if err := hg.Init([]float64{1}, []float64{0}, 10); err == nil {
+		t.Error("inverted bounds are accepted")
+	}
+	if err := hg.Init([]float64{0}, []float64{1}, 0); err == nil {
+		t.Error("zero points number is accepted")
+	}
+}
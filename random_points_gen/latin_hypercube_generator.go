@@ -0,0 +1,50 @@
+package random_points_gen
+
+import (
+	"math/rand"
+	"time"
+)
+
+type LatinHypercubeGen struct {
+	lower  []float64
+	upper  []float64
+	n      int
+	random *rand.Rand
+}
+
+func (lhg *LatinHypercubeGen) Init(lower []float64, upper []float64, n int) error {
+	err := checkBox(lower, upper, n)
+	if err != nil {
+		return err
+	}
+	lhg.lower = lower
+	lhg.upper = upper
+	lhg.n = n
+	lhg.random = rand.New(rand.NewSource(time.Now().UnixNano()))
+	return nil
+}
+
+func (lhg *LatinHypercubeGen) SetSeed(seed int64) {
+	lhg.random = rand.New(rand.NewSource(seed))
+}
+
+func (lhg *LatinHypercubeGen) SetRandom(random *rand.Rand) {
+	lhg.random = random
+}
+
+func (lhg *LatinHypercubeGen) Generate() [][]float64 {
+	points := make([][]float64, lhg.n)
+	for i := range points {
+		points[i] = make([]float64, len(lhg.lower))
+	}
+	for j := range lhg.lower {
+		strata := lhg.random.Perm(lhg.n) // one point in every stratum
+		for i := range points {
+			points[i][j] = (float64(strata[i]) + lhg.random.Float64()) / float64(lhg.n)
+		}
+	}
+	for i := range points {
+		points[i] = scale(points[i], lhg.lower, lhg.upper)
+	}
+	return points
+}
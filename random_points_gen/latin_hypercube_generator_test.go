@@ -0,0 +1,35 @@
+package random_points_gen
+
+import (
+	"testing"
+)
+
+func TestLatinHypercubeStrata(t *testing.T) {
+	const n = 50
+	lower, upper := []float64{-1, 0, 100}, []float64{1, 5, 200}
+	var lhg LatinHypercubeGen
+	err := lhg.Init(lower, upper, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lhg.SetSeed(7)
+	points := lhg.Generate()
+	if len(points) != n {
+		t.Fatalf("got %d points, want %d", len(points), n)
+	}
+	for j := range lower {
+		strata := make([]int, n)
+		for _, p := range points {
+			s := int((p[j] - lower[j]) / (upper[j] - lower[j]) * n)
+			if s < 0 || s >= n {
+				t.Fatalf("point %v is out of bounds", p)
+			}
+			strata[s]++
+		}
+		for s, count := range strata {
+			if count != 1 {
+				t.Errorf("dimension %d: stratum %d has %d points", j, s, count)
+			}
+		}
+	}
+}
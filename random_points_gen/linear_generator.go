@@ -1,12 +1,16 @@
 package random_points_gen
 
-import "math"
+import (
+	"fmt"
+	"math"
+)
 
 type LinGen struct {
 	startPoint []float64
 	a          []float64
 	c          []float64
-	end        float64
+	lower      []float64
+	upper      []float64 // modulus is bounds width
 	n          int
 }
 
@@ -15,8 +19,30 @@ func (lg *LinGen) Init(startPoint []float64, a []float64, c []float64,
 	lg.startPoint = startPoint
 	lg.a = a
 	lg.c = c
-	lg.end = end
+	lg.lower = make([]float64, len(startPoint))
+	lg.upper = make([]float64, len(startPoint))
+	for i := range lg.upper {
+		lg.upper[i] = end
+	}
+	lg.n = n
+}
+
+func (lg *LinGen) InitBounds(startPoint []float64, a []float64, c []float64,
+	lower []float64, upper []float64, n int) error {
+	err := checkBox(lower, upper, n)
+	if err != nil {
+		return err
+	}
+	if len(startPoint) != len(lower) || len(a) != len(lower) || len(c) != len(lower) {
+		return fmt.Errorf("wrong generator parameters length: %d, %d, %d", len(startPoint), len(a), len(c))
+	}
+	lg.startPoint = startPoint
+	lg.a = a
+	lg.c = c
+	lg.lower = lower
+	lg.upper = upper
 	lg.n = n
+	return nil
 }
 
 func (lg *LinGen) Generate() [][]float64 {
@@ -28,7 +54,7 @@ func (lg *LinGen) Generate() [][]float64 {
 	}
 	for i := 0; i < dimension; i++ {
 		for j := 1; j < lg.n; j++ {
-			points[j][i] = gen(lg.a[i], lg.c[i], points[j-1][i], lg.end)
+			points[j][i] = lg.lower[i] + gen(lg.a[i], lg.c[i], points[j-1][i]-lg.lower[i], lg.upper[i]-lg.lower[i])
 		}
 	}
 	return points
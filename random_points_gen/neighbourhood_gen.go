@@ -0,0 +1,45 @@
+package random_points_gen
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+type NeighbourhoodGen struct {
+	distribution Distribution
+	random       *rand.Rand
+}
+
+func (ng *NeighbourhoodGen) Init(distribution Distribution) {
+	ng.distribution = distribution
+	ng.random = rand.New(rand.NewSource(time.Now().UnixNano()))
+}
+
+func (ng *NeighbourhoodGen) SetSeed(seed int64) {
+	ng.random = rand.New(rand.NewSource(seed))
+}
+
+func (ng *NeighbourhoodGen) SetRandom(random *rand.Rand) {
+	ng.random = random
+}
+
+func (ng *NeighbourhoodGen) Generate(center []float64, scale []float64) []float64 {
+	point := make([]float64, len(center))
+	for i := range point {
+		point[i] = center[i] + scale[i]*ng.sample()
+	}
+	return point
+}
+
+func (ng *NeighbourhoodGen) sample() float64 {
+	switch ng.distribution {
+	case Normal:
+		return ng.random.NormFloat64()
+	case TruncatedNormal:
+		return truncatedNormal(ng.random, -normalDeviations, normalDeviations)
+	case Cauchy:
+		return math.Tan(math.Pi * (ng.random.Float64() - 0.5))
+	}
+	return 2*ng.random.Float64() - 1
+}
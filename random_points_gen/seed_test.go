@@ -0,0 +1,56 @@
+package random_points_gen
+
+import (
+	"testing"
+)
+
+func samePoints(a [][]float64, b [][]float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		for j := range a[i] {
+			if a[i][j] != b[i][j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestSeededGeneratorsReproducible(t *testing.T) {
+	lower, upper := []float64{0.1, -1, 2}, []float64{10, 1, 3}
+	generators := map[string]func(seed int64) [][]float64{
+		"latin hypercube": func(seed int64) [][]float64 {
+			var lhg LatinHypercubeGen
+			_ = lhg.Init(lower, upper, 20)
+			lhg.SetSeed(seed)
+			return lhg.Generate()
+		},
+		"standart distribution": func(seed int64) [][]float64 {
+			var sdg StDistributionGen
+			_ = sdg.InitBounds(lower, upper, 20)
+			_ = sdg.SetDistributions([]Distribution{LogUniform, TruncatedNormal, Cauchy})
+			sdg.SetSeed(seed)
+			return sdg.Generate()
+		},
+		"neighbourhood": func(seed int64) [][]float64 {
+			var ng NeighbourhoodGen
+			ng.Init(Normal)
+			ng.SetSeed(seed)
+			points := make([][]float64, 20)
+			for i := range points {
+				points[i] = ng.Generate(lower, []float64{0.5, 0.5, 0.5})
+			}
+			return points
+		},
+	}
+	for name, generate := range generators {
+		if !samePoints(generate(11), generate(11)) {
+			t.Errorf("%s: points generated with same seed differ", name)
+		}
+		if samePoints(generate(11), generate(12)) {
+			t.Errorf("%s: points generated with different seeds are same", name)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package random_points_gen
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	sobolBits         = 32
+	SobolMaxDimension = 21 // first dimension and directions table
+)
+
+// joe kuo direction numbers starting from the second dimension
+var sobolDirections = []struct {
+	s int
+	a uint32
+	m []uint32
+}{
+	{1, 0, []uint32{1}},
+	{2, 1, []uint32{1, 3}},
+	{3, 1, []uint32{1, 3, 1}},
+	{3, 2, []uint32{1, 1, 1}},
+	{4, 1, []uint32{1, 1, 3, 3}},
+	{4, 4, []uint32{1, 3, 5, 13}},
+	{5, 2, []uint32{1, 1, 5, 5, 17}},
+	{5, 4, []uint32{1, 1, 5, 5, 5}},
+	{5, 7, []uint32{1, 1, 7, 11, 19}},
+	{5, 11, []uint32{1, 1, 5, 1, 1}},
+	{5, 13, []uint32{1, 1, 1, 3, 11}},
+	{5, 14, []uint32{1, 3, 5, 5, 31}},
+	{6, 1, []uint32{1, 3, 3, 9, 7, 49}},
+	{6, 13, []uint32{1, 1, 1, 15, 21, 21}},
+	{6, 16, []uint32{1, 3, 1, 13, 27, 49}},
+	{6, 19, []uint32{1, 1, 1, 15, 7, 5}},
+	{6, 22, []uint32{1, 3, 1, 15, 13, 25}},
+	{6, 25, []uint32{1, 1, 5, 5, 19, 61}},
+	{7, 1, []uint32{1, 3, 7, 11, 23, 15, 103}},
+	{7, 4, []uint32{1, 3, 7, 13, 13, 15, 69}},
+}
+
+type SobolGen struct {
+	lower      []float64
+	upper      []float64
+	n          int
+	directions [][]uint32
+}
+
+func (sg *SobolGen) Init(lower []float64, upper []float64, n int) error {
+	err := checkBox(lower, upper, n)
+	if err != nil {
+		return err
+	}
+	if len(lower) > SobolMaxDimension {
+		return fmt.Errorf("sobol sequence dimension is not supported: %d", len(lower))
+	}
+	if n >= 1<<sobolBits-1 {
+		return fmt.Errorf("wrong points number: %d", n)
+	}
+	sg.lower = lower
+	sg.upper = upper
+	sg.n = n
+	sg.directions = make([][]uint32, len(lower))
+	for j := range sg.directions {
+		sg.directions[j] = directionNumbers(j)
+	}
+	return nil
+}
+
+func (sg *SobolGen) Generate() [][]float64 {
+	points := make([][]float64, sg.n)
+	x := make([]uint32, len(sg.directions))
+	for i := range points {
+		points[i] = make([]float64, len(x))
+		for j := range x {
+			if i > 0 {
+				x[j] ^= sg.directions[j][trailingOnes(uint32(i-1))] // gray code order
+			}
+			points[i][j] = float64(x[j]) / math.Exp2(sobolBits)
+		}
+		points[i] = scale(points[i], sg.lower, sg.upper)
+	}
+	return points
+}
+
+func directionNumbers(dimension int) []uint32 {
+	v := make([]uint32, sobolBits)
+	if dimension == 0 {
+		for i := range v {
+			v[i] = 1 << (sobolBits - 1 - i)
+		}
+		return v
+	}
+	d := sobolDirections[dimension-1]
+	for i := 0; i < sobolBits; i++ {
+		if i < d.s {
+			v[i] = d.m[i] << (sobolBits - 1 - i)
+			continue
+		}
+		v[i] = v[i-d.s] ^ (v[i-d.s] >> d.s)
+		for k := 1; k < d.s; k++ {
+			v[i] ^= ((d.a >> (d.s - 1 - k)) & 1) * v[i-k]
+		}
+	}
+	return v
+}
+
+func trailingOnes(value uint32) int {
+	var c int
+	for ; value&1 == 1; value >>= 1 {
+		c++
+	}
+	return c
+}
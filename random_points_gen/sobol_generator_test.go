@@ -0,0 +1,83 @@
+package random_points_gen
+
+import (
+	"testing"
+)
+
+func TestSobolReferencePoints(t *testing.T) {
+	want := [][]float64{ // joe kuo reference values
+		{0, 0, 0},
+		{0.5, 0.5, 0.5},
+		{0.75, 0.25, 0.25},
+		{0.25, 0.75, 0.75},
+		{0.375, 0.375, 0.625},
+		{0.875, 0.875, 0.125},
+		{0.625, 0.125, 0.875},
+		{0.125, 0.625, 0.375},
+	}
+	var sg SobolGen
+	err := sg.Init([]float64{0, 0, 0}, []float64{1, 1, 1}, len(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	points := sg.Generate()
+	for i := range want {
+		for j := range want[i] {
+			if points[i][j] != want[i][j] {
+				t.Errorf("point %d: got %v, want %v", i, points[i], want[i])
+				break
+			}
+		}
+	}
+}
+
+func TestSobolStrata(t *testing.T) {
+	const n = 64 // every power of two prefix has one point in every elementary interval
+	lower, upper := make([]float64, 8), make([]float64, 8)
+	for j := range upper {
+		upper[j] = 1
+	}
+	var sg SobolGen
+	err := sg.Init(lower, upper, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	points := sg.Generate()
+	for j := range lower {
+		strata := make([]bool, n)
+		for _, p := range points {
+			strata[int(p[j]*n)] = true
+		}
+		for s, filled := range strata {
+			if !filled {
+				t.Errorf("dimension %d: stratum %d is empty", j, s)
+			}
+		}
+	}
+}
+
+func TestSobolBounds(t *testing.T) {
+	var sg SobolGen
+	lower, upper := []float64{-2, 10}, []float64{2, 20}
+	err := sg.Init(lower, upper, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range sg.Generate() {
+		for j := range p {
+			if p[j] < lower[j] || p[j] >= upper[j] {
+				t.Fatalf("point %v is out of bounds", p)
+			}
+		}
+	}
+	lower, upper = make([]float64, SobolMaxDimension+1), make([]float64, SobolMaxDimension+1)
+	for j := range upper {
+		upper[j] = 1
+	}
+	if err = sg.Init(lower[1:], upper[1:], 10); err != nil {
+		t.Errorf("maximum dimension isn't supported: %v", err)
+	}
+	if err = sg.Init(lower, upper, 10); err == nil {
+		t.Error("unsupported dimension is accepted")
+	}
+}
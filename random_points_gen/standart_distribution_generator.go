@@ -1,41 +1,98 @@
 package random_points_gen
 
 import (
+	"fmt"
+	"math"
 	"math/rand"
 	"time"
 )
 
 type StDistributionGen struct {
-	start     float64
-	end       float64
-	n         int
-	dimension int
-	shift     float64
+	lower         []float64
+	upper         []float64
+	distributions []Distribution // uniform when not set
+	n             int
+	random        *rand.Rand
 }
 
 func (sdg *StDistributionGen) Init(start float64,
 	end float64, n int, dimension int) {
-	sdg.start = start
-	sdg.end = end
+	lower := make([]float64, dimension)
+	upper := make([]float64, dimension)
+	for i := 0; i < dimension; i++ {
+		lower[i] = start
+		upper[i] = end
+	}
+	sdg.lower = lower
+	sdg.upper = upper
+	sdg.distributions = nil
 	sdg.n = n
-	sdg.dimension = dimension
-	sdg.shift = end - start
+	sdg.random = rand.New(rand.NewSource(time.Now().UnixNano()))
 }
 
-func (sdg *StDistributionGen) Generate() [][]float64 {
+func (sdg *StDistributionGen) InitBounds(lower []float64, upper []float64, n int) error {
+	err := checkBox(lower, upper, n)
+	if err != nil {
+		return err
+	}
+	sdg.lower = lower
+	sdg.upper = upper
+	sdg.distributions = nil
+	sdg.n = n
+	sdg.random = rand.New(rand.NewSource(time.Now().UnixNano()))
+	return nil
+}
+
+func (sdg *StDistributionGen) SetDistributions(distributions []Distribution) error {
+	if len(distributions) != len(sdg.lower) {
+		return fmt.Errorf("wrong distributions length: %d", len(distributions))
+	}
+	for i, distribution := range distributions {
+		if distribution == LogUniform && !(sdg.lower[i] > 0) {
+			return fmt.Errorf("wrong log uniform bounds: %f, %f", sdg.lower[i], sdg.upper[i])
+		}
+	}
+	sdg.distributions = distributions
+	return nil
+}
 
-	randomSource := rand.NewSource(time.Now().UnixNano())
-	random := rand.New(randomSource)
+func (sdg *StDistributionGen) SetSeed(seed int64) {
+	sdg.random = rand.New(rand.NewSource(seed))
+}
 
+func (sdg *StDistributionGen) SetRandom(random *rand.Rand) {
+	sdg.random = random
+}
+
+func (sdg *StDistributionGen) Generate() [][]float64 {
 	points := make([][]float64, sdg.n)
 	for i := 0; i < sdg.n; i++ {
-		points[i] = make([]float64, sdg.dimension)
+		points[i] = make([]float64, len(sdg.lower))
 	}
-	//var val float64
 	for i := 0; i < sdg.n; i++ {
-		for j := 0; j < sdg.dimension; j++ {
-			points[i][j] = random.Float64()*float64(sdg.n)*sdg.shift/(float64(sdg.n)-1) + sdg.start
+		for j := range sdg.lower {
+			points[i][j] = sdg.sample(j)
 		}
 	}
 	return points
 }
+
+func (sdg *StDistributionGen) sample(j int) float64 {
+	lower, upper := sdg.lower[j], sdg.upper[j]
+	center, deviation := (lower+upper)/2, (upper-lower)/(2*normalDeviations)
+	distribution := Uniform
+	if sdg.distributions != nil {
+		distribution = sdg.distributions[j]
+	}
+	switch distribution {
+	case Normal:
+		return center + deviation*sdg.random.NormFloat64()
+	case TruncatedNormal:
+		return center + deviation*truncatedNormal(sdg.random, -normalDeviations, normalDeviations)
+	case Cauchy:
+		return center + deviation*math.Tan(math.Pi*(sdg.random.Float64()-0.5))
+	case LogUniform:
+		return math.Exp(math.Log(lower) + sdg.random.Float64()*(math.Log(upper)-math.Log(lower)))
+	}
+	return lower + sdg.random.Float64()*(upper-lower)
+}
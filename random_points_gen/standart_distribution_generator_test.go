@@ -0,0 +1,120 @@
+package random_points_gen
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStDistributionGenBounds(t *testing.T) {
+	lower, upper := []float64{-1, 0.01, 5}, []float64{1, 100, 6}
+	var sdg StDistributionGen
+	err := sdg.InitBounds(lower, upper, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sdg.SetSeed(3)
+	for _, distributions := range [][]Distribution{
+		{Uniform, Uniform, Uniform},
+		{TruncatedNormal, LogUniform, TruncatedNormal},
+	} {
+		err = sdg.SetDistributions(distributions)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, p := range sdg.Generate() {
+			for j := range p {
+				if p[j] < lower[j] || p[j] > upper[j] {
+					t.Fatalf("distributions %v: point %v is out of bounds", distributions, p)
+				}
+			}
+		}
+	}
+}
+
+func TestStDistributionGenLogUniform(t *testing.T) {
+	var sdg StDistributionGen
+	err := sdg.InitBounds([]float64{0.001}, []float64{1000}, 10000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sdg.SetSeed(5)
+	err = sdg.SetDistributions([]Distribution{LogUniform})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var below int
+	for _, p := range sdg.Generate() {
+		if p[0] < 1 {
+			below++
+		}
+	}
+	if math.Abs(float64(below)/10000-0.5) > 0.03 { // half of the logarithm range is below one
+		t.Errorf("got %d points below one, want about half", below)
+	}
+}
+
+func TestStDistributionGenNormal(t *testing.T) {
+	var sdg StDistributionGen
+	err := sdg.InitBounds([]float64{4}, []float64{10}, 20000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sdg.SetSeed(11)
+	err = sdg.SetDistributions([]Distribution{Normal})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sum, squaresSum float64
+	for _, p := range sdg.Generate() {
+		sum += p[0]
+		squaresSum += p[0] * p[0]
+	}
+	mean := sum / 20000
+	deviation := math.Sqrt(squaresSum/20000 - mean*mean)
+	if math.Abs(mean-7) > 0.05 || math.Abs(deviation-1) > 0.05 { // bounds cover three deviations
+		t.Errorf("got mean %f and deviation %f, want 7 and 1", mean, deviation)
+	}
+}
+
+func TestStDistributionGenWrongParameters(t *testing.T) {
+	var sdg StDistributionGen
+	if err := sdg.InitBounds([]float64{0, 0}, []float64{1}, 10); err == nil {
+		t.Error("bounds of different length are accepted")
+	}
+	if err := sdg.InitBounds([]float64{0}, []float64{math.Inf(1)}, 10); err == nil {
+		t.Error("infinite bound is accepted")
+	}
+	err := sdg.InitBounds([]float64{-1, 1}, []float64{1, 2}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = sdg.SetDistributions([]Distribution{LogUniform, Uniform}); err == nil {
+		t.Error("log uniform distribution with negative bound is accepted")
+	}
+	if err = sdg.SetDistributions([]Distribution{Uniform}); err == nil {
+		t.Error("distributions of wrong length are accepted")
+	}
+}
+
+func TestLinGenBounds(t *testing.T) {
+	lower, upper := []float64{-3, 10}, []float64{3, 11}
+	var lg LinGen
+	err := lg.InitBounds([]float64{0, 10.5}, []float64{5, 3}, []float64{1.5, 0.25}, lower, upper, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	points := lg.Generate()
+	if len(points) != 30 {
+		t.Fatalf("got %d points, want 30", len(points))
+	}
+	for _, p := range points {
+		for j := range p {
+			if p[j] < lower[j] || p[j] >= upper[j] {
+				t.Fatalf("point %v is out of bounds", p)
+			}
+		}
+	}
+	if err = lg.InitBounds([]float64{0}, []float64{5, 3}, []float64{1, 1}, lower, upper, 30); err == nil {
+		t.Error("start point of wrong length is accepted")
+	}
+}
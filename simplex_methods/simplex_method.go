@@ -3,6 +3,7 @@ package simplex_methods
 import (
 	"fmt"
 	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
 	"gonum.org/v1/gonum/mat"
 	"sort"
 )
@@ -22,18 +23,18 @@ type SimplexMethod struct {
 
 func (sm *SimplexMethod) Init(n int, m int, constraints [][]float64, f []float64, firstPhase int) error {
 	if len(constraints) != m {
-		return fmt.Errorf("wrong constraints dimension:%d != %d", len(constraints), m)
+		return fmt.Errorf("wrong constraints dimension: %w", &optimization_errors.DimensionError{Expected: m, Actual: len(constraints)})
 	}
 	sm.m = m
 	sm.constraints = constraints
 	if len(f) != n {
-		return fmt.Errorf("wrong f dimension:%d != %d", len(f), n)
+		return fmt.Errorf("wrong f dimension: %w", &optimization_errors.DimensionError{Expected: n, Actual: len(f)})
 	}
 	sm.n = n
 	sm.f = f
 	sm.fr = n - m
 	if firstPhase != FIRST && firstPhase != SECOND {
-		return fmt.Errorf("wrong phase const")
+		return &optimization_errors.MethodError{Method: fmt.Sprintf("first phase %d", firstPhase)}
 	}
 	sm.firstPhase = firstPhase
 
@@ -49,12 +50,12 @@ func (sm *SimplexMethod) Solve() ([]float64, float64, error) {
 	if sm.firstPhase == FIRST {
 		baseIndexA, freeIndexA, bMatrix, system, err = sm.firstPhaseGauss()
 		if err != nil {
-			return nil, 0, fmt.Errorf("error during first phase: %v", err)
+			return nil, 0, fmt.Errorf("error during first phase: %w", err)
 		}
 	} else if sm.firstPhase == SECOND {
 		baseIndexA, freeIndexA, bMatrix, system, err = sm.firstPhaseSimplex()
 		if err != nil {
-			return nil, 0, fmt.Errorf("error during first phase: %v", err)
+			return nil, 0, fmt.Errorf("error during first phase: %w", err)
 		}
 	}
 	_, _, b, min, err := simplex(sm.m, sm.fr, sm.n, bMatrix, freeIndexA, baseIndexA, sm.f, system, sm.eps)
@@ -79,7 +80,7 @@ func simplex(m int, fr int, n int, bMatrix la_methods.Matrix, freeIndexA []int,
 	var bOldVec, bNewVec la_methods.Vector
 
 	if err != nil {
-		return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error initing matrix: %v", err)
+		return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error initing matrix: %w", err)
 	}
 
 	for {
@@ -93,7 +94,10 @@ func simplex(m int, fr int, n int, bMatrix la_methods.Matrix, freeIndexA []int,
 
 		bDense := mat.NewDense(m, m, bPointsFlat)
 		bDenseInv := mat.NewDense(m, m, nil)
-		_ = bDenseInv.Inverse(bDense)
+		err = la_methods.InverseError(bDenseInv.Inverse(bDense))
+		if err != nil {
+			return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error inverting basis matrix: %w", err)
+		}
 
 		var bI [][]float64
 		rows, cols := bDenseInv.Dims()
@@ -102,7 +106,7 @@ func simplex(m int, fr int, n int, bMatrix la_methods.Matrix, freeIndexA []int,
 		}
 		err = bInverted.InitWithPoints(rows, cols, bI)
 		if err != nil {
-			return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error initing matrix: %v", err)
+			return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error initing matrix: %w", err)
 		}
 
 		//fmt.Println(baseIndexA)
@@ -114,7 +118,7 @@ func simplex(m int, fr int, n int, bMatrix la_methods.Matrix, freeIndexA []int,
 
 		err = cT.InitWithPoints(1, m, [][]float64{cTPoints})
 		if err != nil {
-			return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error initing matrix: %v", err)
+			return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error initing matrix: %w", err)
 		}
 
 		for i := 0; i < m; i++ {
@@ -122,20 +126,20 @@ func simplex(m int, fr int, n int, bMatrix la_methods.Matrix, freeIndexA []int,
 		}
 		err = bOldVec.InitWithPoints(m, bOld)
 		if err != nil {
-			return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error initing vector: %v", err)
+			return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error initing vector: %w", err)
 		}
 		bNewVec, err = bInverted.MulV(bOldVec)
 		if err != nil {
-			return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error multiplying: %v", err)
+			return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error multiplying: %w", err)
 		}
 
 		piMatrix, err = cT.MulM(bInverted)
 		if err != nil {
-			return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error multiplying matrix: %v", err)
+			return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error multiplying matrix: %w", err)
 		}
 		err = piVector.InitWithPoints(m, piMatrix.Points[0])
 		if err != nil {
-			return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error initing vector: %v", err)
+			return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error initing vector: %w", err)
 		}
 		var a = make([]float64, m)
 		var aVec la_methods.Vector
@@ -146,11 +150,11 @@ func simplex(m int, fr int, n int, bMatrix la_methods.Matrix, freeIndexA []int,
 			}
 			err = aVec.InitWithPoints(m, a)
 			if err != nil {
-				return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error initing vector: %v", err)
+				return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error initing vector: %w", err)
 			}
 			d, err = piVector.Mul(aVec)
 			if err != nil {
-				return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error multiplying vectors: %v", err)
+				return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error multiplying vectors: %w", err)
 			}
 			d -= f[freeIndexA[i]]
 			ds[i] = d
@@ -178,7 +182,7 @@ func simplex(m int, fr int, n int, bMatrix la_methods.Matrix, freeIndexA []int,
 			}
 			err = newMatrix.InitWithPoints(m, m, newMatrixPoints)
 			if err != nil {
-				return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error initing matrix:%v", err)
+				return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error initing matrix:%w", err)
 			}
 
 			return newMatrix, baseIndexA, xVec.Points, val, nil
@@ -188,16 +192,16 @@ func simplex(m int, fr int, n int, bMatrix la_methods.Matrix, freeIndexA []int,
 		}
 		err = aVec.InitWithPoints(m, a)
 		if err != nil {
-			return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error initing vector: %v", err)
+			return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error initing vector: %w", err)
 		}
 		aVecNew, err = bInverted.MulV(aVec)
 		if err != nil {
-			return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error multiplying: %v", err)
+			return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("error multiplying: %w", err)
 		}
 
 		has2, _, baMinI = findMin(bNewVec, aVecNew, m)
 		if !has2 {
-			return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("function is limitless")
+			return la_methods.Matrix{}, []int{}, nil, 0, fmt.Errorf("%w: simplex ratio test has no leaving variable", optimization_errors.ErrUnbounded)
 		}
 
 		for i := 0; i < m; i++ {
@@ -249,7 +253,7 @@ func (sm *SimplexMethod) firstPhaseGauss() ([]int, []int, la_methods.Matrix, la_
 	var err error
 	err = matrix.InitWithPoints(sm.m, sm.n+1, sm.constraints)
 	if err != nil {
-		return nil, nil, la_methods.Matrix{}, la_methods.Matrix{}, fmt.Errorf("error initializing matrix: %v", err)
+		return nil, nil, la_methods.Matrix{}, la_methods.Matrix{}, fmt.Errorf("error initializing matrix: %w", err)
 	}
 	matrix = matrix.MakeE()
 	var freeIndexA = make([]int, sm.fr)
@@ -298,7 +302,7 @@ func (sm *SimplexMethod) firstPhaseSimplex() ([]int, []int, la_methods.Matrix, l
 	var system la_methods.Matrix
 	err = system.InitWithPoints(sm.m, mn+1, sMatrixPoints)
 	if err != nil {
-		return nil, nil, la_methods.Matrix{}, la_methods.Matrix{}, fmt.Errorf("error initing matrix: %v", err)
+		return nil, nil, la_methods.Matrix{}, la_methods.Matrix{}, fmt.Errorf("error initing matrix: %w", err)
 	}
 	var f = make([]float64, mn)
 	for i := 0; i < sm.m; i++ {
@@ -306,9 +310,13 @@ func (sm *SimplexMethod) firstPhaseSimplex() ([]int, []int, la_methods.Matrix, l
 	}
 	var baseIndex, freeIndex []int
 	var newMatrix la_methods.Matrix
-	newMatrix, baseIndex, _, _, err = simplex(sm.m, sm.n, mn, bFirst, freeAFirst, baseAFirst, f, system, sm.eps)
+	var artificialSum float64
+	newMatrix, baseIndex, _, artificialSum, err = simplex(sm.m, sm.n, mn, bFirst, freeAFirst, baseAFirst, f, system, sm.eps)
 	if err != nil {
-		return nil, nil, la_methods.Matrix{}, la_methods.Matrix{}, fmt.Errorf("error initing matrix: %v", err)
+		return nil, nil, la_methods.Matrix{}, la_methods.Matrix{}, fmt.Errorf("error initing matrix: %w", err)
+	}
+	if artificialSum < sm.eps {
+		return nil, nil, la_methods.Matrix{}, la_methods.Matrix{}, fmt.Errorf("%w: artificial variables sum is %f", optimization_errors.ErrInfeasible, -artificialSum)
 	}
 	var baseIndexHelp []int = make([]int, len(baseIndex))
 	copy(baseIndexHelp, baseIndex)
@@ -1,7 +1,9 @@
 package simplex_methods
 
 import (
+	"errors"
 	"fmt"
+	"github.com/saskamegaprogrammist/optimization_methods/optimization_errors"
 	"math"
 )
 
@@ -19,18 +21,18 @@ type SimplexMethodReal struct {
 
 func (smr *SimplexMethodReal) Init(n int, m int, constraints [][]float64, f []float64, firstPhase int) error {
 	if len(constraints) != m {
-		return fmt.Errorf("wrong constraints dimension:%d != %d", len(constraints), m)
+		return fmt.Errorf("wrong constraints dimension: %w", &optimization_errors.DimensionError{Expected: m, Actual: len(constraints)})
 	}
 	smr.m = m
 	smr.constraints = constraints
 	if len(f) != n {
-		return fmt.Errorf("wrong f dimension:%d != %d", len(f), n)
+		return fmt.Errorf("wrong f dimension: %w", &optimization_errors.DimensionError{Expected: n, Actual: len(f)})
 	}
 	smr.n = n
 	smr.f = f
 	smr.fr = n - m
 	if firstPhase != FIRST && firstPhase != SECOND {
-		return fmt.Errorf("wrong phase const")
+		return &optimization_errors.MethodError{Method: fmt.Sprintf("first phase %d", firstPhase)}
 	}
 	smr.firstPhase = firstPhase
 
@@ -45,9 +47,12 @@ func (smr *SimplexMethodReal) SolveReal() ([]float64, float64, error) {
 	var err error
 	err = sm.Init(smr.n, smr.m, smr.constraints, smr.f, smr.firstPhase)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error initing simplex method: %v", err)
+		return nil, 0, fmt.Errorf("error initing simplex method: %w", err)
 	}
 	x, fVal, err = sm.Solve()
+	if err != nil {
+		return nil, 0, fmt.Errorf("error solving simplex method: %w", err)
+	}
 	var hasFr bool
 	var optimizeIndex int
 	var realPart float64
@@ -104,9 +109,15 @@ func (smr *SimplexMethodReal) SolveReal() ([]float64, float64, error) {
 		J1Constraints = J1Constraints[1:]
 		err = sm.Init(n, m, constraints, f, smr.firstPhase)
 		if err != nil {
-			return nil, 0, fmt.Errorf("error initing simplex method: %v", err)
+			return nil, 0, fmt.Errorf("error initing simplex method: %w", err)
 		}
 		x1, f1, err = sm.Solve()
+		if errors.Is(err, optimization_errors.ErrInfeasible) {
+			continue // branch without integer points
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("error solving simplex method: %w", err)
+		}
 		//fmt.Println(f1)
 		//fmt.Println(x1)
 		realPart = math.Floor(x1[optimizeIndex])
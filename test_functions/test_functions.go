@@ -0,0 +1,67 @@
+package test_functions
+
+import (
+	"github.com/saskamegaprogrammist/optimization_methods/la_methods"
+	"math"
+)
+
+func Rosenbrock(xs []float64) float64 {
+	var sum float64
+	for i := 0; i+1 < len(xs); i++ {
+		sum += 100*math.Pow(xs[i+1]-xs[i]*xs[i], 2) + math.Pow(1-xs[i], 2)
+	}
+	return sum
+}
+
+func RosenbrockGradient(dimension int) []func(xs []float64) float64 {
+	gradient := make([]func(xs []float64) float64, dimension)
+	for j := range gradient {
+		j := j
+		gradient[j] = func(xs []float64) float64 {
+			var d float64
+			if j+1 < len(xs) {
+				d += -400*xs[j]*(xs[j+1]-xs[j]*xs[j]) - 2*(1-xs[j])
+			}
+			if j > 0 {
+				d += 200 * (xs[j] - xs[j-1]*xs[j-1])
+			}
+			return d
+		}
+	}
+	return gradient
+}
+
+func RosenbrockHessian(xs []float64) la_methods.Matrix {
+	n := len(xs)
+	points := make([][]float64, n)
+	for j := range points {
+		points[j] = make([]float64, n)
+		if j+1 < n {
+			points[j][j] += 1200*xs[j]*xs[j] - 400*xs[j+1] + 2
+			points[j][j+1] = -400 * xs[j]
+		}
+		if j > 0 {
+			points[j][j] += 200
+			points[j][j-1] = -400 * xs[j-1]
+		}
+	}
+	var hessian la_methods.Matrix
+	hessian.InitWithPoints(n, n, points)
+	return hessian
+}
+
+func Sphere(xs []float64) float64 {
+	var sum float64
+	for _, x := range xs {
+		sum += x * x
+	}
+	return sum
+}
+
+func Rastrigin(xs []float64) float64 {
+	sum := 10 * float64(len(xs))
+	for _, x := range xs {
+		sum += x*x - 10*math.Cos(2*math.Pi*x)
+	}
+	return sum
+}